@@ -3,7 +3,6 @@ package cards
 import (
 	"fmt"
 	"math/rand"
-	"slices"
 
 	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
 )
@@ -77,8 +76,8 @@ var CardRankString = map[CardRank]string{
 }
 
 type Card struct {
-	Suite CardSuite
-	Rank  CardRank
+	Suite CardSuite `json:"suite"`
+	Rank  CardRank  `json:"rank"`
 }
 
 func (self Card) Str() string {
@@ -145,31 +144,49 @@ var UNSHUFFLED_DECK = []Card{
 	Card{Suite: CLUBS, Rank: KING},
 }
 
+// Spanish 21 is played with 48 card decks, the 10s are taken out, the face cards are not.
+var SPANISH_DECK = createSpanishDeck()
+
+func createSpanishDeck() []Card {
+	var deck []Card = []Card{}
+	for i := 0; i < len(UNSHUFFLED_DECK); i++ {
+		if UNSHUFFLED_DECK[i].Rank != TEN {
+			deck = append(deck, UNSHUFFLED_DECK[i])
+		}
+	}
+	return deck
+}
+
+func DeckForRules(rules *house_rules.HouseRules) []Card {
+	if rules.RemoveTens {
+		return SPANISH_DECK
+	}
+	return UNSHUFFLED_DECK
+}
+
 func CreateShoe() []Card {
-	var shoe []Card = []Card{}
-
-	switch house_rules.DECKS_IN_SHOE {
-	case 1:
-		shoe = UNSHUFFLED_DECK
-	case 2:
-		shoe = slices.Concat(UNSHUFFLED_DECK, UNSHUFFLED_DECK)
-	case 3:
-		shoe = slices.Concat(UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK)
-	case 4:
-		shoe = slices.Concat(UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK)
-	case 5:
-		shoe = slices.Concat(UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK)
-	case 6:
-		shoe = slices.Concat(UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK)
-	case 7:
-		shoe = slices.Concat(UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK)
-	case 8:
-		shoe = slices.Concat(UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK, UNSHUFFLED_DECK)
-	default:
-		shoe = UNSHUFFLED_DECK
+	return CreateShoeWithRandom(house_rules.DECKS_IN_SHOE, randomGenerator)
+}
+
+// CreateShoeWithRandom() lets a caller (eg a simulation job with its own seed)
+// own the random generator, since a *rand.Rand is not safe for concurrent use.
+func CreateShoeWithRandom(decksInShoe int, random *rand.Rand) []Card {
+	return CreateShoeFromDeck(UNSHUFFLED_DECK, decksInShoe, random)
+}
+
+// CreateShoeFromDeck() shuffles decksInShoe copies of the deck, eg SPANISH_DECK.
+func CreateShoeFromDeck(deck []Card, decksInShoe int, random *rand.Rand) []Card {
+	if decksInShoe < 1 || decksInShoe > 8 {
+		decksInShoe = 1
+	}
+
+	// copy the decks, so that shuffling never reorders the deck itself
+	var shoe []Card = make([]Card, 0, decksInShoe*len(deck))
+	for i := 0; i < decksInShoe; i++ {
+		shoe = append(shoe, deck...)
 	}
 
-	ShuffleShoe(shoe)
+	ShuffleShoeWithRandom(shoe, random)
 
 	return shoe
 }
@@ -181,9 +198,22 @@ var seed int64 = 42
 var source rand.Source = rand.NewSource(seed)
 var randomGenerator *rand.Rand = rand.New(source)
 
+// SharedRandom() is the generator ShuffleShoe() uses, for a caller without one of its own
+func SharedRandom() *rand.Rand {
+	return randomGenerator
+}
+
+func CreateRandom(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
 func ShuffleShoe(shoe []Card) {
+	ShuffleShoeWithRandom(shoe, randomGenerator)
+}
+
+func ShuffleShoeWithRandom(shoe []Card, random *rand.Rand) {
 	for i := 0; i < len(shoe); i++ {
-		randomGenerator.Shuffle(
+		random.Shuffle(
 			len(shoe),
 			func(i, j int) {
 				shoe[i], shoe[j] = shoe[j], shoe[i]
@@ -0,0 +1,94 @@
+package cards
+
+import (
+	"math/rand"
+
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// A shelf CSM, eg a ShuffleMaster One2Six: every card loaded drops onto one
+// of the machine's shelves at random, on top of the cards already there or
+// underneath them.  The dealer deals from a tray, and when it runs low the
+// machine drops the cards of a shelf picked at random into it.  A card
+// loaded can come out again a few rounds later, or much later, and the cards
+// of a shelf come out together, so the cards are not perfectly shuffled.
+
+const CSM_SHELVES int = 20
+
+// the machine keeps at least this many cards ready in the tray
+const CSM_TRAY_CARDS int = 10
+
+type ShelfShuffler struct {
+	Shelves [][]Card
+	// the cards ready to deal, in dealing order
+	Tray      []Card
+	TrayCards int
+}
+
+func CreateShelfShuffler(numShelves int, trayCards int) *ShelfShuffler {
+	var shuffler ShelfShuffler = ShelfShuffler{
+		Shelves:   make([][]Card, numShelves),
+		Tray:      []Card{},
+		TrayCards: trayCards,
+	}
+	return &shuffler
+}
+
+func (self *ShelfShuffler) Kind() house_rules.ShufflerKind {
+	return house_rules.SHUFFLE_CSM
+}
+
+// Shuffle() starts the machine over with the cards of the shoe.
+func (self *ShelfShuffler) Shuffle(shoe []Card, random *rand.Rand) {
+	for i := 0; i < len(self.Shelves); i++ {
+		self.Shelves[i] = self.Shelves[i][:0]
+	}
+	self.Tray = self.Tray[:0]
+	self.Load(shoe, random)
+}
+
+// the discards are loaded while the next round is dealt, the table never waits
+func (self *ShelfShuffler) ShuffleSeconds(numCards int) float64 {
+	return 0
+}
+
+func (self *ShelfShuffler) Load(cards []Card, random *rand.Rand) {
+	for i := 0; i < len(cards); i++ {
+		shelf := random.Intn(len(self.Shelves))
+		if random.Intn(2) == 0 {
+			self.Shelves[shelf] = append(self.Shelves[shelf], cards[i])
+		} else {
+			self.Shelves[shelf] = append([]Card{cards[i]}, self.Shelves[shelf]...)
+		}
+	}
+}
+
+func (self *ShelfShuffler) Deal(random *rand.Rand) Card {
+	for len(self.Tray) < self.TrayCards && self.shelvedCards() > 0 {
+		// a shelf picked at random, or the next one along that has cards
+		shelf := random.Intn(len(self.Shelves))
+		for len(self.Shelves[shelf]) == 0 {
+			shelf = (shelf + 1) % len(self.Shelves)
+		}
+		self.Tray = append(self.Tray, self.Shelves[shelf]...)
+		self.Shelves[shelf] = self.Shelves[shelf][:0]
+	}
+	if len(self.Tray) == 0 {
+		panic("the continuous shuffling machine has run out of cards")
+	}
+	var card Card = self.Tray[0]
+	self.Tray = self.Tray[1:]
+	return card
+}
+
+func (self *ShelfShuffler) shelvedCards() int {
+	numCards := 0
+	for i := 0; i < len(self.Shelves); i++ {
+		numCards += len(self.Shelves[i])
+	}
+	return numCards
+}
+
+func (self *ShelfShuffler) NumCards() int {
+	return self.shelvedCards() + len(self.Tray)
+}
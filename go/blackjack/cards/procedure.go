@@ -0,0 +1,177 @@
+package cards
+
+import (
+	"math/rand"
+
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// ProcedureShuffler is a dealer shuffling by hand, step by step, see
+// house_rules.ShuffleStep.  The cards end up clumped the way a hand shuffle
+// leaves them, not perfectly shuffled.
+type ProcedureShuffler struct {
+	kind  house_rules.ShufflerKind
+	Steps []house_rules.ShuffleStep
+	// where the last shuffle was cut, in order, the cuts of all the cards
+	// are made in front of the players, the ones of zones or grabs are not
+	Cuts []int
+}
+
+func CreateProcedureShuffler(kind house_rules.ShufflerKind, steps []house_rules.ShuffleStep) *ProcedureShuffler {
+	var shuffler ProcedureShuffler = ProcedureShuffler{
+		kind:  kind,
+		Steps: steps,
+		Cuts:  []int{},
+	}
+	return &shuffler
+}
+
+func (self *ProcedureShuffler) Kind() house_rules.ShufflerKind {
+	return self.kind
+}
+
+func (self *ProcedureShuffler) Shuffle(shoe []Card, random *rand.Rand) {
+	self.Cuts = self.Cuts[:0]
+	for i := 0; i < len(self.Steps); i++ {
+		var step house_rules.ShuffleStep = self.Steps[i]
+		if step.Action == house_rules.CUT && step.Zones == 0 && step.Grab == 0 {
+			for j := 0; j < step.TimesDone(); j++ {
+				self.Cuts = append(self.Cuts, Cut(shoe, random))
+			}
+			continue
+		}
+		ShuffleByStep(shoe, step, random)
+	}
+}
+
+// the seconds a dealer takes to do the action on a deck's worth of cards, or less
+var SHUFFLE_ACTION_SECONDS map[house_rules.ShuffleAction]float64 = map[house_rules.ShuffleAction]float64{
+	house_rules.RIFFLE: 6,
+	house_rules.STRIP:  5,
+	house_rules.CUT:    3,
+	house_rules.WASH:   30,
+}
+
+func (self *ProcedureShuffler) ShuffleSeconds(numCards int) float64 {
+	seconds := 0.0
+	for i := 0; i < len(self.Steps); i++ {
+		var step house_rules.ShuffleStep = self.Steps[i]
+		packets := 1
+		if step.Zones > 0 {
+			packets = step.Zones
+		} else if step.Grab > 0 {
+			packets = (numCards + step.Grab - 1) / step.Grab
+		}
+		decksPerPacket := max(1, float64(numCards)/float64(packets)/52)
+		if step.Action == house_rules.CUT {
+			// one cut, whatever the number of cards
+			decksPerPacket = 1
+		}
+		seconds += SHUFFLE_ACTION_SECONDS[step.Action] * decksPerPacket * float64(packets*step.TimesDone())
+	}
+	return seconds
+}
+
+// ShuffleByStep() does the step on the cards, in place
+func ShuffleByStep(cards []Card, step house_rules.ShuffleStep, random *rand.Rand) {
+	if step.Zones > 0 {
+		for zone := 0; zone < step.Zones; zone++ {
+			shuffleTimes(cards[zone*len(cards)/step.Zones:(zone+1)*len(cards)/step.Zones], step, random)
+		}
+		return
+	}
+	if step.Grab == 0 {
+		shuffleTimes(cards, step, random)
+		return
+	}
+
+	// grab half from the top half, half from the bottom half, shuffle them
+	// and put them on the finished pile, the first grab ends up at the bottom
+	var top []Card = append([]Card{}, cards[:len(cards)/2]...)
+	var bottom []Card = append([]Card{}, cards[len(cards)/2:]...)
+	finished := len(cards)
+	for len(top) > 0 || len(bottom) > 0 {
+		fromTop := min(step.Grab/2, len(top))
+		fromBottom := min(step.Grab-fromTop, len(bottom))
+		if fromTop+fromBottom == 0 {
+			// a grab of one card
+			fromTop = min(1, len(top))
+			fromBottom = 1 - fromTop
+		}
+		var packet []Card = append(append([]Card{}, top[:fromTop]...), bottom[:fromBottom]...)
+		top = top[fromTop:]
+		bottom = bottom[fromBottom:]
+		shuffleTimes(packet, step, random)
+		finished -= len(packet)
+		copy(cards[finished:], packet)
+	}
+}
+
+func shuffleTimes(cards []Card, step house_rules.ShuffleStep, random *rand.Rand) {
+	for i := 0; i < step.TimesDone(); i++ {
+		switch step.Action {
+		case house_rules.RIFFLE:
+			Riffle(cards, random)
+		case house_rules.STRIP:
+			Strip(cards, random)
+		case house_rules.CUT:
+			Cut(cards, random)
+		case house_rules.WASH:
+			Wash(cards, random)
+		}
+	}
+}
+
+// Riffle() is a Gilbert-Shannon-Reeds riffle: the cut is binomial, each card
+// is a coin flip, then the cards drop from the two halves, from each with a
+// chance that goes with the number of cards left in it.
+func Riffle(cards []Card, random *rand.Rand) {
+	cut := 0
+	for i := 0; i < len(cards); i++ {
+		cut += random.Intn(2)
+	}
+	var left []Card = append([]Card{}, cards[:cut]...)
+	var right []Card = append([]Card{}, cards[cut:]...)
+	for i := 0; i < len(cards); i++ {
+		if random.Intn(len(left)+len(right)) < len(left) {
+			cards[i] = left[0]
+			left = left[1:]
+		} else {
+			cards[i] = right[0]
+			right = right[1:]
+		}
+	}
+}
+
+// Strip() takes packets of about a fifth of the cards off the top, one on top
+// of the other, so the packets end up in the reverse order.
+func Strip(cards []Card, random *rand.Rand) {
+	var stripped []Card = make([]Card, 0, len(cards))
+	var rest []Card = cards
+	for len(rest) > 0 {
+		size := max(1, len(cards)/5+random.Intn(len(cards)/10+1)-len(cards)/20)
+		size = min(size, len(rest))
+		stripped = append(append([]Card{}, rest[:size]...), stripped...)
+		rest = rest[size:]
+	}
+	copy(cards, stripped)
+}
+
+// Cut() cuts somewhere in the middle half of the cards, the cards that were
+// on top of the cut end up at the bottom, returns how many of them there were.
+func Cut(cards []Card, random *rand.Rand) int {
+	if len(cards) < 2 {
+		return 0
+	}
+	cut := len(cards)/4 + random.Intn(len(cards)/2+1)
+	var cutCards []Card = append(append([]Card{}, cards[cut:]...), cards[:cut]...)
+	copy(cards, cutCards)
+	return cut
+}
+
+// Wash() mixes the cards about on the table, about as good as a perfect shuffle.
+func Wash(cards []Card, random *rand.Rand) {
+	random.Shuffle(len(cards), func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
+	})
+}
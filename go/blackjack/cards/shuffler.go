@@ -0,0 +1,66 @@
+package cards
+
+import (
+	"fmt"
+	"math/rand"
+
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// A Shuffler mixes the cards of a shoe: the dealer by hand, or a machine.
+type Shuffler interface {
+	Kind() house_rules.ShufflerKind
+	// Shuffle() mixes the whole shoe, eg once the cut card has come out
+	Shuffle(shoe []Card, random *rand.Rand)
+	// ShuffleSeconds() is how long the table waits while numCards are shuffled
+	ShuffleSeconds(numCards int) float64
+}
+
+// A ContinuousShuffler is a machine the discards go back into after every
+// round, the cards are dealt from the machine instead of from a shoe with a
+// cut card.  Shuffle() empties the machine and loads the whole shoe into it.
+type ContinuousShuffler interface {
+	Shuffler
+	// Load() puts cards into the machine, eg the discards of a round
+	Load(cards []Card, random *rand.Rand)
+	Deal(random *rand.Rand) Card
+	// NumCards() is the cards in the machine, the ones ready to deal included
+	NumCards() int
+}
+
+// CreateShuffler() is the house rules' Shuffler, a hand shuffle follows the rules' ShuffleSteps()
+func CreateShuffler(rules *house_rules.HouseRules) (Shuffler, error) {
+	switch rules.Shuffler {
+	case house_rules.SHUFFLE_RANDOM, "":
+		return &RandomShuffler{}, nil
+	case house_rules.SHUFFLE_CSM:
+		return CreateShelfShuffler(CSM_SHELVES, CSM_TRAY_CARDS), nil
+	}
+	if steps := rules.ShuffleSteps(); len(steps) > 0 {
+		return CreateProcedureShuffler(rules.Shuffler, steps), nil
+	}
+	return nil, fmt.Errorf("unknown shuffler %q", rules.Shuffler)
+}
+
+//
+// RandomShuffler
+//
+
+// the dealer swaps in a shoe shuffled by a batch shuffling machine during
+// play, then it is cut: about a minute whatever the number of decks
+const RANDOM_SHUFFLE_SECONDS float64 = 60
+
+// RandomShuffler is a perfect shuffle, every order of the cards is as likely.
+type RandomShuffler struct{}
+
+func (self *RandomShuffler) Kind() house_rules.ShufflerKind {
+	return house_rules.SHUFFLE_RANDOM
+}
+
+func (self *RandomShuffler) Shuffle(shoe []Card, random *rand.Rand) {
+	ShuffleShoeWithRandom(shoe, random)
+}
+
+func (self *RandomShuffler) ShuffleSeconds(numCards int) float64 {
+	return RANDOM_SHUFFLE_SECONDS
+}
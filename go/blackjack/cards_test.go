@@ -1,6 +1,7 @@
 package main
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
@@ -174,6 +175,21 @@ func TestCreateShoe(t *testing.T) {
 	)
 }
 
+func TestSpanishDeck(t *testing.T) {
+	assert.Equal(t, 48, len(cards.SPANISH_DECK), "a Spanish deck has 48 cards")
+	rankCounts := map[cards.CardRank]int{}
+	for i := 0; i < len(cards.SPANISH_DECK); i++ {
+		rankCounts[cards.SPANISH_DECK[i].Rank]++
+	}
+	assert.Equal(t, 0, rankCounts[cards.TEN], "the 10s are taken out")
+	assert.Equal(t, 4, rankCounts[cards.KING], "the face cards stay in")
+
+	var rules *house_rules.HouseRules = house_rules.CreateSpanish21Rules()
+	var shoe []cards.Card = cards.CreateShoeFromDeck(cards.DeckForRules(rules), rules.DecksInShoe, cards.CreateRandom(1))
+	assert.Equal(t, rules.DecksInShoe*48, len(shoe), "a shoe of Spanish decks")
+	assert.Equal(t, 52, len(cards.UNSHUFFLED_DECK), "the regular deck is left alone")
+}
+
 
 func TestDisplayShoe(t *testing.T) {
 	var shoe []cards.Card = cards.CreateShoe()
@@ -192,3 +208,122 @@ func TestDisplayShoe(t *testing.T) {
 	)
 	// cards.DisplayShoe(shoe)
 }
+
+func TestCreateShuffler(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	for _, kind := range house_rules.ShufflerKinds() {
+		rules.Shuffler = kind
+		shuffler, err := cards.CreateShuffler(rules)
+		assert.NoError(t, err, kind)
+		assert.Equal(t, kind, shuffler.Kind())
+	}
+	rules.Shuffler = "riffle-and-pray"
+	_, err := cards.CreateShuffler(rules)
+	assert.Error(t, err)
+}
+
+func TestShelfShuffler(t *testing.T) {
+	var random *rand.Rand = cards.CreateRandom(7)
+	var csm *cards.ShelfShuffler = cards.CreateShelfShuffler(cards.CSM_SHELVES, cards.CSM_TRAY_CARDS)
+	csm.Shuffle(cards.UNSHUFFLED_DECK, random)
+	assert.Equal(t, 52, csm.NumCards(), "loaded with the deck")
+
+	var dealt []cards.Card = []cards.Card{}
+	for i := 0; i < 20; i++ {
+		dealt = append(dealt, csm.Deal(random))
+	}
+	assert.Equal(t, 32, csm.NumCards())
+	assert.GreaterOrEqual(t, len(csm.Tray), 1)
+	assert.NotEqual(t, cards.UNSHUFFLED_DECK[:20], dealt, "shuffled")
+
+	csm.Load(dealt, random)
+	assert.Equal(t, 52, csm.NumCards(), "the discards go back in")
+	seen := map[cards.Card]int{}
+	for i := 0; i < 52; i++ {
+		seen[csm.Deal(random)]++
+	}
+	assert.Equal(t, 52, len(seen), "every card comes out once")
+	assert.Equal(t, 0, csm.NumCards())
+	assert.Panics(t, func() { csm.Deal(random) }, "nothing left to deal")
+}
+
+// deckOrder() is where each card of the deck ended up
+func deckOrder(deck []cards.Card) map[cards.Card]int {
+	order := map[cards.Card]int{}
+	for i := 0; i < len(deck); i++ {
+		order[deck[i]] = i
+	}
+	return order
+}
+
+// risingSequences() counts the runs of the unshuffled deck, in order, that
+// are interleaved in the deck, 1 => not shuffled at all
+func risingSequences(deck []cards.Card) int {
+	var order map[cards.Card]int = deckOrder(deck)
+	sequences := 1
+	for i := 1; i < len(cards.UNSHUFFLED_DECK); i++ {
+		if order[cards.UNSHUFFLED_DECK[i]] < order[cards.UNSHUFFLED_DECK[i-1]] {
+			sequences++
+		}
+	}
+	return sequences
+}
+
+func TestHandShuffles(t *testing.T) {
+	var random *rand.Rand = cards.CreateRandom(7)
+	var deck []cards.Card = append([]cards.Card{}, cards.UNSHUFFLED_DECK...)
+
+	cards.Riffle(deck, random)
+	assert.Equal(t, 52, len(deckOrder(deck)), "the same cards")
+	assert.LessOrEqual(t, risingSequences(deck), 2, "one riffle interleaves two runs")
+	cards.Riffle(deck, random)
+	assert.LessOrEqual(t, risingSequences(deck), 4, "two riffles, four runs")
+
+	deck = append([]cards.Card{}, cards.UNSHUFFLED_DECK...)
+	cards.Strip(deck, random)
+	assert.Equal(t, 52, len(deckOrder(deck)))
+	assert.NotEqual(t, cards.UNSHUFFLED_DECK[0], deck[0], "the top packet ends up at the bottom")
+	assert.Greater(t, risingSequences(deck), 2, "a run for every packet")
+
+	deck = append([]cards.Card{}, cards.UNSHUFFLED_DECK...)
+	cutCards := cards.Cut(deck, random)
+	var order map[cards.Card]int = deckOrder(deck)
+	cut := order[cards.UNSHUFFLED_DECK[0]]
+	assert.True(t, cut >= 13 && cut <= 39, "cut in the middle half")
+	assert.Equal(t, 52-cutCards, cut, "the cards above the cut go to the bottom")
+	for i := 0; i < 52; i++ {
+		assert.Equal(t, cards.UNSHUFFLED_DECK[i], deck[(i+cut)%52], "a cut keeps the order")
+	}
+}
+
+func TestProcedureShuffler(t *testing.T) {
+	var random *rand.Rand = cards.CreateRandom(7)
+	var shoe []cards.Card = cards.CreateShoeFromDeck(cards.UNSHUFFLED_DECK, 2, random)
+	var before []cards.Card = append([]cards.Card{}, shoe...)
+
+	// the cards never leave their zone
+	cards.ShuffleByStep(shoe, house_rules.ShuffleStep{Action: house_rules.WASH, Zones: 4}, random)
+	for zone := 0; zone < 4; zone++ {
+		assert.ElementsMatch(t, before[zone*26:(zone+1)*26], shoe[zone*26:(zone+1)*26], "zone %v", zone)
+	}
+	assert.NotEqual(t, before, shoe)
+
+	// grab 26 cards, 13 from each half, the first grab ends up at the bottom
+	before = append([]cards.Card{}, shoe...)
+	cards.ShuffleByStep(shoe, house_rules.ShuffleStep{Action: house_rules.RIFFLE, Grab: 26}, random)
+	assert.ElementsMatch(t, append(append([]cards.Card{}, before[:13]...), before[52:65]...), shoe[78:], "the first grab")
+	assert.ElementsMatch(t, before, shoe)
+
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	rules.Shuffler = house_rules.SHUFFLE_CASINO
+	shuffler, _ := cards.CreateShuffler(rules)
+	shuffler.Shuffle(shoe, random)
+	assert.ElementsMatch(t, before, shoe)
+	assert.Equal(t, 1, len(shuffler.(*cards.ProcedureShuffler).Cuts), "the cut in front of the players")
+	assert.Greater(t, shuffler.ShuffleSeconds(312), cards.RANDOM_SHUFFLE_SECONDS, "the dealer takes longer than a machine")
+
+	rules.ShuffleProcedure = []house_rules.ShuffleStep{{Action: house_rules.WASH}}
+	shuffler, _ = cards.CreateShuffler(rules)
+	assert.Equal(t, rules.ShuffleProcedure, shuffler.(*cards.ProcedureShuffler).Steps, "the dealer's own shuffle")
+	assert.Equal(t, house_rules.SHUFFLE_CASINO, shuffler.Kind())
+}
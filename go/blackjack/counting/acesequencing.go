@@ -0,0 +1,58 @@
+package counting
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+)
+
+// An ace sequencer remembers the card that came out right before each ace,
+// the ace's key card.  A hand shuffle tends to leave cards that were next
+// to each other close together, so when a key card comes out at the end of
+// a round the ace is likely to come out early in the next one, and the
+// sequencer bets big.  A perfect shuffle leaves nothing to sequence.
+
+// a key card among the last cards of a round, the ace is expected next round
+const SEQUENCING_CARDS int = 2
+
+// AceSequencer hears the game through the CountingStrategy it works for.
+type AceSequencer struct {
+	// key card => the aces it came out in front of in the last shoe, less
+	// the times it has come out again since
+	KeyCards map[cards.Card]int
+	dealt    dealtCards
+	// where the last key card came out, -1 => none since the shuffle
+	keyCard int
+}
+
+func CreateAceSequencer() *AceSequencer {
+	var sequencer AceSequencer = AceSequencer{
+		KeyCards: map[cards.Card]int{},
+		dealt:    dealtCards{Cards: []cards.Card{}, holeCard: -1},
+		keyCard:  -1,
+	}
+	return &sequencer
+}
+
+func (self *AceSequencer) OnGameEvent(event game.GameEvent) {
+	switch event.Type {
+	case game.SHOE_SHUFFLED:
+		self.KeyCards = map[cards.Card]int{}
+		for i := 1; i < len(self.dealt.Cards); i++ {
+			if self.dealt.Cards[i].Rank == cards.ACE && self.dealt.Cards[i-1] != (cards.Card{}) {
+				self.KeyCards[self.dealt.Cards[i-1]]++
+			}
+		}
+		self.keyCard = -1
+	case game.CARD_DEALT:
+		if self.KeyCards[*event.Card] > 0 {
+			self.KeyCards[*event.Card]--
+			self.keyCard = len(self.dealt.Cards)
+		}
+	}
+	self.dealt.OnGameEvent(event)
+}
+
+// AceExpected() is true when a key card is among the last cards dealt.
+func (self *AceSequencer) AceExpected() bool {
+	return self.keyCard >= 0 && self.keyCard >= len(self.dealt.Cards)-SEQUENCING_CARDS
+}
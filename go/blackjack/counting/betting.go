@@ -0,0 +1,368 @@
+package counting
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
+)
+
+// A counter plays the hands like everyone else and makes the money on the
+// bets, small when the cards to come are poor, big when they are rich.
+// How the counter knows what is to come is the betting:
+//     hi-lo             the true count, see HiLoCounter
+//     shuffle-tracking  where the slugs of high cards went in the shuffle, see ShuffleTracker
+//     ace-sequencing    which card an ace came after, see AceSequencer
+
+const (
+	HI_LO_BETTING    string = "hi-lo"
+	SHUFFLE_TRACKING string = "shuffle-tracking"
+	ACE_SEQUENCING   string = "ace-sequencing"
+)
+
+func BettingNames() []string {
+	return []string{HI_LO_BETTING, SHUFFLE_TRACKING, ACE_SEQUENCING}
+}
+
+// Whether the counter plays every round, the wonging:
+//     play-all   plays every round of the shoe
+//     wong-in    watches from the start of the shoe, back counting, plays
+//                once the true count reaches WongIn, leaves when it falls below WongOut
+//     wong-out   plays from the start of the shoe, leaves when the true count
+//                falls below WongOut, comes back when it reaches WongIn
+// A wonger who has left can not come back with no mid-shoe entry, see house_rules.NoMidShoeEntry.
+
+const (
+	PLAY_ALL string = "play-all"
+	WONG_IN  string = "wong-in"
+	WONG_OUT string = "wong-out"
+)
+
+func WongingNames() []string {
+	return []string{PLAY_ALL, WONG_IN, WONG_OUT}
+}
+
+// the true counts a wonger enters and leaves at, by default
+const WONG_IN_COUNT float64 = 2
+const WONG_OUT_COUNT float64 = -1
+
+// the bet ramp: one unit, ie the player's bets, at a true count of 1 or
+// less, a unit more for every true count above that, up to BET_SPREAD units
+const BET_SPREAD int = 8
+
+func BetUnits(trueCount float64) int {
+	return min(max(int(math.Floor(trueCount)), 1), BET_SPREAD)
+}
+
+// CountingStrategy is a player strategy that bets by the cards seen, the
+// hands are played by the strategy it wraps.  It is a GameListener too,
+// add it to the game so that it sees the cards.
+type CountingStrategy struct {
+	Play    strategy.PlayerStrategy
+	Betting string
+	// the bets of one unit
+	Bets []int
+	// the player whose hands are settled in Results
+	PlayerName string
+	Counter    *HiLoCounter
+	Tracker    *ShuffleTracker
+	Sequencer  *AceSequencer
+	Results    *BettingResults
+	// see WongingNames(), WongIn is never below WongOut
+	Wonging string
+	WongIn  float64
+	WongOut float64
+	// by master hand, the bets made this round and what plain Hi-Lo would have bet
+	roundBets []int
+	hiLoBets  []int
+	// the most a bet can grow to in a round, by splitting, doubling and
+	// insuring, and whether the bankroll covered that for both bets this round
+	exposure int
+	compared bool
+	// bet the last round, ie is at the table
+	seated bool
+}
+
+// BettingResults is how the bets did next to the bets plain Hi-Lo would
+// have made on the same hands, ie on the same shoes played the same way.
+//
+// The plain Hi-Lo hands are not played, a hand's result is scaled to the
+// Hi-Lo bet instead.  That assumes the hand plays out the same on either
+// bet, which is not so when the bankroll stops one of them splitting or
+// doubling, so the rounds the bankroll could limit are left out of the
+// comparison: the Compared and HiLo fields are over the rest.
+type BettingResults struct {
+	Betting  string      `json:"betting"`
+	Wonging  string      `json:"wonging"`
+	Rounds   int         `json:"rounds"`
+	Wagered  int         `json:"wagered"`
+	Proceeds money.Money `json:"proceeds"`
+	// the rounds the bankroll covered every split and double of both bets
+	// on, and what the bets made in them did
+	RoundsCompared   int         `json:"rounds_compared"`
+	ComparedWagered  int         `json:"compared_wagered"`
+	ComparedProceeds money.Money `json:"compared_proceeds"`
+	// what the hands would have paid on plain Hi-Lo bets, to the cent or so
+	HiLoWagered  int         `json:"hi_lo_wagered"`
+	HiLoProceeds money.Money `json:"hi_lo_proceeds"`
+	// rounds bet bigger or smaller than plain Hi-Lo would have
+	RoundsBetHigher int `json:"rounds_bet_higher"`
+	RoundsBetLower  int `json:"rounds_bet_lower"`
+	// PlayerEdge(), PlainHiLoEdge() and the edge gained over plain Hi-Lo,
+	// ComparedEdge() - PlainHiLoEdge()
+	Edge       float64 `json:"edge"`
+	HiLoEdge   float64 `json:"hi_lo_edge"`
+	EdgeGained float64 `json:"edge_gained"`
+}
+
+func CreateCountingStrategy(
+	betting string,
+	playerName string,
+	bets []int,
+	playStrategy strategy.PlayerStrategy,
+	rules *house_rules.HouseRules,
+) (*CountingStrategy, error) {
+	var countingStrategy CountingStrategy = CountingStrategy{
+		Play:       playStrategy,
+		Betting:    betting,
+		Bets:       bets,
+		PlayerName: playerName,
+		Counter:    CreateHiLoCounter(rules.DecksInShoe),
+		Results:    &BettingResults{Betting: betting, Wonging: PLAY_ALL},
+		Wonging:    PLAY_ALL,
+		WongIn:     WONG_IN_COUNT,
+		WongOut:    WONG_OUT_COUNT,
+		roundBets:  []int{},
+		hiLoBets:   []int{},
+		// every split hand doubled and re-doubled, plus the insurance
+		exposure: (rules.SplitsPerHand+1)*(1<<(rules.Redoubles+1)) + 1,
+	}
+	// the count is what the player saw from their seat
+	countingStrategy.Counter.Seat = playerName
+	countingStrategy.Counter.CardsPerDeck = rules.CardsPerDeck()
+	switch betting {
+	case HI_LO_BETTING:
+	case SHUFFLE_TRACKING:
+		countingStrategy.Tracker = CreateShuffleTracker(rules)
+	case ACE_SEQUENCING:
+		countingStrategy.Sequencer = CreateAceSequencer()
+	default:
+		return nil, fmt.Errorf("unknown betting %q", betting)
+	}
+	return &countingStrategy, nil
+}
+
+func (self *CountingStrategy) Name() string {
+	return self.Play.Name()
+}
+
+func (self *CountingStrategy) DeterminePlay(
+	dealerTopCard cards.Card,
+	playerHand strategy.PlayerHandInterface,
+	legalActions []strategy.PlayerDecision,
+) strategy.PlayerDecision {
+	return self.Play.DeterminePlay(dealerTopCard, playerHand, legalActions)
+}
+
+// SetWonging() is for a counter who does not play every round, see WongingNames().
+func (self *CountingStrategy) SetWonging(wonging string, wongIn float64, wongOut float64) error {
+	if !slices.Contains(WongingNames(), wonging) {
+		return fmt.Errorf("unknown wonging %q", wonging)
+	}
+	if wongIn < wongOut {
+		return fmt.Errorf("wong in %v is below wong out %v, the wonger would never sit still", wongIn, wongOut)
+	}
+	self.Wonging = wonging
+	self.Results.Wonging = wonging
+	self.WongIn = wongIn
+	self.WongOut = wongOut
+	self.seated = wonging == WONG_OUT
+	return nil
+}
+
+// TrueCount() is the true count the betting goes by.
+func (self *CountingStrategy) TrueCount() float64 {
+	if self.Betting == SHUFFLE_TRACKING {
+		return self.Tracker.TrueCount(self.Counter)
+	}
+	return self.Counter.TrueCount()
+}
+
+// Units() is how many units the betting says to bet on the next round.
+func (self *CountingStrategy) Units() int {
+	if self.Betting == ACE_SEQUENCING && self.Sequencer.AceExpected() {
+		return BET_SPREAD
+	}
+	return BetUnits(self.TrueCount())
+}
+
+// PlaysRound() stays at the table while the true count is WongOut or more,
+// and sits down once it is WongIn or more.
+func (self *CountingStrategy) PlaysRound() bool {
+	seated := self.seated
+	// seated again when the bets are made
+	self.seated = false
+	switch self.Wonging {
+	case WONG_IN, WONG_OUT:
+		if seated {
+			return self.TrueCount() >= self.WongOut
+		}
+		return self.TrueCount() >= self.WongIn
+	}
+	return true
+}
+
+func (self *CountingStrategy) DetermineBets(bankroll int, sideBets int) []int {
+	units := self.Units()
+	hiLoUnits := BetUnits(self.Counter.TrueCount())
+	unitTotal := 0
+	for i := 0; i < len(self.Bets); i++ {
+		unitTotal += self.Bets[i]
+	}
+	// the bet comes down to what the bankroll covers, side bets included
+	bankroll -= sideBets * len(self.Bets)
+	for units > 1 && units*unitTotal > bankroll {
+		units--
+	}
+	// plain Hi-Lo plays the same bankroll
+	for hiLoUnits > 1 && hiLoUnits*unitTotal > bankroll {
+		hiLoUnits--
+	}
+	if units*unitTotal > bankroll {
+		// not even a unit left, the counter leaves the table
+		return []int{}
+	}
+	self.seated = true
+
+	self.roundBets = make([]int, len(self.Bets))
+	self.hiLoBets = make([]int, len(self.Bets))
+	for i := 0; i < len(self.Bets); i++ {
+		self.roundBets[i] = self.Bets[i] * units
+		self.hiLoBets[i] = self.Bets[i] * hiLoUnits
+	}
+	self.Results.Rounds++
+	self.Results.Wagered += units * unitTotal
+	self.compared = max(units, hiLoUnits)*unitTotal*self.exposure <= bankroll
+	if self.compared {
+		self.Results.RoundsCompared++
+		self.Results.ComparedWagered += units * unitTotal
+		self.Results.HiLoWagered += hiLoUnits * unitTotal
+	}
+	if units > hiLoUnits {
+		self.Results.RoundsBetHigher++
+	} else if units < hiLoUnits {
+		self.Results.RoundsBetLower++
+	}
+	return self.roundBets
+}
+
+func (self *CountingStrategy) OnGameEvent(event game.GameEvent) {
+	// the trackers look at the cards seen before the count forgets them
+	if self.Tracker != nil {
+		self.Tracker.OnGameEvent(event)
+	}
+	if self.Sequencer != nil {
+		self.Sequencer.OnGameEvent(event)
+	}
+	self.Counter.OnGameEvent(event)
+	if event.Type == game.SHOE_SHUFFLED {
+		// a new shoe, the wong-out player starts it at the table
+		self.seated = self.Wonging == WONG_OUT
+	}
+
+	if event.Type == game.HAND_SETTLED && event.PlayerName == self.PlayerName && event.MasterHandIndex < len(self.roundBets) {
+		bet := int64(self.roundBets[event.MasterHandIndex])
+		hiLoBet := int64(self.hiLoBets[event.MasterHandIndex])
+		self.Results.Proceeds += event.Result
+		if self.compared {
+			// the bankroll covered both bets, the hand plays out the same
+			// whatever the bet and its result goes with the bet
+			self.Results.ComparedProceeds += event.Result
+			self.Results.HiLoProceeds += money.Cents(event.Result.Cents() * hiLoBet / bet)
+		}
+		self.Results.Edge = self.Results.PlayerEdge()
+		self.Results.HiLoEdge = self.Results.PlainHiLoEdge()
+		self.Results.EdgeGained = self.Results.ComparedEdge() - self.Results.HiLoEdge
+	}
+}
+
+// the strategy that plays the hands is asked what it would do
+
+func (self *CountingStrategy) TakeInsurance(dealerTopCard cards.Card, playerHand strategy.PlayerHandInterface) bool {
+	insuranceStrategy, ok := self.Play.(game.InsuranceStrategy)
+	return ok && insuranceStrategy.TakeInsurance(dealerTopCard, playerHand)
+}
+
+func (self *CountingStrategy) SurrenderEarly(dealerTopCard cards.Card, playerHand strategy.PlayerHandInterface) bool {
+	earlySurrenderStrategy, ok := self.Play.(game.EarlySurrenderStrategy)
+	return ok && earlySurrenderStrategy.SurrenderEarly(dealerTopCard, playerHand)
+}
+
+func (self *CountingStrategy) DoubleDownAmount(dealerTopCard cards.Card, playerHand strategy.PlayerHandInterface, maxAmount int) int {
+	doubleDownStrategy, ok := self.Play.(game.DoubleDownStrategy)
+	if !ok {
+		return maxAmount
+	}
+	return doubleDownStrategy.DoubleDownAmount(dealerTopCard, playerHand, maxAmount)
+}
+
+func (self *CountingStrategy) SwitchCards(dealerTopCard cards.Card, firstHand strategy.PlayerHandInterface, secondHand strategy.PlayerHandInterface) bool {
+	switchStrategy, ok := self.Play.(game.SwitchStrategy)
+	return ok && switchStrategy.SwitchCards(dealerTopCard, firstHand, secondHand)
+}
+
+func (self *CountingStrategy) DeterminePlayWithHoleCard(
+	dealerTopCard cards.Card,
+	dealerHoleCard cards.Card,
+	playerHand strategy.PlayerHandInterface,
+	legalActions []strategy.PlayerDecision,
+) strategy.PlayerDecision {
+	holeCardStrategy, ok := self.Play.(game.HoleCardStrategy)
+	if !ok {
+		return self.Play.DeterminePlay(dealerTopCard, playerHand, legalActions)
+	}
+	return holeCardStrategy.DeterminePlayWithHoleCard(dealerTopCard, dealerHoleCard, playerHand, legalActions)
+}
+
+func (self *CountingStrategy) DeterminePlayWithTell(
+	dealerTopCard cards.Card,
+	holeCardIsTen bool,
+	playerHand strategy.PlayerHandInterface,
+	legalActions []strategy.PlayerDecision,
+) strategy.PlayerDecision {
+	tellStrategy, ok := self.Play.(game.TellStrategy)
+	if !ok {
+		return self.Play.DeterminePlay(dealerTopCard, playerHand, legalActions)
+	}
+	return tellStrategy.DeterminePlayWithTell(dealerTopCard, holeCardIsTen, playerHand, legalActions)
+}
+
+// PlayerEdge() is what the bets returned per dollar wagered, negative => the house has the edge.
+func (self *BettingResults) PlayerEdge() float64 {
+	if self.Wagered == 0 {
+		return 0
+	}
+	return self.Proceeds.Float() / float64(self.Wagered)
+}
+
+// ComparedEdge() is the PlayerEdge() over the rounds compared with plain Hi-Lo.
+func (self *BettingResults) ComparedEdge() float64 {
+	if self.ComparedWagered == 0 {
+		return 0
+	}
+	return self.ComparedProceeds.Float() / float64(self.ComparedWagered)
+}
+
+// PlainHiLoEdge() is the PlayerEdge() plain Hi-Lo bets would have had, over
+// the rounds compared.
+func (self *BettingResults) PlainHiLoEdge() float64 {
+	if self.HiLoWagered == 0 {
+		return 0
+	}
+	return self.HiLoProceeds.Float() / float64(self.HiLoWagered)
+}
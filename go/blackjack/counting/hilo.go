@@ -0,0 +1,87 @@
+package counting
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+)
+
+// Card counting keeps track of the cards seen at the table since the last shuffle.
+// Hi-Lo, the count everyone learns first:
+//     2, 3, 4, 5, 6   +1
+//     7, 8, 9          0
+//     10s and Aces    -1
+// The running count divided by the decks not yet seen is the true count,
+// the higher it goes the better the shoe is for the player.
+
+func HiLoValue(card cards.Card) int {
+	value := cards.CardRankValue[card.Rank]
+	if value >= 2 && value <= 6 {
+		return 1
+	} else if value >= 7 && value <= 9 {
+		return 0
+	}
+	return -1
+}
+
+// HiLoCounter is a GameListener, add it to a game to have it count.
+type HiLoCounter struct {
+	DecksInShoe int
+	// a full deck unless set, eg 48 for a Spanish deck, see HouseRules.CardsPerDeck()
+	CardsPerDeck int
+	RunningCount int
+	CardsSeen    int
+	// "" => counts every card as it is turned over, otherwise counts what
+	// the player sitting there saw, once the round is over, see game.CARDS_SEEN
+	Seat string
+}
+
+func CreateHiLoCounter(decksInShoe int) *HiLoCounter {
+	var counter HiLoCounter = HiLoCounter{
+		DecksInShoe:  decksInShoe,
+		CardsPerDeck: 52,
+		RunningCount: 0,
+		CardsSeen:    0,
+	}
+	return &counter
+}
+
+func (self *HiLoCounter) Reset() {
+	self.RunningCount = 0
+	self.CardsSeen = 0
+}
+
+func (self *HiLoCounter) Count(card cards.Card) {
+	self.RunningCount += HiLoValue(card)
+	self.CardsSeen++
+}
+
+func (self *HiLoCounter) OnGameEvent(event game.GameEvent) {
+	switch event.Type {
+	case game.SHOE_SHUFFLED:
+		self.Reset()
+	case game.CARD_DEALT, game.HOLE_CARD_REVEALED:
+		// the hole card is counted when it is turned over, not when it is dealt
+		if self.Seat == "" {
+			self.Count(*event.Card)
+		}
+	case game.CARDS_SEEN:
+		if self.Seat != "" && event.PlayerName == self.Seat {
+			for i := 0; i < len(event.Cards); i++ {
+				self.Count(event.Cards[i])
+			}
+		}
+	}
+}
+
+// DecksRemaining() is never less than half a deck, nobody counts the last few cards.
+func (self *HiLoCounter) DecksRemaining() float64 {
+	decksRemaining := float64(self.DecksInShoe*self.CardsPerDeck-self.CardsSeen) / float64(self.CardsPerDeck)
+	if decksRemaining < 0.5 {
+		return 0.5
+	}
+	return decksRemaining
+}
+
+func (self *HiLoCounter) TrueCount() float64 {
+	return float64(self.RunningCount) / self.DecksRemaining()
+}
@@ -0,0 +1,246 @@
+package counting
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// A dealer shuffling by hand does the same steps every time, in front of
+// everyone, and a hand shuffle does not mix the cards all that well.  A
+// shuffle tracker remembers where the high cards were in the discards, eg
+// a slug of tens and aces near the end of the shoe, follows the slugs
+// through the dealer's shuffle and the cut, and bets big when a slug is
+// about to be dealt.  A perfect shuffle leaves nothing to track, the
+// tracker then bets the same as plain Hi-Lo.
+
+// the cards the tracker looks ahead to, a few rounds' worth
+const TRACKING_WINDOW int = 26
+
+// dealtCards are the cards dealt since the shuffle in the order they came
+// out of the shoe, the hole card is the zero card until it is turned over,
+// a burned card for good.
+type dealtCards struct {
+	Cards    []cards.Card
+	holeCard int
+}
+
+func (self *dealtCards) OnGameEvent(event game.GameEvent) {
+	switch event.Type {
+	case game.SHOE_SHUFFLED:
+		self.Cards = self.Cards[:0]
+		self.holeCard = -1
+	case game.CARD_DEALT:
+		self.Cards = append(self.Cards, *event.Card)
+	case game.HOLE_CARD_DEALT:
+		self.holeCard = len(self.Cards)
+		self.Cards = append(self.Cards, cards.Card{})
+	case game.CARD_BURNED:
+		self.Cards = append(self.Cards, cards.Card{})
+	case game.HOLE_CARD_REVEALED:
+		if self.holeCard >= 0 {
+			self.Cards[self.holeCard] = *event.Card
+			self.holeCard = -1
+		} else {
+			self.Cards = append(self.Cards, *event.Card)
+		}
+	}
+}
+
+// ShuffleTracker hears the game through the CountingStrategy it works for.
+type ShuffleTracker struct {
+	Steps    []house_rules.ShuffleStep
+	ShoeSize int
+	// by position in the shoe being dealt, the Hi-Lo value the tracker
+	// expects the card there to have, eg -1 in the middle of a slug of tens
+	Expected []float64
+	dealt    dealtCards
+}
+
+func CreateShuffleTracker(rules *house_rules.HouseRules) *ShuffleTracker {
+	var tracker ShuffleTracker = ShuffleTracker{
+		Steps:    rules.ShuffleSteps(),
+		ShoeSize: rules.ShoeSize(),
+		Expected: []float64{},
+		dealt:    dealtCards{Cards: []cards.Card{}, holeCard: -1},
+	}
+	return &tracker
+}
+
+func (self *ShuffleTracker) OnGameEvent(event game.GameEvent) {
+	if event.Type == game.SHOE_SHUFFLED {
+		self.Track(event.Cuts)
+	}
+	self.dealt.OnGameEvent(event)
+}
+
+// Track() follows the cards dealt since the last shuffle through the
+// dealer's shuffle, cuts are the cuts seen, see game.GameEvent.
+func (self *ShuffleTracker) Track(cuts []int) {
+	var expected []float64 = make([]float64, self.ShoeSize)
+	total := 0.0
+	numSeen := 0
+	for i := 0; i < len(self.dealt.Cards) && i < len(expected); i++ {
+		if self.dealt.Cards[i] != (cards.Card{}) {
+			expected[i] = float64(HiLoValue(self.dealt.Cards[i]))
+			total += expected[i]
+			numSeen++
+		}
+	}
+	// the cards not seen count to whatever the shoe is missing
+	for i := 0; i < len(expected); i++ {
+		if i >= len(self.dealt.Cards) || self.dealt.Cards[i] == (cards.Card{}) {
+			expected[i] = -total / float64(len(expected)-numSeen)
+		}
+	}
+
+	if len(self.Steps) == 0 {
+		// a machine, nothing to follow, the tracker counts plain Hi-Lo
+		self.Expected = self.Expected[:0]
+		return
+	}
+	for i := 0; i < len(self.Steps); i++ {
+		var step house_rules.ShuffleStep = self.Steps[i]
+		if step.Action == house_rules.CUT && step.Zones == 0 && step.Grab == 0 {
+			for j := 0; j < step.TimesDone(); j++ {
+				if len(cuts) > 0 {
+					expectSeenCut(expected, cuts[0])
+					cuts = cuts[1:]
+				} else {
+					expectCut(expected)
+				}
+			}
+			continue
+		}
+		expectStep(expected, step)
+	}
+	self.Expected = expected
+}
+
+// TrueCount() is the Hi-Lo true count, give or take how much richer the
+// cards about to be dealt are expected to be than the rest of the shoe.
+func (self *ShuffleTracker) TrueCount(counter *HiLoCounter) float64 {
+	position := len(self.dealt.Cards)
+	if position >= len(self.Expected) {
+		return counter.TrueCount()
+	}
+	window := min(position+TRACKING_WINDOW, len(self.Expected))
+	// Hi-Lo counts the high cards +1, a true count per deck
+	cardsPerDeck := float64(counter.CardsPerDeck)
+	slug := -average(self.Expected[position:window]) * cardsPerDeck
+	rest := -average(self.Expected[position:]) * cardsPerDeck
+	return counter.TrueCount() + slug - rest
+}
+
+func average(values []float64) float64 {
+	total := 0.0
+	for i := 0; i < len(values); i++ {
+		total += values[i]
+	}
+	return total / float64(len(values))
+}
+
+// what the tracker expects of a shuffle step, the steps are done the same
+// way as cards.ShuffleByStep() does them
+
+func expectStep(expected []float64, step house_rules.ShuffleStep) {
+	if step.Zones > 0 {
+		for zone := 0; zone < step.Zones; zone++ {
+			expectTimes(expected[zone*len(expected)/step.Zones:(zone+1)*len(expected)/step.Zones], step)
+		}
+		return
+	}
+	if step.Grab == 0 {
+		expectTimes(expected, step)
+		return
+	}
+
+	var top []float64 = append([]float64{}, expected[:len(expected)/2]...)
+	var bottom []float64 = append([]float64{}, expected[len(expected)/2:]...)
+	finished := len(expected)
+	for len(top) > 0 || len(bottom) > 0 {
+		fromTop := min(step.Grab/2, len(top))
+		fromBottom := min(step.Grab-fromTop, len(bottom))
+		if fromTop+fromBottom == 0 {
+			fromTop = min(1, len(top))
+			fromBottom = 1 - fromTop
+		}
+		var packet []float64 = append(append([]float64{}, top[:fromTop]...), bottom[:fromBottom]...)
+		top = top[fromTop:]
+		bottom = bottom[fromBottom:]
+		expectTimes(packet, step)
+		finished -= len(packet)
+		copy(expected[finished:], packet)
+	}
+}
+
+func expectTimes(expected []float64, step house_rules.ShuffleStep) {
+	for i := 0; i < step.TimesDone(); i++ {
+		switch step.Action {
+		case house_rules.RIFFLE:
+			expectRiffle(expected)
+		case house_rules.STRIP:
+			expectStrip(expected)
+		case house_rules.CUT:
+			expectCut(expected)
+		case house_rules.WASH:
+			expectWash(expected)
+		}
+	}
+}
+
+// a riffle cut in half: the card at p came from p/2 in the top half or
+// from p/2 in the bottom half, as likely as not
+func expectRiffle(expected []float64) {
+	if len(expected) < 2 {
+		return
+	}
+	var before []float64 = append([]float64{}, expected...)
+	half := len(expected) / 2
+	for p := 0; p < len(expected); p++ {
+		expected[p] = (before[min(p/2, half-1)] + before[min(half+p/2, len(before)-1)]) / 2
+	}
+}
+
+// packets of a fifth of the cards, in the reverse order
+func expectStrip(expected []float64) {
+	size := max(1, len(expected)/5)
+	var stripped []float64 = make([]float64, 0, len(expected))
+	var rest []float64 = expected
+	for len(rest) > 0 {
+		packet := min(size, len(rest))
+		stripped = append(append([]float64{}, rest[:packet]...), stripped...)
+		rest = rest[packet:]
+	}
+	copy(expected, stripped)
+}
+
+// a cut not seen is anywhere in the middle half
+func expectCut(expected []float64) {
+	if len(expected) < 2 {
+		return
+	}
+	var before []float64 = append([]float64{}, expected...)
+	n := len(before)
+	for p := 0; p < n; p++ {
+		total := 0.0
+		for cut := n / 4; cut <= n/4+n/2; cut++ {
+			total += before[(p+cut)%n]
+		}
+		expected[p] = total / float64(n/2+1)
+	}
+}
+
+func expectSeenCut(expected []float64, cut int) {
+	var before []float64 = append([]float64{}, expected...)
+	for p := 0; p < len(before); p++ {
+		expected[p] = before[(p+cut)%len(before)]
+	}
+}
+
+func expectWash(expected []float64) {
+	mean := average(expected)
+	for p := 0; p < len(expected); p++ {
+		expected[p] = mean
+	}
+}
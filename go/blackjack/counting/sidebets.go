@@ -0,0 +1,66 @@
+package counting
+
+import (
+	"math"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
+// SideBetCounter adds up the side bets by the Hi-Lo true count at the start
+// of the round they were made in, to show at which counts, if any, a side
+// bet is worth making.  Add it to a game like a HiLoCounter.
+type SideBetCounter struct {
+	Counter *HiLoCounter
+	// by side bet name, then true count
+	Results map[string]map[int]*SideBetCountResults
+	// the true count, rounded down, when the round started
+	trueCount int
+}
+
+type SideBetCountResults struct {
+	Bets     int         `json:"bets"`
+	Wagered  int         `json:"wagered"`
+	Proceeds money.Money `json:"proceeds"`
+}
+
+func CreateSideBetCounter(decksInShoe int, cardsPerDeck int) *SideBetCounter {
+	var sideBetCounter SideBetCounter = SideBetCounter{
+		Counter: CreateHiLoCounter(decksInShoe),
+		Results: map[string]map[int]*SideBetCountResults{},
+	}
+	sideBetCounter.Counter.CardsPerDeck = cardsPerDeck
+	return &sideBetCounter
+}
+
+func (self *SideBetCounter) OnGameEvent(event game.GameEvent) {
+	self.Counter.OnGameEvent(event)
+	switch event.Type {
+	case game.ROUND_STARTED:
+		self.trueCount = int(math.Floor(self.Counter.TrueCount()))
+	case game.SIDE_BET_SETTLED:
+		byCount, ok := self.Results[event.SideBet]
+		if !ok {
+			byCount = map[int]*SideBetCountResults{}
+			self.Results[event.SideBet] = byCount
+		}
+		results, ok := byCount[self.trueCount]
+		if !ok {
+			results = &SideBetCountResults{}
+			byCount[self.trueCount] = results
+		}
+		results.Bets++
+		results.Wagered += event.Bet
+		results.Proceeds += event.Result
+	}
+}
+
+// PlayerEdge() is what the side bet returns per dollar wagered at the true
+// count, negative => the house has the edge, zero when it was never made.
+func (self *SideBetCounter) PlayerEdge(sideBet string, trueCount int) float64 {
+	results, ok := self.Results[sideBet][trueCount]
+	if !ok || results.Wagered == 0 {
+		return 0
+	}
+	return results.Proceeds.Float() / float64(results.Wagered)
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/counting"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/sidebets"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHiLoValue(t *testing.T) {
+	expected := map[cards.CardRank]int{
+		cards.ACE: -1, cards.TWO: 1, cards.THREE: 1, cards.FOUR: 1, cards.FIVE: 1, cards.SIX: 1,
+		cards.SEVEN: 0, cards.EIGHT: 0, cards.NINE: 0,
+		cards.TEN: -1, cards.JACK: -1, cards.QUEEN: -1, cards.KING: -1,
+	}
+	total := 0
+	for rank, value := range expected {
+		assert.Equal(t, value, counting.HiLoValue(cards.Card{Suite: cards.SPADES, Rank: rank}), "Hi-Lo value of %v", rank)
+		total += value
+	}
+	assert.Equal(t, 0, total, "a deck counts to zero")
+}
+
+func TestHiLoCounterFollowsTheGame(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	var counter *counting.HiLoCounter = counting.CreateHiLoCounter(house_rules.DECKS_IN_SHOE)
+	blackjack.AddListener(counter)
+	// John: 5, 6   dealer: 2, 10   John stands, the dealer hits a 3 and a 4
+	stackShoe(blackjack, []cards.CardRank{cards.FIVE, cards.TWO, cards.SIX, cards.TEN, cards.THREE, cards.FOUR})
+
+	var round *game.Round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	assert.Equal(t, 3, counter.RunningCount, "the hole card is not counted until it is seen")
+	assert.Equal(t, 3, counter.CardsSeen, "three cards seen")
+
+	round.Step(game.PlayAction(strategy.STAND))
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, 4, counter.RunningCount, "5, 2, 6, 10, 3, 4")
+	assert.Equal(t, 6, counter.CardsSeen, "six cards seen")
+	assert.InDelta(t, 4.0/(float64(house_rules.DECKS_IN_SHOE*52-6)/52.0), counter.TrueCount(), 0.0001, "true count")
+
+	blackjack.ReshuffleShoe()
+	assert.Equal(t, 0, counter.RunningCount, "a shuffle starts the count over")
+
+	// Spanish 21 decks have no 10s, 48 cards
+	var rules *house_rules.HouseRules = house_rules.CreateSpanish21Rules()
+	var spanish *counting.HiLoCounter = counting.CreateHiLoCounter(rules.DecksInShoe)
+	spanish.CardsPerDeck = rules.CardsPerDeck()
+	spanish.CardsSeen = 48
+	assert.Equal(t, float64(rules.DecksInShoe-1), spanish.DecksRemaining(), "a Spanish deck is 48 cards")
+}
+
+func TestBetUnits(t *testing.T) {
+	assert.Equal(t, 1, counting.BetUnits(-3.5), "one unit at a negative count")
+	assert.Equal(t, 1, counting.BetUnits(1.9))
+	assert.Equal(t, 3, counting.BetUnits(3.2), "a unit per true count")
+	assert.Equal(t, counting.BET_SPREAD, counting.BetUnits(20), "the spread tops out")
+}
+
+func TestCountingStrategyBets(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	var countingStrategy *counting.CountingStrategy
+	countingStrategy, err := counting.CreateCountingStrategy(
+		counting.HI_LO_BETTING, "John", []int{5}, blackjack.Players[0].Strategy, blackjack.Rules,
+	)
+	assert.NoError(t, err)
+	blackjack.Players[0].Strategy = countingStrategy
+	blackjack.AddListener(countingStrategy)
+	// John: 10, 10   dealer: 10, 7   John stands and wins
+	stackShoe(blackjack, []cards.CardRank{cards.TEN, cards.TEN, cards.TEN, cards.SEVEN})
+
+	countingStrategy.Counter.RunningCount = 3 * house_rules.DECKS_IN_SHOE
+	var round *game.Round = blackjack.StartRound()
+	var action game.RoundAction = round.DefaultAction()
+	assert.Equal(t, game.BetAction([]int{15}), action, "three units at a true count of 3")
+	round.Step(action)
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	var results *counting.BettingResults = countingStrategy.Results
+	assert.Equal(t, 1, results.Rounds)
+	assert.Equal(t, 15, results.Wagered)
+	assert.Equal(t, money.Dollars(15), results.Proceeds)
+	assert.Equal(t, results.Proceeds, results.HiLoProceeds, "plain Hi-Lo bets the same")
+	assert.Equal(t, 1.0, results.Edge)
+	assert.Equal(t, 0.0, results.EdgeGained)
+
+	assert.Equal(t, 1, results.RoundsCompared, "an unlimited bankroll covers every split and double")
+	assert.Equal(t, results.Proceeds, results.ComparedProceeds)
+
+	bets := countingStrategy.DetermineBets(12, 0)
+	assert.Equal(t, []int{10}, bets, "no more than the bankroll covers")
+	assert.Equal(t, 2, results.Rounds)
+	assert.Equal(t, 1, results.RoundsCompared, "the bankroll could stop a split or a double, the round is not compared")
+	assert.Equal(t, 15, results.HiLoWagered)
+	assert.Equal(t, 15, results.ComparedWagered)
+	bets = countingStrategy.DetermineBets(10*9, 0)
+	assert.Equal(t, []int{10}, bets)
+	assert.Equal(t, 2, results.RoundsCompared, "the bankroll covers four split hands doubled, and the insurance")
+	bets = countingStrategy.DetermineBets(12, 3)
+	assert.Equal(t, []int{5}, bets, "the side bets come out of the bankroll first")
+	bets = countingStrategy.DetermineBets(7, 3)
+	assert.Empty(t, bets, "not even a unit left")
+
+	spanish, err := counting.CreateCountingStrategy(counting.HI_LO_BETTING, "John", []int{5}, countingStrategy.Play, house_rules.CreateSpanish21Rules())
+	assert.NoError(t, err)
+	assert.Equal(t, 48, spanish.Counter.CardsPerDeck, "counts Spanish decks")
+
+	_, err = counting.CreateCountingStrategy("martingale", "John", []int{5}, countingStrategy.Play, blackjack.Rules)
+	assert.Error(t, err)
+}
+
+func TestBankrollRunsOut(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John", "Jane")
+	countingStrategy, err := counting.CreateCountingStrategy(
+		counting.HI_LO_BETTING, "John", []int{5}, blackjack.Players[0].Strategy, blackjack.Rules,
+	)
+	assert.NoError(t, err)
+	blackjack.Players[0].Strategy = countingStrategy
+	blackjack.AddListener(countingStrategy)
+	blackjack.Players[1].Bets = []int{5}
+	for i := 0; i < len(blackjack.Players); i++ {
+		var player *game.Player = blackjack.Players[i]
+		// the side bet makes sure the bankrolls run out
+		player.SideBets = []game.SideBetWager{{SideBet: sidebets.CreatePerfectPairs(), Amount: 5}}
+		player.Bankroll = money.Dollars(20)
+	}
+
+	for i := 0; i < 1000; i++ {
+		blackjack.PlayGame()
+	}
+	for i := 0; i < len(blackjack.Players); i++ {
+		var player *game.Player = blackjack.Players[i]
+		assert.Less(t, player.Bankroll, money.Dollars(10), "%v can not cover a bet and its side bet", player.Name)
+		assert.GreaterOrEqual(t, player.Bankroll, money.Dollars(0), "%v never bets what is not there", player.Name)
+		assert.Greater(t, blackjack.Results[player.Name].RoundsSatOut, 0, "%v leaves the table", player.Name)
+	}
+}
+
+func TestWonging(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	create := func(wonging string) *counting.CountingStrategy {
+		countingStrategy, err := counting.CreateCountingStrategy(counting.HI_LO_BETTING, "John", []int{5}, strategy.CreateBasicStrategy(rules), rules)
+		assert.NoError(t, err)
+		assert.NoError(t, countingStrategy.SetWonging(wonging, counting.WONG_IN_COUNT, counting.WONG_OUT_COUNT))
+		countingStrategy.OnGameEvent(game.GameEvent{Type: game.SHOE_SHUFFLED})
+		return countingStrategy
+	}
+	trueCount := func(countingStrategy *counting.CountingStrategy, count int) {
+		countingStrategy.Counter.RunningCount = count * rules.DecksInShoe
+	}
+
+	var playAll *counting.CountingStrategy = create(counting.PLAY_ALL)
+	trueCount(playAll, -5)
+	assert.True(t, playAll.PlaysRound(), "plays every round")
+
+	var wongIn *counting.CountingStrategy = create(counting.WONG_IN)
+	assert.False(t, wongIn.PlaysRound(), "watches the start of the shoe")
+	trueCount(wongIn, 2)
+	assert.True(t, wongIn.PlaysRound(), "sits down at the wong in count")
+	wongIn.DetermineBets(1000, 0)
+	trueCount(wongIn, 0)
+	assert.True(t, wongIn.PlaysRound(), "stays in above the wong out count")
+	wongIn.DetermineBets(1000, 0)
+	trueCount(wongIn, -2)
+	assert.False(t, wongIn.PlaysRound(), "leaves below the wong out count")
+	trueCount(wongIn, 0)
+	assert.False(t, wongIn.PlaysRound(), "back in only at the wong in count")
+	assert.Equal(t, counting.WONG_IN, wongIn.Results.Wonging)
+
+	var wongOut *counting.CountingStrategy = create(counting.WONG_OUT)
+	assert.True(t, wongOut.PlaysRound(), "plays the start of the shoe")
+	wongOut.DetermineBets(1000, 0)
+	trueCount(wongOut, -2)
+	assert.False(t, wongOut.PlaysRound(), "leaves a bad shoe")
+	wongOut.OnGameEvent(game.GameEvent{Type: game.SHOE_SHUFFLED})
+	assert.True(t, wongOut.PlaysRound(), "back at the table for the next shoe")
+
+	assert.Error(t, wongOut.SetWonging("wong-sideways", 2, -1))
+	assert.Error(t, wongOut.SetWonging(counting.WONG_IN, -1, 2), "in below out")
+}
+
+func dealCards(listener game.GameListener, ranks ...cards.CardRank) {
+	for i := 0; i < len(ranks); i++ {
+		var card cards.Card = cards.Card{Suite: cards.SPADES, Rank: ranks[i]}
+		listener.OnGameEvent(game.GameEvent{Type: game.CARD_DEALT, Card: &card})
+	}
+}
+
+func TestShuffleTracker(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	rules.Shuffler = house_rules.SHUFFLE_RIFFLE
+	rules.ShuffleProcedure = []house_rules.ShuffleStep{{Action: house_rules.CUT}}
+	var tracker *counting.ShuffleTracker = counting.CreateShuffleTracker(rules)
+	var counter *counting.HiLoCounter = counting.CreateHiLoCounter(rules.DecksInShoe)
+
+	// a slug of 26 tens on top of the discards
+	var slug []cards.CardRank = []cards.CardRank{}
+	for i := 0; i < 26; i++ {
+		slug = append(slug, cards.TEN)
+	}
+	dealCards(tracker, slug...)
+	// the cut puts the slug at the bottom of the shoe
+	tracker.OnGameEvent(game.GameEvent{Type: game.SHOE_SHUFFLED, Cuts: []int{26}})
+	assert.Equal(t, -1.0, tracker.Expected[rules.ShoeSize()-1])
+	assert.Less(t, tracker.TrueCount(counter), -4.0, "the small cards come first")
+
+	// the slug is further down the discards, the cut brings it up to the top
+	for i := 0; i < 260; i++ {
+		dealCards(tracker, cards.SEVEN)
+	}
+	dealCards(tracker, slug...)
+	tracker.OnGameEvent(game.GameEvent{Type: game.SHOE_SHUFFLED, Cuts: []int{260}})
+	assert.Greater(t, tracker.TrueCount(counter), 4.0, "the slug comes first")
+
+	// nothing to follow through a machine
+	rules = house_rules.CreateHouseRules()
+	tracker = counting.CreateShuffleTracker(rules)
+	dealCards(tracker, slug...)
+	tracker.OnGameEvent(game.GameEvent{Type: game.SHOE_SHUFFLED})
+	counter.RunningCount = 6
+	assert.Equal(t, counter.TrueCount(), tracker.TrueCount(counter), "plain Hi-Lo")
+}
+
+func TestAceSequencer(t *testing.T) {
+	var sequencer *counting.AceSequencer = counting.CreateAceSequencer()
+	dealCards(sequencer, cards.KING, cards.ACE, cards.FIVE, cards.ACE)
+	sequencer.OnGameEvent(game.GameEvent{Type: game.SHOE_SHUFFLED})
+	assert.Equal(t, 1, sequencer.KeyCards[cards.Card{Suite: cards.SPADES, Rank: cards.KING}], "the king came right before an ace")
+	assert.Equal(t, 1, sequencer.KeyCards[cards.Card{Suite: cards.SPADES, Rank: cards.FIVE}])
+	assert.False(t, sequencer.AceExpected())
+
+	dealCards(sequencer, cards.TWO, cards.KING)
+	assert.True(t, sequencer.AceExpected(), "the king has come out")
+	dealCards(sequencer, cards.THREE, cards.FOUR)
+	assert.False(t, sequencer.AceExpected(), "the ace went by")
+	dealCards(sequencer, cards.KING)
+	assert.False(t, sequencer.AceExpected(), "the key card is used up")
+}
@@ -0,0 +1,252 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/counting"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
+)
+
+// An Environment lets an agent learn blackjack the way a Gym environment does:
+//     observation := env.Reset(seed)
+//     for !done {
+//         observation, reward, done, err = env.Step(action)
+//     }
+// An episode is one round with a single player, the agent, at the table.
+// The betting, dealing, early surrender (as basic strategy would), insurance
+// (always declined), dealer and settlement phases are stepped through for the
+// agent, which only makes the playing decisions, one Step() per decision,
+// for every hand after any splits.
+// Rounds where there is no decision to make, ie a natural on either side,
+// are played out by Reset() and are not episodes.
+
+type Action int
+
+const (
+	ACTION_STAND Action = iota
+	ACTION_HIT
+	ACTION_DOUBLE
+	ACTION_SPLIT
+	ACTION_SURRENDER
+	NUM_ACTIONS
+)
+
+var ACTION_DECISIONS = [NUM_ACTIONS]strategy.PlayerDecision{
+	strategy.STAND,
+	strategy.HIT,
+	strategy.DOUBLE,
+	strategy.SPLIT,
+	strategy.SURRENDER,
+}
+
+func ActionForDecision(decision strategy.PlayerDecision) Action {
+	for i := Action(0); i < NUM_ACTIONS; i++ {
+		if ACTION_DECISIONS[i] == decision {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("ActionForDecision(): %q is not an action", decision))
+}
+
+func (self Action) Decision() strategy.PlayerDecision {
+	return ACTION_DECISIONS[self]
+}
+
+// Observation is what the agent sees before each decision.
+type Observation struct {
+	// the hand's hard total and its soft total, the same when there is no usable ace
+	HardTotal int  `json:"hard_total"`
+	SoftTotal int  `json:"soft_total"`
+	IsSoft    bool `json:"is_soft"`
+	IsPair    bool `json:"is_pair"`
+	// Ace => 1, face cards => 10
+	DealerUpCard int `json:"dealer_up_card"`
+	// Hi-Lo true count of the cards seen since the shoe was shuffled
+	TrueCount float64 `json:"true_count"`
+	// indexed by Action, all false once the episode is done
+	LegalActions [NUM_ACTIONS]bool `json:"legal_actions"`
+}
+
+// the observation as numbers, for agents that want a vector:
+//
+//	[0] hard total   [1] soft total   [2] soft 0/1   [3] pair 0/1
+//	[4] dealer up card   [5] true count   [6:11] legal action mask 0/1
+const OBSERVATION_SIZE int = 6 + int(NUM_ACTIONS)
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1.0
+	}
+	return 0.0
+}
+
+func (self *Observation) Vector() []float64 {
+	var vector []float64 = []float64{
+		float64(self.HardTotal),
+		float64(self.SoftTotal),
+		boolToFloat(self.IsSoft),
+		boolToFloat(self.IsPair),
+		float64(self.DealerUpCard),
+		self.TrueCount,
+	}
+	for i := 0; i < len(self.LegalActions); i++ {
+		vector = append(vector, boolToFloat(self.LegalActions[i]))
+	}
+	return vector
+}
+
+func (self *Observation) IsLegal(action Action) bool {
+	return action >= 0 && action < NUM_ACTIONS && self.LegalActions[action]
+}
+
+// Reset() with KEEP_SHOE carries on with the same shoe and random generator.
+const KEEP_SHOE int64 = -1
+
+const AGENT_NAME string = "agent"
+
+var ErrEpisodeOver = errors.New("episode is over, Reset() to start another")
+
+type Environment struct {
+	Rules *house_rules.HouseRules
+	// the agent's bet on every round, rewards are in units of this bet
+	Bet       int
+	BlackJack *game.BlackJack
+	Agent     *game.Player
+	Counter   *counting.HiLoCounter
+
+	round *game.Round
+	// the hand the agent decided on last, observed once the round is over
+	lastHand       *game.PlayerHand
+	proceedsBefore money.Money
+}
+
+// the environment needs a Reset() before the first Step()
+func CreateEnvironment(rules *house_rules.HouseRules) *Environment {
+	var environment Environment = Environment{
+		Rules: rules,
+		Bet:   2,
+	}
+	return &environment
+}
+
+// Reset() starts a new episode, on a freshly shuffled shoe for a seed >= 0.
+func (self *Environment) Reset(seed int64) Observation {
+	if seed != KEEP_SHOE || self.BlackJack == nil {
+		if seed == KEEP_SHOE {
+			seed = 0
+		}
+		self.BlackJack = game.CreateBlackJackWithRules(self.Rules, seed)
+		self.BlackJack.Verbose = false
+		self.Counter = counting.CreateHiLoCounter(self.Rules.DecksInShoe)
+		self.Counter.CardsPerDeck = self.Rules.CardsPerDeck()
+		self.BlackJack.AddListener(self.Counter)
+		self.Agent = game.CreatePlayer(AGENT_NAME)
+		self.BlackJack.SetPlayersForGame([]*game.Player{self.Agent})
+	}
+	self.Agent.Bets = self.Rules.StartingBets(self.Bet)
+
+	for {
+		self.proceedsBefore = self.BlackJack.Results[AGENT_NAME].Proceeds
+		self.round = self.BlackJack.StartRound()
+		self.lastHand = nil
+		self.advance()
+		if !self.round.IsOver() {
+			return self.Observe()
+		}
+	}
+}
+
+// steps through the phases where the agent has nothing to decide
+func (self *Environment) advance() {
+	for !self.round.IsOver() && self.round.Phase != game.PHASE_PLAYER_TURNS {
+		err := self.round.Step(self.round.DefaultAction())
+		if err != nil {
+			panic(fmt.Sprintf("Environment.advance(): %v", err))
+		}
+	}
+}
+
+func (self *Environment) IsDone() bool {
+	return self.round == nil || self.round.IsOver()
+}
+
+// Step() plays the action on the current hand. The reward is zero until the
+// episode is done, then it is what the round won or lost in units of the bet,
+// eg 1.5 for a natural, -2 for a lost double down, -0.5 for a surrender.
+func (self *Environment) Step(action Action) (Observation, float64, bool, error) {
+	if self.IsDone() {
+		return self.Observe(), 0.0, true, ErrEpisodeOver
+	}
+	if action < 0 || action >= NUM_ACTIONS {
+		return self.Observe(), 0.0, false, fmt.Errorf("%w: no action %v", game.ErrIllegalAction, action)
+	}
+
+	var hand *game.PlayerHand = self.round.CurrentHand()
+	err := self.round.Step(game.PlayAction(action.Decision()))
+	if err != nil {
+		return self.Observe(), 0.0, false, err
+	}
+	self.lastHand = hand
+	self.advance()
+
+	if !self.IsDone() {
+		return self.Observe(), 0.0, false, nil
+	}
+	proceeds := self.BlackJack.Results[AGENT_NAME].Proceeds - self.proceedsBefore
+	return self.Observe(), proceeds.Float() / float64(self.Bet), true, nil
+}
+
+func (self *Environment) Observe() Observation {
+	var observation Observation = Observation{}
+	if self.Counter != nil {
+		observation.TrueCount = self.Counter.TrueCount()
+	}
+	var hand *game.PlayerHand = self.lastHand
+	if !self.IsDone() {
+		hand = self.round.CurrentHand()
+		var legalDecisions []strategy.PlayerDecision = self.LegalDecisions()
+		for i := 0; i < len(legalDecisions); i++ {
+			observation.LegalActions[ActionForDecision(legalDecisions[i])] = true
+		}
+	}
+	if self.round != nil {
+		observation.DealerUpCard = cards.CardRankValue[self.round.DealerTopCard().Rank]
+	}
+	if hand != nil {
+		observation.HardTotal = hand.HardCount()
+		observation.SoftTotal = hand.HardCount()
+		if hand.SoftCount() <= 21 {
+			observation.SoftTotal = hand.SoftCount()
+		}
+		observation.IsSoft = observation.SoftTotal > observation.HardTotal
+		observation.IsPair = strategy.IsPair(hand, self.Rules)
+	}
+	return observation
+}
+
+func (self *Environment) LegalDecisions() []strategy.PlayerDecision {
+	if self.IsDone() {
+		return []strategy.PlayerDecision{}
+	}
+	return strategy.LegalActions(
+		self.round.CurrentHand(),
+		self.round.CurrentMasterHand(),
+		self.Rules,
+		self.Agent.BankrollDollars(),
+	)
+}
+
+// BasicStrategyAction() is what basic strategy would do with the current hand,
+// the yardstick for a learning agent.
+func (self *Environment) BasicStrategyAction() Action {
+	var basicStrategy *strategy.BasicStrategy = strategy.CreateBasicStrategy(self.Rules)
+	var decision strategy.PlayerDecision = basicStrategy.DeterminePlay(
+		self.round.DealerTopCard(), self.round.CurrentHand(), self.LegalDecisions(),
+	)
+	return ActionForDecision(decision)
+}
@@ -0,0 +1,167 @@
+package env
+
+import (
+	"math/rand"
+)
+
+// A tabular Q-learning agent, the example of training against an Environment.
+// It learns the value of each action in each state from nothing but the
+// rewards, and the greedy policy it ends up with is basic strategy, give or
+// take the rarely seen states.
+//
+// The state leaves out the true count, basic strategy does not count cards.
+
+type QState struct {
+	Total        int
+	IsSoft       bool
+	IsPair       bool
+	DealerUpCard int
+	// the legal action mask tells a first decision from a later one
+	LegalActions [NUM_ACTIONS]bool
+}
+
+func QStateFor(observation Observation) QState {
+	var state QState = QState{
+		Total:        observation.SoftTotal,
+		IsSoft:       observation.IsSoft,
+		IsPair:       observation.IsPair,
+		DealerUpCard: observation.DealerUpCard,
+		LegalActions: observation.LegalActions,
+	}
+	return state
+}
+
+type QValues struct {
+	Values [NUM_ACTIONS]float64
+	Visits [NUM_ACTIONS]int
+}
+
+type QLearner struct {
+	Table map[QState]*QValues
+	// chance of exploring with a random action while training
+	Epsilon float64
+	Random  *rand.Rand
+}
+
+func CreateQLearner(seed int64) *QLearner {
+	var learner QLearner = QLearner{
+		Table:   make(map[QState]*QValues),
+		Epsilon: 0.1,
+		Random:  rand.New(rand.NewSource(seed)),
+	}
+	return &learner
+}
+
+func (self *QLearner) values(state QState) *QValues {
+	qValues, ok := self.Table[state]
+	if !ok {
+		qValues = &QValues{}
+		self.Table[state] = qValues
+	}
+	return qValues
+}
+
+// BestAction() is the greedy policy: the legal action worth the most.
+func (self *QLearner) BestAction(observation Observation) Action {
+	var qValues *QValues = self.values(QStateFor(observation))
+	var best Action = ACTION_STAND
+	found := false
+	for action := Action(0); action < NUM_ACTIONS; action++ {
+		if !observation.IsLegal(action) {
+			continue
+		}
+		if !found || qValues.Values[action] > qValues.Values[best] {
+			best = action
+			found = true
+		}
+	}
+	return best
+}
+
+func (self *QLearner) bestValue(observation Observation) float64 {
+	return self.values(QStateFor(observation)).Values[self.BestAction(observation)]
+}
+
+// epsilon greedy
+func (self *QLearner) chooseAction(observation Observation) Action {
+	if self.Random.Float64() >= self.Epsilon {
+		return self.BestAction(observation)
+	}
+	var legalActions []Action = []Action{}
+	for action := Action(0); action < NUM_ACTIONS; action++ {
+		if observation.IsLegal(action) {
+			legalActions = append(legalActions, action)
+		}
+	}
+	return legalActions[self.Random.Intn(len(legalActions))]
+}
+
+// Train() plays episodes on the environment's current shoe.
+// Every round is worth playing well, so there is no discount on future rewards,
+// and the learning rate is 1/visits, ie each value is an average of what it has seen.
+func (self *QLearner) Train(environment *Environment, episodes int) {
+	for i := 0; i < episodes; i++ {
+		var observation Observation = environment.Reset(KEEP_SHOE)
+		done := false
+		for !done {
+			var action Action = self.chooseAction(observation)
+			nextObservation, reward, episodeDone, err := environment.Step(action)
+			if err != nil {
+				panic(err)
+			}
+
+			target := reward
+			if !episodeDone {
+				target += self.bestValue(nextObservation)
+			}
+			var qValues *QValues = self.values(QStateFor(observation))
+			qValues.Visits[action]++
+			qValues.Values[action] += (target - qValues.Values[action]) / float64(qValues.Visits[action])
+
+			observation = nextObservation
+			done = episodeDone
+		}
+	}
+}
+
+type QEvaluation struct {
+	Episodes int
+	// average reward per episode, in units of the bet
+	AverageReward float64
+	// fraction of decisions that were the same as basic strategy's
+	BasicStrategyAgreement float64
+}
+
+// Evaluate() plays the greedy policy, no exploring and no learning.
+func (self *QLearner) Evaluate(environment *Environment, episodes int) QEvaluation {
+	totalReward := 0.0
+	decisions := 0
+	agreements := 0
+	for i := 0; i < episodes; i++ {
+		var observation Observation = environment.Reset(KEEP_SHOE)
+		done := false
+		for !done {
+			var action Action = self.BestAction(observation)
+			decisions++
+			if action == environment.BasicStrategyAction() {
+				agreements++
+			}
+			nextObservation, reward, episodeDone, err := environment.Step(action)
+			if err != nil {
+				panic(err)
+			}
+			totalReward += reward
+			observation = nextObservation
+			done = episodeDone
+		}
+	}
+
+	var evaluation QEvaluation = QEvaluation{Episodes: episodes}
+	if episodes > 0 {
+		evaluation.AverageReward = totalReward / float64(episodes)
+	}
+	if decisions > 0 {
+		evaluation.BasicStrategyAgreement = float64(agreements) / float64(decisions)
+	}
+	return evaluation
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/env"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stackNextRound() puts the given ranks on top of what is left of the shoe,
+// in dealing order: agent, dealer up card, agent, dealer hole card, then the rest.
+func stackNextRound(environment *env.Environment, ranks []cards.CardRank) {
+	var blackjack *game.BlackJack = environment.BlackJack
+	for i := 0; i < len(ranks); i++ {
+		blackjack.Shoe[blackjack.ShoeTop+i] = cards.Card{Suite: cards.CLUBS, Rank: ranks[i]}
+	}
+}
+
+func TestEnvironmentEpisodes(t *testing.T) {
+	var environment *env.Environment = env.CreateEnvironment(house_rules.CreateHouseRules())
+	_, _, done, err := environment.Step(env.ACTION_STAND)
+	assert.ErrorIs(t, err, env.ErrEpisodeOver, "no episode before Reset()")
+	assert.True(t, done, "no episode before Reset()")
+
+	var observation env.Observation = environment.Reset(7)
+	for episode := 0; episode < 500; episode++ {
+		proceedsBefore := environment.BlackJack.Results[env.AGENT_NAME].Proceeds
+		done := false
+		totalReward := 0.0
+		for steps := 0; !done; steps++ {
+			assert.Less(t, steps, 50, "episode should end")
+			assert.True(t, observation.IsLegal(env.ACTION_STAND), "standing is always legal")
+			assert.Len(t, observation.Vector(), env.OBSERVATION_SIZE, "observation vector size")
+			legalCount := 0
+			for action := env.Action(0); action < env.NUM_ACTIONS; action++ {
+				if observation.IsLegal(action) {
+					legalCount++
+				}
+			}
+			assert.Len(t, environment.LegalDecisions(), legalCount, "legal action mask matches LegalActions()")
+
+			var reward float64
+			observation, reward, done, err = environment.Step(environment.BasicStrategyAction())
+			assert.NoError(t, err, "basic strategy plays legal actions")
+			totalReward += reward
+		}
+		proceeds := environment.BlackJack.Results[env.AGENT_NAME].Proceeds - proceedsBefore
+		assert.Equal(t, proceeds.Float()/float64(environment.Bet), totalReward, "reward is the round's proceeds")
+
+		_, _, _, err = environment.Step(env.ACTION_STAND)
+		assert.ErrorIs(t, err, env.ErrEpisodeOver, "no steps once done")
+		observation = environment.Reset(env.KEEP_SHOE)
+	}
+
+	// the true count in a Spanish shoe goes by 48 card decks
+	environment = env.CreateEnvironment(house_rules.CreateSpanish21Rules())
+	environment.Reset(7)
+	assert.Equal(t, 48, environment.Counter.CardsPerDeck)
+}
+
+func TestEnvironmentRewards(t *testing.T) {
+	var environment *env.Environment = env.CreateEnvironment(house_rules.CreateHouseRules())
+	environment.Reset(7)
+	for !environment.IsDone() {
+		environment.Step(env.ACTION_STAND)
+	}
+
+	// agent: 5, 6   dealer: 9, 8   agent doubles on a 10
+	stackNextRound(environment, []cards.CardRank{cards.FIVE, cards.NINE, cards.SIX, cards.EIGHT, cards.TEN})
+	var observation env.Observation = environment.Reset(env.KEEP_SHOE)
+	assert.Equal(t, 11, observation.HardTotal, "agent has 11")
+	assert.Equal(t, 9, observation.DealerUpCard, "dealer shows a 9")
+	assert.False(t, observation.IsSoft, "11 is hard")
+	_, _, _, err := environment.Step(env.ACTION_SPLIT)
+	assert.ErrorIs(t, err, game.ErrIllegalAction, "5, 6 is not a pair")
+	_, reward, done, err := environment.Step(env.ACTION_DOUBLE)
+	assert.NoError(t, err, "double down")
+	assert.True(t, done, "doubling ends the episode")
+	assert.Equal(t, 2.0, reward, "double down won")
+
+	// agent: 10, 6   dealer: 9, 8   agent doubles on a king and busts
+	stackNextRound(environment, []cards.CardRank{cards.TEN, cards.NINE, cards.SIX, cards.EIGHT, cards.KING})
+	environment.Reset(env.KEEP_SHOE)
+	_, reward, done, _ = environment.Step(env.ACTION_DOUBLE)
+	assert.True(t, done, "doubling ends the episode")
+	assert.Equal(t, -2.0, reward, "a busted double down is lost")
+
+	// agent: A, 6   dealer: 10, 7
+	stackNextRound(environment, []cards.CardRank{cards.ACE, cards.TEN, cards.SIX, cards.SEVEN})
+	observation = environment.Reset(env.KEEP_SHOE)
+	assert.True(t, observation.IsSoft, "A, 6 is soft")
+	assert.Equal(t, 17, observation.SoftTotal, "soft 17")
+	assert.Equal(t, 7, observation.HardTotal, "hard 7")
+	_, reward, done, _ = environment.Step(env.ACTION_STAND)
+	assert.True(t, done, "standing ends the episode")
+	assert.Equal(t, 0.0, reward, "17 pushes")
+}
+
+func TestQLearning(t *testing.T) {
+	var environment *env.Environment = env.CreateEnvironment(house_rules.CreateHouseRules())
+	environment.Reset(1)
+	var learner *env.QLearner = env.CreateQLearner(1)
+
+	learner.Train(environment, 5000)
+	var early env.QEvaluation = learner.Evaluate(environment, 10000)
+	learner.Train(environment, 50000)
+	var later env.QEvaluation = learner.Evaluate(environment, 10000)
+
+	assert.Greater(t, later.BasicStrategyAgreement, early.BasicStrategyAgreement, "more training, closer to basic strategy")
+	assert.Greater(t, later.BasicStrategyAgreement, 0.65, "most decisions are basic strategy's")
+}
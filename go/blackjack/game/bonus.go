@@ -0,0 +1,65 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// IsBonusHand() is true when the hand is the house's bonus hand, see house_rules.BonusHand
+func IsBonusHand(hand *PlayerHand, bonusHand house_rules.BonusHand) bool {
+	switch bonusHand.Hand {
+	case house_rules.BONUS_BLACKJACK:
+		if !hand.IsNatural() {
+			return false
+		}
+	case house_rules.BONUS_CHARLIE:
+		return hand.NumCards() >= bonusHand.Cards && hand.HardCount() <= 21
+	case house_rules.BONUS_777, house_rules.BONUS_678:
+		if hand.NumCards() != 3 {
+			return false
+		}
+		var values []int = []int{}
+		for i := 0; i < hand.NumCards(); i++ {
+			values = append(values, cards.CardRankValue[hand.Cards[i].Rank])
+		}
+		slices.Sort(values)
+		if bonusHand.Hand == house_rules.BONUS_777 && !slices.Equal(values, []int{7, 7, 7}) {
+			return false
+		} else if bonusHand.Hand == house_rules.BONUS_678 && !slices.Equal(values, []int{6, 7, 8}) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	for i := 1; i < hand.NumCards(); i++ {
+		if bonusHand.Suits == house_rules.SUITED && hand.Cards[i].Suite != hand.Cards[0].Suite {
+			return false
+		} else if bonusHand.Suits == house_rules.SAME_COLOR && isRed(hand.Cards[i]) != isRed(hand.Cards[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isRed(card cards.Card) bool {
+	return card.Suite == cards.HEARTS || card.Suite == cards.DIAMONDS
+}
+
+// BonusHandPayout() is the biggest bonus the hand earns in the house rules,
+// the zero payout => none, the hand is paid as usual.
+func BonusHandPayout(hand *PlayerHand, rules *house_rules.HouseRules) (money.Payout, string) {
+	var payout money.Payout = money.Payout{}
+	var name string = ""
+	for i := 0; i < len(rules.BonusHands); i++ {
+		var bonusHand house_rules.BonusHand = rules.BonusHands[i]
+		if bonusHand.Payout.Ratio() > payout.Ratio() && IsBonusHand(hand, bonusHand) {
+			payout = bonusHand.Payout
+			name = bonusHand.String()
+		}
+	}
+	return payout, name
+}
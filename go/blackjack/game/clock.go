@@ -0,0 +1,24 @@
+package game
+
+// A rough table clock, for rounds and hands per hour: a round takes
+// SECONDS_PER_ROUND plus SECONDS_PER_HAND for every player hand, and the
+// table waits while the shoe is shuffled, see cards.Shuffler.
+
+const SECONDS_PER_ROUND float64 = 20
+const SECONDS_PER_HAND float64 = 10
+
+func (self *BlackJack) RoundsPerHour() float64 {
+	if self.Stats.TableSeconds == 0 {
+		return 0
+	}
+	return float64(self.Stats.RoundsPlayed) * 3600 / self.Stats.TableSeconds
+}
+
+// HandsPerHour() is the hands the player played per hour, splits included
+func (self *BlackJack) HandsPerHour(playerName string) float64 {
+	results, ok := self.Results[playerName]
+	if !ok || self.Stats.TableSeconds == 0 {
+		return 0
+	}
+	return float64(results.HandsPlayed) * 3600 / self.Stats.TableSeconds
+}
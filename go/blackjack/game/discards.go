@@ -0,0 +1,96 @@
+package game
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+)
+
+// Once a round is over its cards go into the discard tray.  The ones that
+// were never turned over, eg the dealer's hole card when every player has
+// busted, go in face down, as do the cards the dealer burns after a shuffle.
+// The tray goes back into the shoe when the shoe is reshuffled.  A continuous
+// shuffling machine has no tray, the cards go straight back into the machine.
+
+type DiscardTray struct {
+	// the cards everyone at the table saw, in the order they were dealt
+	Seen []cards.Card
+	// discarded face down, nobody saw them
+	Unseen []cards.Card
+	// burned face down after the shuffle, see house_rules.BurnCards
+	Burned []cards.Card
+}
+
+func CreateDiscardTray() *DiscardTray {
+	var tray DiscardTray = DiscardTray{
+		Seen:   []cards.Card{},
+		Unseen: []cards.Card{},
+		Burned: []cards.Card{},
+	}
+	return &tray
+}
+
+func (self *DiscardTray) NumCards() int {
+	return len(self.Seen) + len(self.Unseen) + len(self.Burned)
+}
+
+// Empty() is the tray going back into the shoe.
+func (self *DiscardTray) Empty() {
+	self.Seen = self.Seen[:0]
+	self.Unseen = self.Unseen[:0]
+	self.Burned = self.Burned[:0]
+}
+
+// burnCards() burns the rules' BurnCards off the top of the shoe, face down.
+func (self *BlackJack) burnCards() {
+	for i := 0; i < self.Rules.BurnCards && self.ShoeTop < len(self.Shoe); i++ {
+		self.Discards.Burned = append(self.Discards.Burned, self.Shoe[self.ShoeTop])
+		self.ShoeTop++
+		self.emit(GameEvent{Type: CARD_BURNED})
+	}
+}
+
+// CardsNeverSeen() is the cards of the shoe nobody at the table has seen:
+// the ones still to be dealt and the ones in the tray face down.
+func (self *BlackJack) CardsNeverSeen() int {
+	if csm := self.continuousShuffler(); csm != nil {
+		return csm.NumCards()
+	}
+	return len(self.Shoe) - self.ShoeTop + len(self.Discards.Unseen) + len(self.Discards.Burned)
+}
+
+// discard() puts the round's cards into the tray, or back into the
+// continuous shuffling machine, once every seat has seen them.
+func (self *Round) discard() {
+	var blackjack *BlackJack = self.blackjack
+	var seen []cards.Card = make([]cards.Card, 0, len(blackjack.roundCards))
+	var unseen []cards.Card = []cards.Card{}
+	for i := 0; i < len(blackjack.roundCards); i++ {
+		if i == self.holeCardIndex && !self.holeCardShown {
+			unseen = append(unseen, blackjack.roundCards[i])
+		} else {
+			seen = append(seen, blackjack.roundCards[i])
+		}
+	}
+	if len(blackjack.Listeners) > 0 {
+		for i := 0; i < len(self.Players); i++ {
+			blackjack.emit(GameEvent{Type: CARDS_SEEN, PlayerName: self.Players[i].Name, Cards: self.seenBy(self.Players[i], seen)})
+		}
+		for i := 0; i < len(self.Watchers); i++ {
+			blackjack.emit(GameEvent{Type: CARDS_SEEN, PlayerName: self.Watchers[i].Name, Cards: seen})
+		}
+	}
+
+	if csm := blackjack.continuousShuffler(); csm != nil {
+		csm.Load(blackjack.roundCards, blackjack.random())
+		blackjack.emit(GameEvent{Type: SHOE_SHUFFLED})
+	} else {
+		blackjack.Discards.Seen = append(blackjack.Discards.Seen, seen...)
+		blackjack.Discards.Unseen = append(blackjack.Discards.Unseen, unseen...)
+	}
+	blackjack.roundCards = blackjack.roundCards[:0]
+}
+
+// seenBy() is the cards of the round the player could have seen from their
+// seat, every card turned over at the table and a flashed hole card.
+func (self *Round) seenBy(player *Player, seen []cards.Card) []cards.Card {
+	return self.flashedTo(player, seen)
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
+)
+
+// Game events let someone other than the players' strategies follow a game
+// as it is played, eg a table server showing the game to its clients.
+// Only what everyone at the table can see goes into an event, which is why
+// the dealer's hole card is announced face down and revealed later.
+
+type GameEventType string
+
+const (
+	SHOE_SHUFFLED      GameEventType = "shoe-shuffled"
+	ROUND_STARTED      GameEventType = "round-started"
+	CARD_DEALT         GameEventType = "card-dealt"
+	HOLE_CARD_DEALT    GameEventType = "hole-card-dealt"
+	HOLE_CARD_REVEALED GameEventType = "hole-card-revealed"
+	CARD_BURNED        GameEventType = "card-burned" // face down
+	PLAYER_DECISION    GameEventType = "player-decision"
+	CARDS_SWITCHED     GameEventType = "cards-switched" // Blackjack Switch
+	HAND_SETTLED       GameEventType = "hand-settled"
+	SIDE_BET_SETTLED   GameEventType = "side-bet-settled"
+	ROUND_ENDED        GameEventType = "round-ended"
+	// once the round is over, a seat's view of it
+	CARDS_SEEN GameEventType = "cards-seen"
+)
+
+type GameEvent struct {
+	Type GameEventType `json:"type"`
+	// "" => the event is about the dealer
+	PlayerName      string                  `json:"player,omitempty"`
+	MasterHandIndex int                     `json:"master_hand"`
+	HandIndex       int                     `json:"hand"`
+	Card            *cards.Card             `json:"card,omitempty"`
+	Decision        strategy.PlayerDecision `json:"decision,omitempty"`
+	Outcome         HandOutcome             `json:"outcome,omitempty"`
+	// SIDE_BET_SETTLED: the side bet's name
+	SideBet string `json:"side_bet,omitempty"`
+	// PLAYER_DECISION: the extra bet of a double down
+	// SIDE_BET_SETTLED: the side bet
+	Bet int `json:"bet,omitempty"`
+	// HAND_SETTLED, SIDE_BET_SETTLED: money won (positive) or lost (negative)
+	Result money.Money `json:"result"`
+	// SHOE_SHUFFLED: where a dealer shuffling by hand cut the cards, as
+	// everyone at the table saw, see cards.ProcedureShuffler
+	Cuts []int `json:"cuts,omitempty"`
+	// CARDS_SEEN: the cards the player could have seen, in the order they were dealt
+	Cards []cards.Card `json:"cards,omitempty"`
+}
+
+type GameListener interface {
+	OnGameEvent(event GameEvent)
+}
+
+func (self *BlackJack) AddListener(listener GameListener) {
+	self.Listeners = append(self.Listeners, listener)
+}
+
+func (self *BlackJack) emit(event GameEvent) {
+	for i := 0; i < len(self.Listeners); i++ {
+		self.Listeners[i].OnGameEvent(event)
+	}
+}
+
+func (self *BlackJack) emitPlayerCard(player *Player, masterHandIndex int, handIndex int, card cards.Card) {
+	if len(self.Listeners) == 0 {
+		return
+	}
+	self.emit(GameEvent{
+		Type:            CARD_DEALT,
+		PlayerName:      player.Name,
+		MasterHandIndex: masterHandIndex,
+		HandIndex:       handIndex,
+		Card:            &card,
+	})
+}
+
+func (self *BlackJack) emitDealerCard(eventType GameEventType, card cards.Card) {
+	if len(self.Listeners) == 0 {
+		return
+	}
+	var event GameEvent = GameEvent{Type: eventType}
+	if eventType != HOLE_CARD_DEALT {
+		event.Card = &card
+	}
+	self.emit(event)
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+)
+
+// A dealer's hole card is face down, but not always hidden: a careless
+// dealer flashes it to first base now and then while dealing it, and a
+// dealer who peeks under a ten can give away by a tell whether the hole
+// card is a ten too.  Neither is announced to the table, no GameEvent, a
+// seat finds out what it knows from HoleCardVisibility().
+
+// HoleCardView is what a seat knows of the dealer's hole card.
+type HoleCardView struct {
+	// Seen => Card is the hole card
+	Seen bool
+	Card cards.Card
+	// Told => IsTen is whether the hole card is a ten, a seat that has seen
+	// the hole card has been told too
+	Told  bool
+	IsTen bool
+}
+
+// HoleCardVisibility() is what the player knows of the dealer's hole card
+// from their seat, see house_rules.HoleCardFlash and house_rules.DealerTell.
+func (self *Round) HoleCardVisibility(player *Player) HoleCardView {
+	if holeCard, holeCardShown := self.DealerHoleCard(); holeCardShown {
+		return seenHoleCard(holeCard)
+	}
+	if self.holeCardIndex < 0 {
+		return HoleCardView{}
+	}
+	if self.holeCardFlashed && self.isFirstBase(player) {
+		return seenHoleCard(self.Dealer.HoleCard())
+	}
+	if self.holeCardTold {
+		return HoleCardView{Told: true, IsTen: isTen(self.Dealer.HoleCard())}
+	}
+	return HoleCardView{}
+}
+
+func seenHoleCard(holeCard cards.Card) HoleCardView {
+	return HoleCardView{Seen: true, Card: holeCard, Told: true, IsTen: isTen(holeCard)}
+}
+
+func isTen(card cards.Card) bool {
+	return cards.CardRankValue[card.Rank] == 10
+}
+
+// first base is the seat dealt to first, the one the flash is seen from
+func (self *Round) isFirstBase(player *Player) bool {
+	return len(self.Players) > 0 && self.Players[0] == player
+}
+
+// flashHoleCard() is the dealer maybe flashing the hole card just dealt.
+func (self *Round) flashHoleCard() {
+	var blackjack *BlackJack = self.blackjack
+	// no draw at all for a careful dealer, so the shoes come out as before
+	if blackjack.Rules.HoleCardFlash > 0 && blackjack.random().Float64() < blackjack.Rules.HoleCardFlash {
+		self.holeCardFlashed = true
+		blackjack.log(fmt.Sprintf("dealer flashes the hole card: %v", self.Dealer.HoleCard().Str()))
+	}
+}
+
+// tellHoleCard() is the dealer maybe giving the hole card away after peeking
+// under a ten, there is nothing to give away under an ace after the peek.
+func (self *Round) tellHoleCard() {
+	var blackjack *BlackJack = self.blackjack
+	if self.holeCardIndex < 0 || self.holeCardShown || !isTen(self.DealerTopCard()) {
+		return
+	}
+	if blackjack.Rules.DealerTell > 0 && blackjack.random().Float64() < blackjack.Rules.DealerTell {
+		self.holeCardTold = true
+		blackjack.log(fmt.Sprintf("dealer tells the hole card is a ten: %v", isTen(self.Dealer.HoleCard())))
+	}
+}
+
+// flashedTo() is the hole card for first base when it was flashed and never
+// turned over, see seenBy()
+func (self *Round) flashedTo(player *Player, seen []cards.Card) []cards.Card {
+	if !self.holeCardFlashed || self.holeCardShown || !self.isFirstBase(player) {
+		return seen
+	}
+	return append(slices.Clone(seen), self.Dealer.HoleCard())
+}
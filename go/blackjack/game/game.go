@@ -2,51 +2,184 @@ package game
 
 import (
 	"fmt"
+	"math/rand"
+	"slices"
 
 	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
 	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
-	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
 )
 
 type BlackJackPlayerResults struct {
-	HandsPlayed int
-	HandsWon    int
-	HandsLost   int
-	HandsPushed int
-	Proceeds    int
+	HandsPlayed int `json:"hands_played"`
+	HandsWon    int `json:"hands_won"`
+	HandsLost   int `json:"hands_lost"`
+	HandsPushed int `json:"hands_pushed"`
+	// rounds watched instead of played, see SeatStrategy
+	RoundsSatOut int `json:"rounds_sat_out"`
+	// includes InsuranceProceeds and SideBetProceeds
+	Proceeds          money.Money `json:"proceeds"`
+	InsuranceProceeds money.Money `json:"insurance_proceeds"`
+	SideBetProceeds   money.Money `json:"side_bet_proceeds"`
+}
+
+// Since() is what the results have added up to since before, eg over one round.
+func (self BlackJackPlayerResults) Since(before BlackJackPlayerResults) BlackJackPlayerResults {
+	return BlackJackPlayerResults{
+		HandsPlayed:       self.HandsPlayed - before.HandsPlayed,
+		HandsWon:          self.HandsWon - before.HandsWon,
+		HandsLost:         self.HandsLost - before.HandsLost,
+		HandsPushed:       self.HandsPushed - before.HandsPushed,
+		RoundsSatOut:      self.RoundsSatOut - before.RoundsSatOut,
+		Proceeds:          self.Proceeds - before.Proceeds,
+		InsuranceProceeds: self.InsuranceProceeds - before.InsuranceProceeds,
+		SideBetProceeds:   self.SideBetProceeds - before.SideBetProceeds,
+	}
 }
 
 type BlackJackStats struct {
-	DoubleDownCount int
-	SurrenderCount  int
-	SplitCount      int
-	AcesSplit       int
+	DoubleDownCount int `json:"double_down_count"`
+	// doubling down on more than two cards
+	DoubleDownAfterHitCount int `json:"double_down_after_hit_count"`
+	// doubling down for less than the hand's bet
+	DoubleForLessCount int `json:"double_for_less_count"`
+	// doubling down again on a hand that was doubled down on
+	RedoubleCount int `json:"redouble_count"`
+	// rescues are counted in SurrenderCount too
+	DoubleDownRescueCount int `json:"double_down_rescue_count"`
+	// eg a five card 21 in Spanish 21
+	BonusPayoutCount int `json:"bonus_payout_count"`
+	SurrenderCount   int `json:"surrender_count"`
+	// early surrenders are counted in SurrenderCount too
+	EarlySurrenderCount int `json:"early_surrender_count"`
+	SplitCount          int `json:"split_count"`
+	AcesSplit           int `json:"aces_split"`
+	InsuranceCount      int `json:"insurance_count"`
+	// the split aces house rules at work
+	AcesResplit      int `json:"aces_resplit"`
+	SplitAcesHit     int `json:"split_aces_hit"`
+	SplitAcesDoubled int `json:"split_aces_doubled"`
+	// Blackjack Switch, once per player that switched
+	SwitchCount int `json:"switch_count"`
+	// Blackjack Switch and Free Bet, hands pushed by a dealer 22
+	Dealer22PushCount int `json:"dealer_22_push_count"`
+	// Free Bet blackjack, the double downs and splits the house put up the bet for
+	FreeDoubleCount int `json:"free_double_count"`
+	FreeSplitCount  int `json:"free_split_count"`
+	// money won on free bets
+	FreeBetWinnings money.Money `json:"free_bet_winnings"`
+	// Pontoon, counted in BonusPayoutCount too
+	FiveCardTrickCount int `json:"five_card_trick_count"`
+	// bonus hands, hands won by a Charlie whatever the dealer had
+	CharlieCount int `json:"charlie_count"`
+	// the shoes played out to the cut card and the cards dealt from them, see Penetration()
+	ShoesShuffled  int `json:"shoes_shuffled"`
+	ShoeCardsDealt int `json:"shoe_cards_dealt"`
+	// a round that ran out of cards, and was finished with the discards reshuffled
+	ShoeExhaustedCount int `json:"shoe_exhausted_count"`
+	// the table clock, see RoundsPerHour()
+	RoundsPlayed int     `json:"rounds_played"`
+	TableSeconds float64 `json:"table_seconds"`
 }
 
 func CreateBlackJackStats() BlackJackStats {
 	return BlackJackStats{
-		DoubleDownCount: 0,
-		SurrenderCount:  0,
-		SplitCount:      0,
-		AcesSplit:       0,
+		DoubleDownCount:         0,
+		DoubleDownAfterHitCount: 0,
+		DoubleForLessCount:      0,
+		RedoubleCount:           0,
+		DoubleDownRescueCount:   0,
+		BonusPayoutCount:        0,
+		SurrenderCount:          0,
+		SplitCount:              0,
+		AcesSplit:               0,
+		InsuranceCount:          0,
+		AcesResplit:             0,
+		SplitAcesHit:            0,
+		SplitAcesDoubled:        0,
+		SwitchCount:             0,
+		Dealer22PushCount:       0,
+		FreeDoubleCount:         0,
+		FreeSplitCount:          0,
+		FreeBetWinnings:         0,
+		FiveCardTrickCount:      0,
+		CharlieCount:            0,
+		ShoesShuffled:           0,
+		ShoeCardsDealt:          0,
+		ShoeExhaustedCount:      0,
+		RoundsPlayed:            0,
+		TableSeconds:            0,
 	}
 }
 
 type BlackJack struct {
 	Shoe    []cards.Card
 	ShoeTop int
+	// the round that deals the card at CutCard is the shoe's last,
+	// 0 => placed when the next round starts, see placeCutCard()
+	CutCard int
 	Players []*Player
 	Results map[string]*BlackJackPlayerResults
-	Stats   BlackJackStats
+	// by side bet name
+	SideBetResults map[string]*SideBetResults
+	Stats          BlackJackStats
+	Rules          *house_rules.HouseRules
+	// nil => shuffle with the cards package's shared random generator
+	Random *rand.Rand
+	// false => no play by play logging, eg when simulating millions of games
+	Verbose   bool
+	Listeners []GameListener
+	// nil => the dealer plays by the house rules' DealerPolicy()
+	DealerPolicy house_rules.DealerPolicy
+	// nil => the house rules' Shuffler, see cards.CreateShuffler()
+	Shuffler cards.Shuffler
+	Discards *DiscardTray
+
+	// where the cards of the round being played start in the shoe
+	roundStart int
+	// the cards dealt before the discards were reshuffled, see reshuffleDiscards()
+	exhaustedCards int
+	// the cards dealt this round, discarded once it is over
+	roundCards []cards.Card
+	// the rounds dealt since the shoe was shuffled, see IsMidShoe()
+	roundsThisShoe int
 }
 
 func CreateBlackJack() *BlackJack {
 	blackjack := BlackJack{
-		Shoe:    cards.CreateShoe(),
-		ShoeTop: 0,
-		Players: []*Player{},
-		Results: make(map[string]*BlackJackPlayerResults),
-		Stats:   CreateBlackJackStats(),
+		Shoe:           cards.CreateShoe(),
+		ShoeTop:        0,
+		CutCard:        0,
+		Players:        []*Player{},
+		Results:        make(map[string]*BlackJackPlayerResults),
+		SideBetResults: make(map[string]*SideBetResults),
+		Stats:          CreateBlackJackStats(),
+		Rules:          house_rules.CreateHouseRules(),
+		Random:         nil,
+		Verbose:        true,
+		Listeners:      []GameListener{},
+		Discards:       CreateDiscardTray(),
+	}
+	return &blackjack
+}
+
+// CreateBlackJackWithRules() gives the game its own rules and its own seeded
+// random generator, so that many games can be simulated side by side.
+func CreateBlackJackWithRules(rules *house_rules.HouseRules, seed int64) *BlackJack {
+	var random *rand.Rand = cards.CreateRandom(seed)
+	blackjack := BlackJack{
+		Shoe:           cards.CreateShoeFromDeck(cards.DeckForRules(rules), rules.DecksInShoe, random),
+		ShoeTop:        0,
+		CutCard:        0,
+		Players:        []*Player{},
+		Results:        make(map[string]*BlackJackPlayerResults),
+		SideBetResults: make(map[string]*SideBetResults),
+		Stats:          CreateBlackJackStats(),
+		Rules:          rules,
+		Random:         random,
+		Verbose:        true,
+		Listeners:      []GameListener{},
+		Discards:       CreateDiscardTray(),
 	}
 	return &blackjack
 }
@@ -55,17 +188,119 @@ func (self *BlackJack) NumPlayers() int {
 	return len(self.Players)
 }
 
+func (self *BlackJack) random() *rand.Rand {
+	if self.Random != nil {
+		return self.Random
+	}
+	return cards.SharedRandom()
+}
+
+func (self *BlackJack) shuffler() cards.Shuffler {
+	if self.Shuffler == nil {
+		shuffler, err := cards.CreateShuffler(self.Rules)
+		if err != nil {
+			// the rules have not been validated, shuffle as always
+			shuffler = &cards.RandomShuffler{}
+		}
+		self.Shuffler = shuffler
+	}
+	return self.Shuffler
+}
+
+// continuousShuffler() is nil unless the cards are dealt from a continuous shuffling machine
+func (self *BlackJack) continuousShuffler() cards.ContinuousShuffler {
+	csm, _ := self.shuffler().(cards.ContinuousShuffler)
+	return csm
+}
+
 func (self *BlackJack) ReshuffleShoe() {
-	cards.ShuffleShoe(self.Shoe)
+	if self.ShoeTop > 0 {
+		self.Stats.ShoesShuffled++
+		self.Stats.ShoeCardsDealt += self.exhaustedCards + self.ShoeTop
+	}
+	self.shuffler().Shuffle(self.Shoe, self.random())
+	self.Stats.TableSeconds += self.shuffler().ShuffleSeconds(len(self.Shoe))
 	self.ShoeTop = 0
+	self.roundStart = 0
+	self.exhaustedCards = 0
+	self.roundsThisShoe = 0
+	self.Discards.Empty()
+	self.placeCutCard()
+	var event GameEvent = GameEvent{Type: SHOE_SHUFFLED}
+	if procedure, ok := self.shuffler().(*cards.ProcedureShuffler); ok {
+		event.Cuts = slices.Clone(procedure.Cuts)
+	}
+	self.emit(event)
+	if self.continuousShuffler() == nil {
+		self.burnCards()
+	}
+}
+
+// placeCutCard() puts the cut card in at the rules' ForceReshuffle, give or take the CutCardSpread
+func (self *BlackJack) placeCutCard() {
+	self.CutCard = self.Rules.ForceReshuffle
+	if self.Rules.CutCardSpread > 0 {
+		self.CutCard += self.random().Intn(2*self.Rules.CutCardSpread+1) - self.Rules.CutCardSpread
+	}
+	self.CutCard = min(max(self.CutCard, 1), len(self.Shoe)-1)
+}
+
+// IsCutCardOut() is true once the cut card has come out, the round is
+// finished and then the shoe is reshuffled.
+func (self *BlackJack) IsCutCardOut() bool {
+	return self.CutCard > 0 && self.ShoeTop > self.CutCard
+}
+
+// Penetration() is how much of each shoe was dealt before it was reshuffled, on average, eg 0.75
+func (self *BlackJack) Penetration() float64 {
+	if self.Stats.ShoesShuffled == 0 {
+		return 0
+	}
+	return float64(self.Stats.ShoeCardsDealt) / float64(self.Stats.ShoesShuffled*len(self.Shoe))
 }
 
 func (self *BlackJack) GetCardFromShoe() cards.Card {
+	if csm := self.continuousShuffler(); csm != nil {
+		var card cards.Card = csm.Deal(self.random())
+		self.roundCards = append(self.roundCards, card)
+		return card
+	}
+	if self.ShoeTop >= len(self.Shoe) {
+		self.reshuffleDiscards()
+	}
 	card := self.Shoe[self.ShoeTop]
 	self.ShoeTop++
+	self.roundCards = append(self.roundCards, card)
 	return card
 }
 
+// reshuffleDiscards() finishes a round that ran out of cards, the way a
+// dealer would: the cards on the table stay put and the discards are
+// reshuffled to deal from.  The shoe is reshuffled in full before the next round.
+func (self *BlackJack) reshuffleDiscards() {
+	self.Stats.ShoeExhaustedCount++
+	self.log("shoe exhausted: reshuffling the discards")
+	onTable := len(self.Shoe) - self.roundStart
+	if onTable >= len(self.Shoe) {
+		// nothing has been discarded, the round has used up the whole shoe
+		onTable = 0
+	}
+	// the cards on the table go to the front, out of the way
+	var tableCards []cards.Card = append([]cards.Card{}, self.Shoe[self.roundStart:]...)
+	copy(self.Shoe[onTable:], self.Shoe[:self.roundStart])
+	copy(self.Shoe, tableCards[:onTable])
+	self.shuffler().Shuffle(self.Shoe[onTable:], self.random())
+	self.Discards.Empty()
+	self.Stats.TableSeconds += self.shuffler().ShuffleSeconds(len(self.Shoe) - onTable)
+	self.exhaustedCards += len(self.Shoe) - onTable
+	self.ShoeTop = onTable
+	self.roundStart = 0
+	// the cut card came out long ago, 0 => the whole shoe was reshuffled,
+	// it is as good as a new one
+	self.CutCard = onTable
+	self.emit(GameEvent{Type: SHOE_SHUFFLED})
+}
+
 func (self *BlackJack) SetPlayersForGame(players []*Player) {
 	self.Players = players
 	for i := 0; i < self.NumPlayers(); i++ {
@@ -73,11 +308,14 @@ func (self *BlackJack) SetPlayersForGame(players []*Player) {
 		_, ok := self.Results[player.Name]
 		if !ok {
 			self.Results[player.Name] = &BlackJackPlayerResults{
-				HandsPlayed: 0,
-				HandsWon:    0,
-				HandsLost:   0,
-				HandsPushed: 0,
-				Proceeds:    0,
+				HandsPlayed:       0,
+				HandsWon:          0,
+				HandsLost:         0,
+				HandsPushed:       0,
+				RoundsSatOut:      0,
+				Proceeds:          0,
+				InsuranceProceeds: 0,
+				SideBetProceeds:   0,
 			}
 		}
 	}
@@ -90,13 +328,25 @@ func abs(i int) int {
 	return i
 }
 
+// AddResult() settles a hand, initialBet is the master hand's bet before any
+// split or double, result what the hand won or lost, both to the cent.
 func (self *BlackJack) AddResult(
 	player *Player,
+	masterHandIndex int,
 	handIndex int,
 	playerHand *PlayerHand,
-	initialBet int,
-	result int,
+	initialBet money.Money,
+	result money.Money,
 ) {
+	self.emit(GameEvent{
+		Type:            HAND_SETTLED,
+		PlayerName:      player.Name,
+		MasterHandIndex: masterHandIndex,
+		HandIndex:       handIndex,
+		Outcome:         playerHand.OutCome,
+		Result:          result,
+	})
+
 	self.Results[player.Name].HandsPlayed++
 	if result > 0 {
 		self.Results[player.Name].HandsWon++
@@ -106,9 +356,10 @@ func (self *BlackJack) AddResult(
 		self.Results[player.Name].HandsPushed++
 	}
 	self.Results[player.Name].Proceeds += result
+	// the bet comes off the table, along with what it won
+	player.Collect(playerHand.Stake() + result)
 
-	isDoubleDown := playerHand.NumCards() == 3 && abs(initialBet)*2 == abs(result)
-	if isDoubleDown {
+	if playerHand.DoubleDowns > 0 {
 		self.Stats.DoubleDownCount++
 	}
 
@@ -120,286 +371,58 @@ func (self *BlackJack) AddResult(
 		self.Stats.SplitCount++
 	}
 
-	splittingAces := playerHand.FromSplit && playerHand.Cards[0].Rank == cards.ACE
-	if splittingAces {
+	if playerHand.IsSplitAces() {
 		self.Stats.AcesSplit++
 	}
 }
 
-func (self *BlackJack) log(msg string) {
-	fmt.Println(msg)
+// addLoss() settles a losing hand, which loses nothing when only free bets
+// are on it, and counts as lost all the same.
+func (self *BlackJack) addLoss(player *Player, masterHandIndex int, handIndex int, playerHand *PlayerHand) {
+	self.AddResult(player, masterHandIndex, handIndex, playerHand, money.Dollars(player.Bets[masterHandIndex]), -playerHand.Stake())
+	if playerHand.Bet == 0 {
+		// AddResult() took it for a push
+		self.Results[player.Name].HandsPushed--
+		self.Results[player.Name].HandsLost++
+	}
 }
 
-func (self *BlackJack) PlayGame() {
-	if self.ShoeTop > house_rules.FORCE_RESHUFFLE {
-		self.ReshuffleShoe()
+func (self *BlackJack) GetDealerPolicy() house_rules.DealerPolicy {
+	if self.DealerPolicy != nil {
+		return self.DealerPolicy
 	}
+	return self.Rules.DealerPolicy()
+}
 
-	// setting up the dealer and the player(s) could be done
-	// by the caller and pass here via parameters.
-
-	var dealer *Dealer = CreateDealer()
+func (self *BlackJack) log(msg string) {
+	if self.Verbose {
+		fmt.Println(msg)
+	}
+}
 
+// The original table: Jack plays one hand, Jill plays two.
+// In Blackjack Switch Jack plays two hands as well.
+func (self *BlackJack) setDefaultPlayers() {
 	var player1 *Player = CreatePlayer("Jack")
 	var player2 *Player = CreatePlayer("Jill")
 
-	self.SetPlayersForGame([]*Player{player1, player2})
-
 	initialBet := 2
-	player1.SetGameBets([]int{initialBet})
-	player2.SetGameBets([]int{initialBet, initialBet})
-
-	//
-	// DEAL HANDS
-	//
-
-	self.log("\n\nDEAL HANDS")
-
-	var card cards.Card
-
-	for i := 0; i < 2; i++ {
-		for j := 0; j < self.NumPlayers(); j++ {
-			var player *Player = self.Players[j]
-			for k := 0; k < player.NumMasterHands(); k++ {
-				card = self.GetCardFromShoe()
-				var masterHand *PlayerMasterHand = player.PlayerMasterHands[k]
-				var firstHand *PlayerHand = masterHand.Hands[0]
-				firstHand.AddCard(card)
-			}
-		}
-
-		card = self.GetCardFromShoe()
-		dealer.DealerHand.AddCard(card)
-	}
-
-	var dealerTopCard cards.Card = dealer.TopCard()
-	self.log(fmt.Sprintf("dealer top card: %v", dealerTopCard.Str()))
-
-	var dealerHoleCard cards.Card = dealer.HoleCard()
+	player1.Bets = self.Rules.StartingBets(initialBet)
+	player2.Bets = []int{initialBet, initialBet}
 
-	//
-	// PLAY HANDS
-	//
-
-	self.log("PLAY HANDS")
-
-	if dealer.DealerHand.IsNatural() {
-		// a real simulation would have to take care of Insurance, which is a sucker's bet,
-		// so we just assume that no player will ask for insurance.
-		// two cases:
-		//     1. player has a natural and their bet is pushed
-		//     2. player loses
-
-		dealer.DealerHand.OutCome = HandOutcome(DEALER_BLACKJACK)
-
-		for i := 0; i < self.NumPlayers(); i++ {
-			var player *Player = self.Players[i]
-			for j := 0; j < player.NumMasterHands(); j++ {
-				var masterHand *PlayerMasterHand = player.PlayerMasterHands[j]
-				for k := 0; k < masterHand.NumHands(); k++ {
-					// really should only be one hand in the master hand at this point
-					var hand *PlayerHand = masterHand.Hands[k]
-					// standing will do the right thing in the settlement logic below
-					hand.OutCome = HandOutcome(STAND)
-				}
-			}
-		}
-
-	} else {
-		// dealer does not have a natural
-		for i := 0; i < self.NumPlayers(); i++ {
-			var player *Player = self.Players[i]
-			self.log((fmt.Sprintf("player %v - %v", i+1, player.Name)))
-			for j := 0; j < player.NumMasterHands(); j++ {
-				var masterHand *PlayerMasterHand = player.PlayerMasterHands[j]
-				for k := 0; k < masterHand.NumHands(); k++ {
-					var hand *PlayerHand = masterHand.Hands[k]
-
-					self.log(fmt.Sprintf("    hand %v.%v:", j+1, k+1))
-					for l := 0; l < hand.NumCards(); l++ {
-						var card cards.Card = hand.Cards[l]
-						self.log(fmt.Sprintf("        card %v: %v", l+1, card.Str()))
-					}
-
-					var isSplitPossible bool = masterHand.NumHands() < masterHand.HANDS_LIMIT
-
-					// Need to make decisions per player hand ...
-					for {
-						if hand.OutCome == HandOutcome(STAND) {
-							// product of a prior ace split, outcome has already been determined.
-							self.log(fmt.Sprintf("        prior aces split: %v, total H%v S%v", strategy.PlayerDecision(strategy.STAND), hand.HardCount(), hand.SoftCount()))
-							break
-						}
-
-						var decision strategy.PlayerDecision = strategy.DetermineBasicStrategyPlay(
-							dealerTopCard, hand, isSplitPossible,
-						)
-						self.log(fmt.Sprintf("        basic strategy: %v", decision))
-
-						if decision == strategy.STAND {
-							hand.OutCome = HandOutcome(STAND)
-							self.log(fmt.Sprintf("        stand total H%v S%v", hand.HardCount(), hand.SoftCount()))
-							break
-
-						} else if decision == strategy.SURRENDER {
-							hand.OutCome = HandOutcome(strategy.SURRENDER)
-							hand.Bet = int(hand.Bet / 2)
-							break
-
-						} else if decision == strategy.DOUBLE {
-							card = self.GetCardFromShoe()
-							hand.AddCard(card)
-							hand.Bet *= 2
-							self.log(fmt.Sprintf("        hit: %v, total H%v S%v", card.Str(), hand.HardCount(), hand.SoftCount()))
-							hand.OutCome = HandOutcome(STAND)
-							self.log(fmt.Sprintf("        stand total H%v S%v", hand.HardCount(), hand.SoftCount()))
-							break
-
-						} else if decision == strategy.HIT {
-							card = self.GetCardFromShoe()
-							hand.AddCard(card)
-							handTotal := hand.Count()
-							self.log(fmt.Sprintf("        hit: %v, total H%v S%v", card.Str(), hand.HardCount(), hand.SoftCount()))
-							if handTotal > 21 {
-								hand.OutCome = HandOutcome(BUST)
-								self.log(fmt.Sprintf("        %v", hand.OutCome))
-								break
-							} else {
-								hand.OutCome = HandOutcome(IN_PLAY)
-							}
-
-						} else if decision == strategy.SPLIT {
-							var card1 cards.Card = self.GetCardFromShoe()
-							var card2 cards.Card = self.GetCardFromShoe()
-							var handIndex int = k
-							var newHandIndex int = masterHand.SplitHand(handIndex, [2]cards.Card{card1, card2})
-							self.log(fmt.Sprintf("        split, new hand index %v, adding cards %v, %v", newHandIndex+1, card1.Str(), card2.Str()))
-							self.log(fmt.Sprintf("        card 1: %v", hand.Cards[0].Str()))
-							self.log(fmt.Sprintf("        card 2: %v", hand.Cards[1].Str()))
-							splittingAces := hand.Cards[0].Rank == cards.ACE
-							if splittingAces && house_rules.NO_MORE_CARDS_AFTER_SPLITTING_ACES {
-								hand.OutCome = HandOutcome(STAND)
-								self.log(fmt.Sprintf("        aces split: %v, total H%v S%v", strategy.PlayerDecision(strategy.STAND), hand.HardCount(), hand.SoftCount()))
-								masterHand.Hands[newHandIndex].OutCome = HandOutcome(STAND)
-								break
-							}
-
-						} else {
-							self.log("FTW")
-							self.log(fmt.Sprintf("FTW: dealerTopCard: %v, isSplitPossible %v", dealerTopCard.Str(), isSplitPossible))
-							self.log(fmt.Sprintf("FTW: player hand count: H%v S%v", hand.HardCount(), hand.SoftCount()))
-							self.log(fmt.Sprintf("FTW: decision: %v", decision))
-							hand.OutCome = HandOutcome(STAND)
-							break
-						}
-					}
-				}
-			}
-		}
-
-		//
-		// DEALER HAND
-		//
-
-		self.log("DEALER HAND")
-		self.log(fmt.Sprintf("dealer top card: %v", dealerTopCard.Str()))
-		self.log(fmt.Sprintf("dealer hole card: %v", dealerHoleCard.Str()))
-		var dealerDone bool = false
-		for !dealerDone {
-			hardCount := dealer.DealerHand.HardCount()
-			softCount := dealer.DealerHand.SoftCount()
-			var useSoftCount bool = hardCount < softCount && softCount < 21
-			if useSoftCount && softCount <= house_rules.DEALER_HITS_SOFT_ON {
-				card = self.GetCardFromShoe()
-				dealer.DealerHand.AddCard(card)
-				self.log(fmt.Sprintf("    add: %v", card.Str()))
-			} else if !useSoftCount && hardCount <= house_rules.DEALER_HITS_HARD_ON {
-				card = self.GetCardFromShoe()
-				dealer.DealerHand.AddCard(card)
-				self.log(fmt.Sprintf("    add: %v", card.Str()))
-			} else {
-				dealer.DealerHand.OutCome = HandOutcome(STAND)
-				dealerDone = true
-				self.log(fmt.Sprintf("    stand: total H%v S%v", dealer.DealerHand.HardCount(), dealer.DealerHand.SoftCount()))
-			}
-
-			if dealer.DealerHand.Count() > 21 {
-				dealer.DealerHand.OutCome = HandOutcome(BUST)
-				dealerDone = true
-				self.log("    bust")
-			}
-		}
-	}
-
-	//
-	// SETTLE HANDS
-	//
-
-	self.log("SETTLE HAND")
-	if dealer.DealerHand.OutCome == HandOutcome(DEALER_BLACKJACK) {
-		for i := 0; i < self.NumPlayers(); i++ {
-			var player *Player = self.Players[i]
-			self.log(fmt.Sprintf("Player %v - %v", i+1, player.Name))
-			for j := 0; j < player.NumMasterHands(); j++ {
-				var masterHand *PlayerMasterHand = player.PlayerMasterHands[j]
-				for k := 0; k < masterHand.NumHands(); k++ {
-					var hand *PlayerHand = masterHand.Hands[k]
-					if hand.IsNatural() {
-						self.AddResult(player, k, hand, initialBet, 0)
-						self.log(fmt.Sprintf("    hand %v.%v: push both player and dealer had naturals", j+1, k+1))
-					} else {
-						self.AddResult(player, k, hand, initialBet, -hand.Bet)
-						self.log(fmt.Sprintf("    hand %v.%v: dealer natural: lost $%v", j+1, k+1, hand.Bet))
-					}
-				}
-			}
-		}
+	self.SetPlayersForGame([]*Player{player1, player2})
+}
 
-	} else {
-		// dealer does not have a natural
-		for i := 0; i < self.NumPlayers(); i++ {
-			var player *Player = self.Players[i]
-			self.log(fmt.Sprintf("Player %v - %v", i+1, player.Name))
-			for j := 0; j < player.NumMasterHands(); j++ {
-				var masterHand *PlayerMasterHand = player.PlayerMasterHands[j]
-				for k := 0; k < masterHand.NumHands(); k++ {
-					var hand *PlayerHand = masterHand.Hands[k]
-					if hand.OutCome == HandOutcome(BUST) {
-						self.AddResult(player, k, hand, initialBet, -hand.Bet)
-						self.log(fmt.Sprintf("    hand %v.%v: bust: lost $%v", j+1, k+1, hand.Bet))
-
-					} else if hand.OutCome == HandOutcome(SURRENDER) {
-						self.AddResult(player, k, hand, initialBet, -hand.Bet)
-						self.log(fmt.Sprintf("    hand %v.%v: surrender: lost $%v", j+1, k+1, hand.Bet))
-
-					} else {
-						// player has a non-bust, non-surrender hand
-						if hand.IsNatural() {
-							var payout int = int(float32(hand.Bet) * house_rules.NATURAL_BLACKJACK_PAYOUT)
-							self.AddResult(player, k, hand, initialBet, payout)
-							self.log(fmt.Sprintf("    hand %v.%v: natural: won $%v", j+1, k+1, payout))
-
-						} else if dealer.DealerHand.OutCome == HandOutcome(BUST) {
-							self.AddResult(player, k, hand, initialBet, hand.Bet)
-							self.log(fmt.Sprintf("    hand %v.%v: dealer bust: won $%v", j+1, k+1, hand.Bet))
-
-						} else {
-							if hand.Count() < dealer.DealerHand.Count() {
-								self.AddResult(player, k, hand, initialBet, -hand.Bet)
-								self.log(fmt.Sprintf("    hand %v.%v: lost $%v", j+1, k+1, hand.Bet))
-
-							} else if hand.Count() > dealer.DealerHand.Count() {
-								self.AddResult(player, k, hand, initialBet, hand.Bet)
-								self.log(fmt.Sprintf("    hand %v.%v: won $%v", j+1, k+1, hand.Bet))
-
-							} else {
-								self.AddResult(player, k, hand, initialBet, 0)
-								self.log(fmt.Sprintf("    hand %v.%v: push", j+1, k+1))
-							}
-						}
-					}
-				}
-			}
+// PlayGame() plays one round with the players' own bets and strategies.
+func (self *BlackJack) PlayGame() {
+	var round *Round = self.StartRound()
+	for !round.IsOver() {
+		var action RoundAction = round.DefaultAction()
+		err := round.Step(action)
+		if err != nil {
+			// a strategy asked for something the rules do not allow
+			self.log(fmt.Sprintf("%v: %v", round.CurrentPlayer().Name, err))
+			round.Step(round.fallbackAction())
 		}
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
 	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
 )
 
@@ -25,7 +26,18 @@ type PlayerHand struct {
 	Cards     []cards.Card
 	FromSplit bool
 	Bet       int
-	OutCome   HandOutcome
+	// the extra bet from doubling down, included in Bet, 0 => no double down
+	DoubleDownBet int
+	// Free Bet blackjack: the bets the house put up, not included in Bet,
+	// which is the player's money
+	FreeBet int
+	// more than 1 => re-doubled
+	DoubleDowns int
+	OutCome     HandOutcome
+	// half the bet, given back by a surrender, 0 => no surrender
+	Surrendered money.Money
+	// Blackjack Switch: the second card came from the other hand
+	Switched bool
 }
 
 // factory
@@ -60,6 +72,20 @@ func (self *PlayerHand) GetCard(cardIndex int) cards.Card {
 	return self.Cards[cardIndex]
 }
 
+// the player's money and the free bets, what a double down or a split has to match
+func (self *PlayerHand) GetBet() int {
+	return self.Bet + self.FreeBet
+}
+
+// the player's money still on the hand, a surrender gave the rest back
+func (self *PlayerHand) Stake() money.Money {
+	return money.Dollars(self.Bet) - self.Surrendered
+}
+
+func (self *PlayerHand) NumDoubleDowns() int {
+	return self.DoubleDowns
+}
+
 func (self *PlayerHand) AddCard(card cards.Card) {
 	self.Cards = append(self.Cards, card)
 }
@@ -117,8 +143,9 @@ func (self *PlayerHand) Count() int {
 	return self.SoftCount()
 }
 
+// a 21 made by splitting or switching is only 21
 func (self *PlayerHand) IsNatural() bool {
-	if !self.FromSplit {
+	if !self.FromSplit && !self.Switched {
 		if self.NumCards() == 2 {
 			if self.SoftCount() == 21 {
 				return true
@@ -153,6 +180,11 @@ func (self *PlayerHand) CanSplit() bool {
 	return false
 }
 
+// one of the hands from splitting aces
+func (self *PlayerHand) IsSplitAces() bool {
+	return self.FromSplit && self.NumCards() > 0 && self.Cards[0].Rank == cards.ACE
+}
+
 func (self *PlayerHand) IsHandOver() bool {
 	switch self.OutCome {
 	case HandOutcome(STAND):
@@ -228,6 +260,15 @@ func (self *DealerHand) SoftCount() int {
 	return soft_count
 }
 
+// Ace => 1, face cards => 10, what a DealerPolicy looks at
+func (self *DealerHand) CardValues() []int {
+	var values []int = make([]int, self.NumCards())
+	for i := 0; i < self.NumCards(); i++ {
+		values[i] = cards.CardRankValue[self.Cards[i].Rank]
+	}
+	return values
+}
+
 func (self *DealerHand) Count() int {
 	// return the highest count for hand,
 	// which is always the soft count.
@@ -271,15 +312,23 @@ func (self *DealerHand) IsHandOver() bool {
 //
 
 type PlayerMasterHand struct {
-	HANDS_LIMIT int
-	Hands       []*PlayerHand
+	HANDS_LIMIT  int
+	RESPLIT_ACES bool
+	Hands        []*PlayerHand
+	// half the original bet when insurance was taken, otherwise zero
+	InsuranceBet money.Money
+	// the first two cards, as dealt, for settling the side bets
+	DealtCards []cards.Card
 }
 
 // factory
 func CreatePlayerMasterHand() *PlayerMasterHand {
 	var master_hand PlayerMasterHand = PlayerMasterHand{
-		Hands:       []*PlayerHand{},
-		HANDS_LIMIT: house_rules.SPLITS_PER_HAND + 1,
+		Hands:        []*PlayerHand{},
+		HANDS_LIMIT:  house_rules.SPLITS_PER_HAND + 1,
+		RESPLIT_ACES: house_rules.RESPLIT_ACES,
+		InsuranceBet: 0,
+		DealtCards:   []cards.Card{},
 	}
 	return &master_hand
 }
@@ -288,6 +337,10 @@ func (self *PlayerMasterHand) NumHands() int {
 	return len(self.Hands)
 }
 
+func (self *PlayerMasterHand) HandsLimit() int {
+	return self.HANDS_LIMIT
+}
+
 func (self *PlayerMasterHand) AddStartHand(bet int) {
 	const from_split bool = false
 
@@ -327,6 +380,11 @@ func (self *PlayerMasterHand) SplitHand(
 	var card1 cards.Card = self.Hands[handIndex].Cards[0]
 	var card2 cards.Card = self.Hands[handIndex].Cards[1]
 
+	if self.Hands[handIndex].IsSplitAces() && !self.RESPLIT_ACES {
+		// should never get here, CanSplit() says no
+		panic("SplitHand() asked to re-split aces, house rules say no.")
+	}
+
 	var oldPlayerHand *PlayerHand
 	oldPlayerHand = self.Hands[handIndex]
 	oldPlayerHand.Cards = []cards.Card{card1, cardsToAdd[0]}
@@ -350,6 +408,9 @@ func (self *PlayerMasterHand) CanSplit(handIndex int) bool {
 	if self.NumHands() < self.HANDS_LIMIT {
 		// master hand allows
 		var hand *PlayerHand = self.Hands[handIndex]
+		if hand.IsSplitAces() && !self.RESPLIT_ACES {
+			return false
+		}
 		if hand.CanSplit() {
 			// individual hand allows
 			return true
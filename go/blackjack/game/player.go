@@ -2,6 +2,9 @@ package game
 
 import (
 	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
 )
 
 //
@@ -16,16 +19,56 @@ import (
 type Player struct {
 	PlayerMasterHands []*PlayerMasterHand
 	Name              string
+	// the bets placed at the start of each game, one per master hand
+	Bets []int
+	// placed on every master hand, see SideBetWager
+	SideBets []SideBetWager
+	Strategy strategy.PlayerStrategy
+	// money not on the table, money.UNLIMITED => never runs out
+	Bankroll money.Money
+	// played the last round, see BlackJack.seatPlayers()
+	seated bool
 }
 
 func CreatePlayer(name string) *Player {
 	var player Player = Player{
 		PlayerMasterHands: []*PlayerMasterHand{},
 		Name:              name,
+		Bets:              []int{},
+		SideBets:          []SideBetWager{},
+		Strategy:          strategy.CreateBasicStrategy(house_rules.CreateHouseRules()),
+		Bankroll:          money.UNLIMITED,
 	}
 	return &player
 }
 
+func (self *Player) HasUnlimitedBankroll() bool {
+	return self.Bankroll == money.UNLIMITED
+}
+
+// BankrollDollars() is the bankroll in whole dollars, what can still be bet,
+// strategy.UNLIMITED_BANKROLL => never runs out
+func (self *Player) BankrollDollars() int {
+	if self.HasUnlimitedBankroll() {
+		return strategy.UNLIMITED_BANKROLL
+	}
+	return self.Bankroll.WholeDollars()
+}
+
+// Wager() takes money off the bankroll and puts it on the table.
+func (self *Player) Wager(amount money.Money) {
+	if !self.HasUnlimitedBankroll() {
+		self.Bankroll -= amount
+	}
+}
+
+// Collect() takes money off the table and back into the bankroll.
+func (self *Player) Collect(amount money.Money) {
+	if !self.HasUnlimitedBankroll() {
+		self.Bankroll += amount
+	}
+}
+
 func (self *Player) NumMasterHands() int {
 	return len(self.PlayerMasterHands)
 }
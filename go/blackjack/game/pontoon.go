@@ -0,0 +1,20 @@
+package game
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
+)
+
+// FiveCardPayout() is what a winning hand of five or more cards is paid,
+// the zero payout => no bonus, the hand is paid even money:
+// Pontoon's five card trick, or a 21 of five or more cards in some Australian games.
+func FiveCardPayout(hand *PlayerHand, rules *house_rules.HouseRules) money.Payout {
+	if strategy.IsFiveCardTrick(hand, rules) {
+		return rules.FiveCardTrickPayout
+	}
+	if !rules.FiveCard21Payout.IsZero() && hand.NumCards() >= 5 && hand.Count() == 21 {
+		return rules.FiveCard21Payout
+	}
+	return money.Payout{}
+}
@@ -0,0 +1,1237 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
+)
+
+// A Round is one game of blackjack, played one step at a time.
+// PlayGame() steps through a round with each player's bets and strategy,
+// while a UI, a table server or a learning agent can make the steps itself:
+// ask LegalActions() what can be done, then Step() with one of them.
+//
+// The phases, in order:
+//     betting          each player places their bets              ACTION_BET or ACTION_SIT_OUT
+//     dealing          two cards to every hand and the dealer     ACTION_CONTINUE
+//     early-surrender  house rules allow it against the top card  ACTION_SURRENDER_EARLY or ACTION_DECLINE_SURRENDER
+//     insurance        dealer shows an Ace, each hand is asked    ACTION_INSURE or ACTION_DECLINE_INSURANCE
+//     switch           Blackjack Switch, each player is asked     ACTION_SWITCH or ACTION_DECLINE_SWITCH
+//     player-turns     each hand is played to its end             ACTION_PLAY
+//     dealer-turn      hole card revealed, then one card a step   ACTION_CONTINUE
+//                      (no hole card: the second card is dealt)
+//     settlement       every hand is paid or collected            ACTION_CONTINUE
+//     round-over       nothing left to do
+
+type RoundPhase string
+
+const (
+	PHASE_BETTING         RoundPhase = "betting"
+	PHASE_DEALING         RoundPhase = "dealing"
+	PHASE_EARLY_SURRENDER RoundPhase = "early-surrender"
+	PHASE_INSURANCE       RoundPhase = "insurance"
+	PHASE_SWITCH          RoundPhase = "switch"
+	PHASE_PLAYER_TURNS    RoundPhase = "player-turns"
+	PHASE_DEALER_TURN     RoundPhase = "dealer-turn"
+	PHASE_SETTLEMENT      RoundPhase = "settlement"
+	PHASE_ROUND_OVER      RoundPhase = "round-over"
+)
+
+type RoundActionType string
+
+const (
+	ACTION_BET               RoundActionType = "bet"
+	ACTION_SIT_OUT           RoundActionType = "sit-out"
+	ACTION_INSURE            RoundActionType = "insure"
+	ACTION_DECLINE_INSURANCE RoundActionType = "decline-insurance"
+	ACTION_SURRENDER_EARLY   RoundActionType = "surrender-early"
+	ACTION_DECLINE_SURRENDER RoundActionType = "decline-surrender"
+	ACTION_SWITCH            RoundActionType = "switch"
+	ACTION_DECLINE_SWITCH    RoundActionType = "decline-switch"
+	ACTION_PLAY              RoundActionType = "play"
+	ACTION_CONTINUE          RoundActionType = "continue"
+)
+
+type RoundAction struct {
+	Type RoundActionType `json:"type"`
+	// ACTION_BET: one bet per master hand
+	Bets []int `json:"bets,omitempty"`
+	// ACTION_PLAY
+	Decision strategy.PlayerDecision `json:"decision,omitempty"`
+	// ACTION_PLAY with DOUBLE: the extra bet, less than the hand's bet is
+	// doubling for less.  0 => double for the hand's bet, or as much of it
+	// as the bankroll covers when the house allows doubling for less.
+	Amount int `json:"amount,omitempty"`
+}
+
+func BetAction(bets []int) RoundAction {
+	return RoundAction{Type: ACTION_BET, Bets: bets}
+}
+
+func PlayAction(decision strategy.PlayerDecision) RoundAction {
+	return RoundAction{Type: ACTION_PLAY, Decision: decision}
+}
+
+func DoubleAction(amount int) RoundAction {
+	return RoundAction{Type: ACTION_PLAY, Decision: strategy.DOUBLE, Amount: amount}
+}
+
+var CONTINUE RoundAction = RoundAction{Type: ACTION_CONTINUE}
+var SIT_OUT RoundAction = RoundAction{Type: ACTION_SIT_OUT}
+var INSURE RoundAction = RoundAction{Type: ACTION_INSURE}
+var DECLINE_INSURANCE RoundAction = RoundAction{Type: ACTION_DECLINE_INSURANCE}
+var SURRENDER_EARLY RoundAction = RoundAction{Type: ACTION_SURRENDER_EARLY}
+var DECLINE_SURRENDER RoundAction = RoundAction{Type: ACTION_DECLINE_SURRENDER}
+var SWITCH RoundAction = RoundAction{Type: ACTION_SWITCH}
+var DECLINE_SWITCH RoundAction = RoundAction{Type: ACTION_DECLINE_SWITCH}
+
+var ErrIllegalAction = errors.New("illegal action")
+
+// InsuranceStrategy is optional for a PlayerStrategy, without it
+// PlayGame() declines insurance, which is a sucker's bet.
+type InsuranceStrategy interface {
+	TakeInsurance(dealerTopCard cards.Card, playerHand strategy.PlayerHandInterface) bool
+}
+
+// EarlySurrenderStrategy is optional too, without it PlayGame() declines
+// early surrender and the hand can still surrender late.
+type EarlySurrenderStrategy interface {
+	SurrenderEarly(dealerTopCard cards.Card, playerHand strategy.PlayerHandInterface) bool
+}
+
+// DoubleDownStrategy is optional as well, without it PlayGame() doubles down
+// for the full bet, or for less when that is all the bankroll has left.
+// maxAmount is the most the hand can double for, the amount returned is
+// clamped to between 1 and maxAmount.
+type DoubleDownStrategy interface {
+	DoubleDownAmount(dealerTopCard cards.Card, playerHand strategy.PlayerHandInterface, maxAmount int) int
+}
+
+// SwitchStrategy is optional in Blackjack Switch, without it PlayGame()
+// keeps the cards as dealt.  firstHand and secondHand are the player's two
+// hands, true => switch their second cards.
+type SwitchStrategy interface {
+	SwitchCards(dealerTopCard cards.Card, firstHand strategy.PlayerHandInterface, secondHand strategy.PlayerHandInterface) bool
+}
+
+// BettingStrategy is optional, without it PlayGame() bets the player's Bets
+// every round.  bankroll is what the player can still bet, in dollars, and
+// sideBets what the side bets take on every master hand.  No bets => the
+// player sits the round out.
+type BettingStrategy interface {
+	DetermineBets(bankroll int, sideBets int) []int
+}
+
+// SeatStrategy is optional, without it PlayGame() plays the player every
+// round.  It is asked before every round, false => the player sits the
+// round out and watches it, see Round.Watchers and house_rules.NoMidShoeEntry.
+type SeatStrategy interface {
+	PlaysRound() bool
+}
+
+// HoleCardStrategy is optional, it is asked instead of DeterminePlay() when
+// the players can see the dealer's hole card, as in Double Exposure.
+type HoleCardStrategy interface {
+	DeterminePlayWithHoleCard(
+		dealerTopCard cards.Card,
+		dealerHoleCard cards.Card,
+		playerHand strategy.PlayerHandInterface,
+		legalActions []strategy.PlayerDecision,
+	) strategy.PlayerDecision
+}
+
+// TellStrategy is optional, it is asked instead of DeterminePlay() when
+// the dealer has given away whether the hole card is a ten, see HoleCardView.
+type TellStrategy interface {
+	DeterminePlayWithTell(
+		dealerTopCard cards.Card,
+		holeCardIsTen bool,
+		playerHand strategy.PlayerHandInterface,
+		legalActions []strategy.PlayerDecision,
+	) strategy.PlayerDecision
+}
+
+type Round struct {
+	Phase   RoundPhase
+	Dealer  *Dealer
+	Players []*Player
+	// the players sitting the round out, they see the cards all the same
+	Watchers []*Player
+
+	blackjack *BlackJack
+	// the player / master hand / hand whose turn it is
+	playerIndex     int
+	masterHandIndex int
+	handIndex       int
+	holeCardShown   bool
+	// where the hole card is in the round's cards, -1 => not dealt
+	holeCardIndex int
+	// the hole card was flashed to first base, or a tell gave it away, see HoleCardVisibility()
+	holeCardFlashed bool
+	holeCardTold    bool
+}
+
+// StartRound() sets up the next game, reshuffling first when the cut card
+// came out in the last one.
+func (self *BlackJack) StartRound() *Round {
+	if csm := self.continuousShuffler(); csm != nil {
+		if csm.NumCards() == 0 {
+			// the machine is loaded with the whole shoe to start with
+			csm.Shuffle(self.Shoe, self.random())
+		}
+	} else {
+		if self.CutCard == 0 {
+			self.placeCutCard()
+		}
+		if self.IsCutCardOut() {
+			self.ReshuffleShoe()
+		}
+	}
+	self.roundStart = self.ShoeTop
+	self.roundCards = self.roundCards[:0]
+
+	// the players are set up by the caller via SetPlayersForGame(),
+	// otherwise the original Jack and Jill table is used.
+	if self.NumPlayers() == 0 {
+		self.setDefaultPlayers()
+	}
+
+	players, watchers := self.seatPlayers()
+	var round Round = Round{
+		Phase:     PHASE_BETTING,
+		Dealer:    CreateDealer(),
+		Players:   players,
+		Watchers:  watchers,
+		blackjack: self,
+		// not dealt yet
+		holeCardIndex: -1,
+	}
+	if len(players) == 0 {
+		// nobody to bet, the dealer deals on for the rest of the table
+		round.Phase = PHASE_DEALING
+	}
+	return &round
+}
+
+func (self *Round) IsOver() bool {
+	return self.Phase == PHASE_ROUND_OVER
+}
+
+// CurrentPlayer() is nil when the dealer is the one to act.
+func (self *Round) CurrentPlayer() *Player {
+	switch self.Phase {
+	case PHASE_BETTING, PHASE_EARLY_SURRENDER, PHASE_INSURANCE, PHASE_SWITCH, PHASE_PLAYER_TURNS:
+		return self.Players[self.playerIndex]
+	}
+	return nil
+}
+
+func (self *Round) CurrentMasterHand() *PlayerMasterHand {
+	switch self.Phase {
+	case PHASE_EARLY_SURRENDER, PHASE_INSURANCE, PHASE_PLAYER_TURNS:
+		return self.Players[self.playerIndex].PlayerMasterHands[self.masterHandIndex]
+	}
+	return nil
+}
+
+func (self *Round) CurrentHand() *PlayerHand {
+	if self.Phase != PHASE_PLAYER_TURNS {
+		return nil
+	}
+	return self.CurrentMasterHand().Hands[self.handIndex]
+}
+
+// CurrentHandIndices() returns the master hand and hand index of the current hand.
+func (self *Round) CurrentHandIndices() (int, int) {
+	return self.masterHandIndex, self.handIndex
+}
+
+func (self *Round) DealerTopCard() cards.Card {
+	return self.Dealer.TopCard()
+}
+
+// DealerHoleCard() is the dealer's hole card, when the players can see it:
+// false => the hole card is face down.
+func (self *Round) DealerHoleCard() (cards.Card, bool) {
+	if !self.holeCardShown {
+		return cards.Card{}, false
+	}
+	return self.Dealer.HoleCard(), true
+}
+
+func (self *Round) LegalActions() []RoundAction {
+	switch self.Phase {
+	case PHASE_BETTING:
+		return []RoundAction{BetAction(nil), SIT_OUT}
+	case PHASE_EARLY_SURRENDER:
+		return []RoundAction{SURRENDER_EARLY, DECLINE_SURRENDER}
+	case PHASE_INSURANCE:
+		return []RoundAction{INSURE, DECLINE_INSURANCE}
+	case PHASE_SWITCH:
+		return []RoundAction{SWITCH, DECLINE_SWITCH}
+	case PHASE_PLAYER_TURNS:
+		var decisions []strategy.PlayerDecision = self.legalDecisions()
+		var actions []RoundAction = []RoundAction{}
+		for i := 0; i < len(decisions); i++ {
+			actions = append(actions, PlayAction(decisions[i]))
+		}
+		return actions
+	case PHASE_DEALING, PHASE_DEALER_TURN, PHASE_SETTLEMENT:
+		return []RoundAction{CONTINUE}
+	}
+	return []RoundAction{}
+}
+
+// the decisions the current hand can make
+func (self *Round) legalDecisions() []strategy.PlayerDecision {
+	return strategy.LegalActions(
+		self.CurrentHand(),
+		self.CurrentMasterHand(),
+		self.blackjack.Rules,
+		self.CurrentPlayer().BankrollDollars(),
+	)
+}
+
+// fallbackAction() is what PlayGame() does instead of a strategy's illegal
+// action: bets the bankroll does not cover sit the round out, a hand
+// stands when it can, and anything else is declined.
+func (self *Round) fallbackAction() RoundAction {
+	switch self.Phase {
+	case PHASE_BETTING:
+		return SIT_OUT
+	case PHASE_EARLY_SURRENDER:
+		return DECLINE_SURRENDER
+	case PHASE_INSURANCE:
+		return DECLINE_INSURANCE
+	case PHASE_SWITCH:
+		return DECLINE_SWITCH
+	case PHASE_PLAYER_TURNS:
+		var decisions []strategy.PlayerDecision = self.legalDecisions()
+		if slices.Contains(decisions, strategy.STAND) {
+			return PlayAction(strategy.STAND)
+		}
+		// eg a Pontoon hand under 15 has to twist
+		return PlayAction(decisions[0])
+	}
+	return CONTINUE
+}
+
+func (self *Round) isLegal(action RoundAction) bool {
+	var legalActions []RoundAction = self.LegalActions()
+	for i := 0; i < len(legalActions); i++ {
+		if legalActions[i].Type == action.Type && legalActions[i].Decision == action.Decision {
+			return true
+		}
+	}
+	return false
+}
+
+// Step() carries out one action, an illegal action changes nothing
+// and returns an error wrapping ErrIllegalAction.
+func (self *Round) Step(action RoundAction) error {
+	if self.blackjack.Rules.PontoonTerms {
+		action.Decision = strategy.FromPontoonTerm(action.Decision)
+	}
+	if !self.isLegal(action) {
+		return fmt.Errorf("%w: %v %v during %v", ErrIllegalAction, action.Type, action.Decision, self.Phase)
+	}
+
+	switch self.Phase {
+	case PHASE_BETTING:
+		if action.Type == ACTION_SIT_OUT {
+			self.sitOut()
+			return nil
+		}
+		return self.placeBets(action.Bets)
+	case PHASE_DEALING:
+		self.deal()
+	case PHASE_EARLY_SURRENDER:
+		self.surrenderEarly(action.Type == ACTION_SURRENDER_EARLY)
+	case PHASE_INSURANCE:
+		self.insure(action.Type == ACTION_INSURE)
+	case PHASE_SWITCH:
+		self.switchCards(action.Type == ACTION_SWITCH)
+	case PHASE_PLAYER_TURNS:
+		amount := 0
+		if action.Decision == strategy.DOUBLE {
+			var err error
+			amount, err = self.doubleDownAmount(action.Amount)
+			if err != nil {
+				return err
+			}
+		}
+		self.play(action.Decision, amount)
+	case PHASE_DEALER_TURN:
+		self.playDealer()
+	case PHASE_SETTLEMENT:
+		self.settle()
+	}
+	return nil
+}
+
+// DefaultAction() is what PlayGame() does: the players' own bets and strategies.
+func (self *Round) DefaultAction() RoundAction {
+	switch self.Phase {
+	case PHASE_BETTING:
+		var player *Player = self.CurrentPlayer()
+		var bets []int = player.Bets
+		bettingStrategy, ok := player.Strategy.(BettingStrategy)
+		if ok {
+			bets = bettingStrategy.DetermineBets(player.BankrollDollars(), player.sideBetsTotal())
+		}
+		if len(bets) == 0 || money.Dollars(player.betsTotal(bets)) > player.Bankroll {
+			// what is left of the bankroll does not cover the bets
+			return SIT_OUT
+		}
+		return BetAction(bets)
+
+	case PHASE_EARLY_SURRENDER:
+		var player *Player = self.CurrentPlayer()
+		earlySurrenderStrategy, ok := player.Strategy.(EarlySurrenderStrategy)
+		if ok && earlySurrenderStrategy.SurrenderEarly(self.DealerTopCard(), self.CurrentMasterHand().Hands[0]) {
+			return SURRENDER_EARLY
+		}
+		return DECLINE_SURRENDER
+
+	case PHASE_INSURANCE:
+		var player *Player = self.CurrentPlayer()
+		insuranceStrategy, ok := player.Strategy.(InsuranceStrategy)
+		if ok && insuranceStrategy.TakeInsurance(self.DealerTopCard(), self.CurrentMasterHand().Hands[0]) {
+			return INSURE
+		}
+		return DECLINE_INSURANCE
+
+	case PHASE_SWITCH:
+		var player *Player = self.CurrentPlayer()
+		switchStrategy, ok := player.Strategy.(SwitchStrategy)
+		if ok && switchStrategy.SwitchCards(
+			self.DealerTopCard(),
+			player.PlayerMasterHands[0].Hands[0],
+			player.PlayerMasterHands[1].Hands[0],
+		) {
+			return SWITCH
+		}
+		return DECLINE_SWITCH
+
+	case PHASE_PLAYER_TURNS:
+		var player *Player = self.CurrentPlayer()
+		var decision strategy.PlayerDecision
+		var view HoleCardView = self.HoleCardVisibility(player)
+		holeCardStrategy, seesHoleCard := player.Strategy.(HoleCardStrategy)
+		tellStrategy, hearsTell := player.Strategy.(TellStrategy)
+		if view.Seen && seesHoleCard {
+			decision = holeCardStrategy.DeterminePlayWithHoleCard(
+				self.DealerTopCard(), view.Card, self.CurrentHand(), self.legalDecisions(),
+			)
+		} else if view.Told && hearsTell {
+			decision = tellStrategy.DeterminePlayWithTell(
+				self.DealerTopCard(), view.IsTen, self.CurrentHand(), self.legalDecisions(),
+			)
+		} else {
+			decision = player.Strategy.DeterminePlay(
+				self.DealerTopCard(), self.CurrentHand(), self.legalDecisions(),
+			)
+		}
+		doubleDownStrategy, ok := player.Strategy.(DoubleDownStrategy)
+		if decision == strategy.DOUBLE && ok {
+			maxAmount := self.maxDoubleDownAmount()
+			amount := doubleDownStrategy.DoubleDownAmount(self.DealerTopCard(), self.CurrentHand(), maxAmount)
+			return DoubleAction(max(1, min(amount, maxAmount)))
+		}
+		return PlayAction(decision)
+	}
+	return CONTINUE
+}
+
+//
+// betting
+//
+
+func (self *Round) placeBets(bets []int) error {
+	err := self.blackjack.Rules.ValidateBets(bets)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrIllegalAction, err)
+	}
+
+	var player *Player = self.CurrentPlayer()
+	total := player.betsTotal(bets)
+	if money.Dollars(total) > player.Bankroll {
+		return fmt.Errorf("%w: bets of $%v exceed the bankroll of $%v", ErrIllegalAction, total, player.Bankroll)
+	}
+	player.Wager(money.Dollars(total))
+	player.Bets = slices.Clone(bets)
+	player.SetGameBets(player.Bets)
+	for i := 0; i < player.NumMasterHands(); i++ {
+		player.PlayerMasterHands[i].HANDS_LIMIT = self.blackjack.Rules.SplitsPerHand + 1
+		player.PlayerMasterHands[i].RESPLIT_ACES = self.blackjack.Rules.ResplitAces
+	}
+
+	self.playerIndex++
+	if self.playerIndex == len(self.Players) {
+		self.playerIndex = 0
+		self.Phase = PHASE_DEALING
+	}
+	return nil
+}
+
+//
+// dealing
+//
+
+func (self *Round) deal() {
+	var blackjack *BlackJack = self.blackjack
+	blackjack.log("\n\nDEAL HANDS")
+	blackjack.emit(GameEvent{Type: ROUND_STARTED})
+
+	var card cards.Card
+
+	for i := 0; i < 2; i++ {
+		for j := 0; j < len(self.Players); j++ {
+			var player *Player = self.Players[j]
+			for k := 0; k < player.NumMasterHands(); k++ {
+				card = blackjack.GetCardFromShoe()
+				var masterHand *PlayerMasterHand = player.PlayerMasterHands[k]
+				var firstHand *PlayerHand = masterHand.Hands[0]
+				firstHand.AddCard(card)
+				blackjack.emitPlayerCard(player, k, 0, card)
+			}
+		}
+
+		if i == 1 && blackjack.Rules.NoHoleCard {
+			// the dealer's second card comes after the players have played
+			break
+		}
+		card = blackjack.GetCardFromShoe()
+		self.Dealer.DealerHand.AddCard(card)
+		if i == 0 || blackjack.Rules.DoubleExposure {
+			blackjack.emitDealerCard(CARD_DEALT, card)
+		} else {
+			self.holeCardIndex = len(blackjack.roundCards) - 1
+			blackjack.emitDealerCard(HOLE_CARD_DEALT, card)
+			self.flashHoleCard()
+		}
+	}
+
+	for j := 0; j < len(self.Players); j++ {
+		var player *Player = self.Players[j]
+		for k := 0; k < player.NumMasterHands(); k++ {
+			var masterHand *PlayerMasterHand = player.PlayerMasterHands[k]
+			masterHand.DealtCards = slices.Clone(masterHand.Hands[0].Cards)
+		}
+	}
+
+	blackjack.log(fmt.Sprintf("dealer top card: %v", self.DealerTopCard().Str()))
+	if blackjack.Rules.DoubleExposure {
+		// dealt face up, there is nothing left to reveal
+		self.holeCardShown = true
+		blackjack.log(fmt.Sprintf("dealer hole card: %v", self.Dealer.HoleCard().Str()))
+	}
+
+	if blackjack.Rules.EarlySurrenderAgainst(cards.CardRankValue[self.DealerTopCard().Rank]) {
+		self.Phase = PHASE_EARLY_SURRENDER
+		self.playerIndex = 0
+		self.masterHandIndex = 0
+		if self.findMasterHand(canSurrenderEarly) {
+			return
+		}
+	}
+	self.offerInsurance()
+}
+
+// findMasterHand() moves on to the first master hand, from the current one,
+// whose starting hand is to be asked(), false when there are none left to ask.
+func (self *Round) findMasterHand(asked func(hand *PlayerHand) bool) bool {
+	for ; self.playerIndex < len(self.Players); self.playerIndex++ {
+		var player *Player = self.Players[self.playerIndex]
+		for ; self.masterHandIndex < player.NumMasterHands(); self.masterHandIndex++ {
+			if asked(player.PlayerMasterHands[self.masterHandIndex].Hands[0]) {
+				return true
+			}
+		}
+		self.masterHandIndex = 0
+	}
+	return false
+}
+
+//
+// early surrender
+//
+
+// nobody gives up on a natural
+func canSurrenderEarly(hand *PlayerHand) bool {
+	return !hand.IsNatural()
+}
+
+// half the bet goes back to the player, the other half is lost at settlement
+func (self *Round) surrender(player *Player, hand *PlayerHand) {
+	hand.OutCome = HandOutcome(strategy.SURRENDER)
+	// to the cent, surrendering $5 loses $2.50
+	hand.Surrendered = money.Cents(money.Dollars(hand.Bet).Cents() / 2)
+	player.Collect(hand.Surrendered)
+}
+
+func (self *Round) surrenderEarly(surrender bool) {
+	var blackjack *BlackJack = self.blackjack
+	if surrender {
+		var player *Player = self.CurrentPlayer()
+		var hand *PlayerHand = self.CurrentMasterHand().Hands[0]
+		self.surrender(player, hand)
+		blackjack.Stats.EarlySurrenderCount++
+		blackjack.log(fmt.Sprintf("%v hand %v: early surrender", player.Name, self.masterHandIndex+1))
+		blackjack.emit(GameEvent{
+			Type:            PLAYER_DECISION,
+			PlayerName:      player.Name,
+			MasterHandIndex: self.masterHandIndex,
+			HandIndex:       0,
+			Decision:        strategy.SURRENDER,
+		})
+	}
+
+	self.masterHandIndex++
+	if !self.findMasterHand(canSurrenderEarly) {
+		self.offerInsurance()
+	}
+}
+
+//
+// insurance
+//
+
+// a hand that has surrendered has nothing left to insure
+func canInsure(hand *PlayerHand) bool {
+	return hand.OutCome != HandOutcome(strategy.SURRENDER)
+}
+
+// no insurance when the hole card is face up for all to see
+func (self *Round) offerInsurance() {
+	if self.DealerTopCard().Rank == cards.ACE && !self.holeCardShown {
+		self.Phase = PHASE_INSURANCE
+		self.playerIndex = 0
+		self.masterHandIndex = 0
+		if self.findMasterHand(canInsure) {
+			return
+		}
+	}
+	self.peek()
+}
+
+func (self *Round) insure(takeInsurance bool) {
+	var masterHand *PlayerMasterHand = self.CurrentMasterHand()
+	if takeInsurance {
+		// half the bet to the cent, $2.50 on a $5 bet
+		halfBet := money.Cents(money.Dollars(masterHand.Hands[0].Bet).Cents() / 2)
+		masterHand.InsuranceBet = min(halfBet, self.CurrentPlayer().Bankroll)
+		self.CurrentPlayer().Wager(masterHand.InsuranceBet)
+		self.blackjack.Stats.InsuranceCount++
+		self.blackjack.log(fmt.Sprintf("%v hand %v: insurance $%v", self.CurrentPlayer().Name, self.masterHandIndex+1, masterHand.InsuranceBet))
+	}
+
+	self.masterHandIndex++
+	if !self.findMasterHand(canInsure) {
+		self.peek()
+	}
+}
+
+// the dealer checks for a natural before anyone plays their hand
+func (self *Round) peek() {
+	var blackjack *BlackJack = self.blackjack
+	var dealer *Dealer = self.Dealer
+
+	blackjack.log("PLAY HANDS")
+
+	if dealer.DealerHand.IsNatural() {
+		// two cases:
+		//     1. player has a natural and their bet is pushed
+		//     2. player loses
+		dealer.DealerHand.OutCome = HandOutcome(DEALER_BLACKJACK)
+		if !self.holeCardShown {
+			blackjack.emitDealerCard(HOLE_CARD_REVEALED, dealer.HoleCard())
+			self.holeCardShown = true
+		}
+
+		for i := 0; i < len(self.Players); i++ {
+			var player *Player = self.Players[i]
+			for j := 0; j < player.NumMasterHands(); j++ {
+				var masterHand *PlayerMasterHand = player.PlayerMasterHands[j]
+				for k := 0; k < masterHand.NumHands(); k++ {
+					// really should only be one hand in the master hand at this point
+					var hand *PlayerHand = masterHand.Hands[k]
+					// standing will do the right thing in the settlement logic below,
+					// an early surrender keeps its half of the bet
+					if hand.OutCome != HandOutcome(strategy.SURRENDER) {
+						hand.OutCome = HandOutcome(STAND)
+					}
+				}
+			}
+		}
+		self.Phase = PHASE_SETTLEMENT
+		return
+	}
+	self.tellHoleCard()
+
+	if blackjack.Rules.BlackjackSwitch {
+		self.Phase = PHASE_SWITCH
+		self.playerIndex = 0
+		self.masterHandIndex = 0
+		if self.findSwitchPlayer() {
+			return
+		}
+	}
+	self.startPlayerTurns()
+}
+
+//
+// switch
+//
+
+// findSwitchPlayer() moves on to the first player, from the current one,
+// who can switch: neither of their hands has surrendered early.
+func (self *Round) findSwitchPlayer() bool {
+	for ; self.playerIndex < len(self.Players); self.playerIndex++ {
+		var player *Player = self.Players[self.playerIndex]
+		var surrender HandOutcome = HandOutcome(strategy.SURRENDER)
+		if player.PlayerMasterHands[0].Hands[0].OutCome != surrender && player.PlayerMasterHands[1].Hands[0].OutCome != surrender {
+			return true
+		}
+	}
+	return false
+}
+
+// the second cards trade places, a 21 made by switching is not a natural
+func (self *Round) switchCards(switchCards bool) {
+	var blackjack *BlackJack = self.blackjack
+	var player *Player = self.CurrentPlayer()
+	if switchCards {
+		var firstHand *PlayerHand = player.PlayerMasterHands[0].Hands[0]
+		var secondHand *PlayerHand = player.PlayerMasterHands[1].Hands[0]
+		firstHand.Cards[1], secondHand.Cards[1] = secondHand.Cards[1], firstHand.Cards[1]
+		firstHand.Switched = true
+		secondHand.Switched = true
+		blackjack.Stats.SwitchCount++
+		blackjack.log(fmt.Sprintf("%v switches: %v %v and %v %v", player.Name,
+			firstHand.Cards[0].Str(), firstHand.Cards[1].Str(),
+			secondHand.Cards[0].Str(), secondHand.Cards[1].Str()))
+		blackjack.emit(GameEvent{
+			Type:       CARDS_SWITCHED,
+			PlayerName: player.Name,
+		})
+	}
+
+	self.playerIndex++
+	if !self.findSwitchPlayer() {
+		self.startPlayerTurns()
+	}
+}
+
+func (self *Round) startPlayerTurns() {
+	self.Phase = PHASE_PLAYER_TURNS
+	self.playerIndex = 0
+	self.masterHandIndex = 0
+	self.handIndex = 0
+	if len(self.Players) == 0 {
+		// everyone sat the round out, the dealer plays for the rest of the table
+		self.Phase = PHASE_DEALER_TURN
+		return
+	}
+	self.logCurrentHand()
+	self.skipFinishedHands()
+}
+
+//
+// player turns
+//
+
+func (self *Round) logCurrentHand() {
+	var blackjack *BlackJack = self.blackjack
+	if !blackjack.Verbose {
+		return
+	}
+	if self.masterHandIndex == 0 && self.handIndex == 0 {
+		blackjack.log((fmt.Sprintf("player %v - %v", self.playerIndex+1, self.CurrentPlayer().Name)))
+	}
+	var hand *PlayerHand = self.CurrentHand()
+	blackjack.log(fmt.Sprintf("    hand %v.%v:", self.masterHandIndex+1, self.handIndex+1))
+	for l := 0; l < hand.NumCards(); l++ {
+		var card cards.Card = hand.Cards[l]
+		blackjack.log(fmt.Sprintf("        card %v: %v", l+1, card.Str()))
+	}
+}
+
+// moves on to the next hand in play, or to the dealer's turn
+func (self *Round) nextHand() {
+	self.handIndex++
+	if self.handIndex == self.CurrentMasterHand().NumHands() {
+		self.handIndex = 0
+		self.masterHandIndex++
+		if self.masterHandIndex == self.CurrentPlayer().NumMasterHands() {
+			self.masterHandIndex = 0
+			self.playerIndex++
+			if self.playerIndex == len(self.Players) {
+				self.playerIndex = 0
+				self.Phase = PHASE_DEALER_TURN
+				self.blackjack.log("DEALER HAND")
+				self.blackjack.log(fmt.Sprintf("dealer top card: %v", self.DealerTopCard().Str()))
+				return
+			}
+		}
+	}
+	self.logCurrentHand()
+	self.skipFinishedHands()
+}
+
+// a hand can be over before it is played, eg the product of an aces split
+func (self *Round) skipFinishedHands() {
+	if self.Phase != PHASE_PLAYER_TURNS {
+		return
+	}
+	var hand *PlayerHand = self.CurrentHand()
+	if hand.OutCome == HandOutcome(STAND) {
+		// product of a prior ace split, outcome has already been determined.
+		self.blackjack.log(fmt.Sprintf("        prior aces split: %v, total H%v S%v", strategy.PlayerDecision(strategy.STAND), hand.HardCount(), hand.SoftCount()))
+		self.nextHand()
+	} else if hand.OutCome == HandOutcome(strategy.SURRENDER) {
+		self.blackjack.log("        prior early surrender")
+		self.nextHand()
+	}
+}
+
+// the most the current hand can double down for
+func (self *Round) maxDoubleDownAmount() int {
+	var hand *PlayerHand = self.CurrentHand()
+	var player *Player = self.CurrentPlayer()
+	if player.HasUnlimitedBankroll() || !self.blackjack.Rules.DoubleForLess || strategy.IsFreeDouble(hand, self.blackjack.Rules) {
+		return hand.GetBet()
+	}
+	return min(hand.GetBet(), player.BankrollDollars())
+}
+
+// doubleDownAmount() checks a double down's extra bet, see RoundAction.Amount
+func (self *Round) doubleDownAmount(amount int) (int, error) {
+	var hand *PlayerHand = self.CurrentHand()
+	maxAmount := self.maxDoubleDownAmount()
+	if amount == 0 || strategy.IsFreeDouble(hand, self.blackjack.Rules) {
+		// a free double down is always for the hand's bet
+		return maxAmount, nil
+	}
+	if amount < 0 || amount > maxAmount {
+		return 0, fmt.Errorf("%w: double down for $%v, at most $%v", ErrIllegalAction, amount, maxAmount)
+	}
+	if amount < hand.GetBet() && !self.blackjack.Rules.DoubleForLess {
+		return 0, fmt.Errorf("%w: double down for $%v, the house does not allow doubling for less than $%v", ErrIllegalAction, amount, hand.GetBet())
+	}
+	return amount, nil
+}
+
+// standWhenDone() stands a doubled down hand that has nothing left to do but
+// stand, otherwise it is still to re-double, rescue or hit up to the house's minimum
+func (self *Round) standWhenDone(player *Player, masterHand *PlayerMasterHand, hand *PlayerHand) {
+	var blackjack *BlackJack = self.blackjack
+	var legalDecisions []strategy.PlayerDecision = strategy.LegalActions(hand, masterHand, blackjack.Rules, player.BankrollDollars())
+	if len(legalDecisions) == 1 && legalDecisions[0] == strategy.STAND {
+		hand.OutCome = HandOutcome(STAND)
+		blackjack.log(fmt.Sprintf("        stand total H%v S%v", hand.HardCount(), hand.SoftCount()))
+	} else {
+		hand.OutCome = HandOutcome(IN_PLAY)
+	}
+}
+
+// rescue() takes back the double down bets and gives up the original bet
+func (self *Round) rescue(player *Player, hand *PlayerHand) {
+	hand.OutCome = HandOutcome(strategy.SURRENDER)
+	player.Collect(money.Dollars(hand.DoubleDownBet))
+	hand.Bet -= hand.DoubleDownBet
+	hand.DoubleDownBet = 0
+	self.blackjack.Stats.DoubleDownRescueCount++
+}
+
+// the decision as the house calls it, see HouseRules.PontoonTerms
+func (self *Round) decisionTerm(decision strategy.PlayerDecision) strategy.PlayerDecision {
+	if self.blackjack.Rules.PontoonTerms {
+		return strategy.PontoonTerm(decision)
+	}
+	return decision
+}
+
+// amount is the extra bet when doubling down, see doubleDownAmount()
+func (self *Round) play(decision strategy.PlayerDecision, amount int) {
+	var blackjack *BlackJack = self.blackjack
+	var player *Player = self.CurrentPlayer()
+	var masterHand *PlayerMasterHand = self.CurrentMasterHand()
+	var hand *PlayerHand = self.CurrentHand()
+	j := self.masterHandIndex
+	k := self.handIndex
+
+	var card cards.Card
+
+	if hand.IsSplitAces() {
+		switch decision {
+		case strategy.HIT:
+			blackjack.Stats.SplitAcesHit++
+		case strategy.DOUBLE:
+			blackjack.Stats.SplitAcesDoubled++
+		case strategy.SPLIT:
+			blackjack.Stats.AcesResplit++
+		}
+	}
+
+	blackjack.log(fmt.Sprintf("        %v strategy: %v", player.Strategy.Name(), self.decisionTerm(decision)))
+	blackjack.emit(GameEvent{
+		Type:            PLAYER_DECISION,
+		PlayerName:      player.Name,
+		MasterHandIndex: j,
+		HandIndex:       k,
+		Decision:        decision,
+		Bet:             amount,
+	})
+
+	switch decision {
+	case strategy.STAND:
+		hand.OutCome = HandOutcome(STAND)
+		blackjack.log(fmt.Sprintf("        stand total H%v S%v", hand.HardCount(), hand.SoftCount()))
+
+	case strategy.SURRENDER:
+		if hand.DoubleDowns > 0 {
+			self.rescue(player, hand)
+		} else {
+			self.surrender(player, hand)
+		}
+
+	case strategy.DOUBLE:
+		if hand.DoubleDowns > 0 {
+			blackjack.Stats.RedoubleCount++
+		} else if hand.NumCards() > 2 {
+			blackjack.Stats.DoubleDownAfterHitCount++
+		}
+		if amount < hand.GetBet() {
+			blackjack.Stats.DoubleForLessCount++
+		}
+		if strategy.IsFreeDouble(hand, blackjack.Rules) {
+			blackjack.Stats.FreeDoubleCount++
+			hand.FreeBet += amount
+		} else {
+			player.Wager(money.Dollars(amount))
+			hand.Bet += amount
+			hand.DoubleDownBet += amount
+		}
+		card = blackjack.GetCardFromShoe()
+		hand.AddCard(card)
+		blackjack.emitPlayerCard(player, j, k, card)
+		hand.DoubleDowns++
+		blackjack.log(fmt.Sprintf("        hit: %v, total H%v S%v", card.Str(), hand.HardCount(), hand.SoftCount()))
+		if hand.Count() > 21 {
+			// a double down can bust too, and then it is lost no matter what the dealer does
+			hand.OutCome = HandOutcome(BUST)
+			blackjack.log(fmt.Sprintf("        %v", hand.OutCome))
+		} else {
+			self.standWhenDone(player, masterHand, hand)
+		}
+
+	case strategy.HIT:
+		card = blackjack.GetCardFromShoe()
+		hand.AddCard(card)
+		blackjack.emitPlayerCard(player, j, k, card)
+		handTotal := hand.Count()
+		blackjack.log(fmt.Sprintf("        hit: %v, total H%v S%v", card.Str(), hand.HardCount(), hand.SoftCount()))
+		if handTotal > 21 {
+			hand.OutCome = HandOutcome(BUST)
+			blackjack.log(fmt.Sprintf("        %v", hand.OutCome))
+		} else if strategy.IsFiveCardTrick(hand, blackjack.Rules) {
+			hand.OutCome = HandOutcome(STAND)
+			blackjack.log("        five card trick")
+		} else if strategy.IsCharlie(hand, blackjack.Rules) {
+			hand.OutCome = HandOutcome(STAND)
+			blackjack.log(fmt.Sprintf("        %v card charlie", hand.NumCards()))
+		} else if hand.DoubleDowns > 0 {
+			// a Pontoon buy under 15 twists on
+			self.standWhenDone(player, masterHand, hand)
+		} else {
+			hand.OutCome = HandOutcome(IN_PLAY)
+		}
+
+	case strategy.SPLIT:
+		var card1 cards.Card = blackjack.GetCardFromShoe()
+		var card2 cards.Card = blackjack.GetCardFromShoe()
+		splitBet := hand.GetBet()
+		freeSplit := strategy.IsFreeSplit(hand, blackjack.Rules)
+		var newHandIndex int = masterHand.SplitHand(k, [2]cards.Card{card1, card2})
+		var newHand *PlayerHand = masterHand.Hands[newHandIndex]
+		if freeSplit {
+			blackjack.Stats.FreeSplitCount++
+			newHand.Bet = 0
+			newHand.FreeBet = splitBet
+		} else {
+			player.Wager(money.Dollars(splitBet))
+			newHand.Bet = splitBet
+			newHand.FreeBet = 0
+		}
+		blackjack.emitPlayerCard(player, j, k, card1)
+		blackjack.emitPlayerCard(player, j, newHandIndex, card2)
+		blackjack.log(fmt.Sprintf("        split, new hand index %v, adding cards %v, %v", newHandIndex+1, card1.Str(), card2.Str()))
+		blackjack.log(fmt.Sprintf("        card 1: %v", hand.Cards[0].Str()))
+		blackjack.log(fmt.Sprintf("        card 2: %v", hand.Cards[1].Str()))
+		if hand.IsSplitAces() {
+			// eg one card to each ace and that is it, unless the house rules say otherwise
+			var splitHands [2]*PlayerHand = [2]*PlayerHand{hand, masterHand.Hands[newHandIndex]}
+			for i := 0; i < len(splitHands); i++ {
+				var legalDecisions []strategy.PlayerDecision = strategy.LegalActions(
+					splitHands[i], masterHand, blackjack.Rules, player.BankrollDollars(),
+				)
+				if len(legalDecisions) == 1 && legalDecisions[0] == strategy.STAND {
+					splitHands[i].OutCome = HandOutcome(STAND)
+				}
+			}
+			if hand.OutCome == HandOutcome(STAND) {
+				blackjack.log(fmt.Sprintf("        aces split: %v, total H%v S%v", strategy.PlayerDecision(strategy.STAND), hand.HardCount(), hand.SoftCount()))
+			}
+		}
+	}
+
+	if hand.IsHandOver() {
+		self.nextHand()
+	}
+}
+
+//
+// dealer turn
+//
+
+func (self *Round) playDealer() {
+	var blackjack *BlackJack = self.blackjack
+	var dealer *Dealer = self.Dealer
+
+	if !self.holeCardShown && blackjack.Rules.NoHoleCard {
+		card := blackjack.GetCardFromShoe()
+		dealer.DealerHand.AddCard(card)
+		blackjack.emitDealerCard(CARD_DEALT, card)
+		blackjack.log(fmt.Sprintf("dealer second card: %v", card.Str()))
+		self.holeCardShown = true
+		if dealer.DealerHand.IsNatural() {
+			// too late to check for, it takes every bet on the table
+			dealer.DealerHand.OutCome = HandOutcome(DEALER_BLACKJACK)
+			self.Phase = PHASE_SETTLEMENT
+			blackjack.log("    natural")
+		}
+		return
+	}
+
+	if !self.holeCardShown {
+		blackjack.log(fmt.Sprintf("dealer hole card: %v", dealer.HoleCard().Str()))
+		blackjack.emitDealerCard(HOLE_CARD_REVEALED, dealer.HoleCard())
+		self.holeCardShown = true
+		return
+	}
+
+	if blackjack.GetDealerPolicy().Hits(dealer.DealerHand.CardValues()) {
+		card := blackjack.GetCardFromShoe()
+		dealer.DealerHand.AddCard(card)
+		blackjack.emitDealerCard(CARD_DEALT, card)
+		blackjack.log(fmt.Sprintf("    add: %v", card.Str()))
+	} else {
+		dealer.DealerHand.OutCome = HandOutcome(STAND)
+		self.Phase = PHASE_SETTLEMENT
+		blackjack.log(fmt.Sprintf("    stand: total H%v S%v", dealer.DealerHand.HardCount(), dealer.DealerHand.SoftCount()))
+	}
+
+	if dealer.DealerHand.Count() > 21 {
+		dealer.DealerHand.OutCome = HandOutcome(BUST)
+		self.Phase = PHASE_SETTLEMENT
+		blackjack.log("    bust")
+	}
+}
+
+//
+// settlement
+//
+
+func (self *Round) settleInsurance(player *Player, masterHand *PlayerMasterHand) {
+	if masterHand.InsuranceBet == 0 {
+		return
+	}
+	var blackjack *BlackJack = self.blackjack
+	var result money.Money
+	if self.Dealer.DealerHand.OutCome == HandOutcome(DEALER_BLACKJACK) {
+		// insurance pays 2 to 1
+		result = money.PAYS_2_TO_1.PayAmount(masterHand.InsuranceBet, blackjack.Rules.PayoutRounding)
+	} else {
+		result = -masterHand.InsuranceBet
+	}
+	player.Collect(masterHand.InsuranceBet + result)
+	blackjack.Results[player.Name].Proceeds += result
+	blackjack.Results[player.Name].InsuranceProceeds += result
+	blackjack.log(fmt.Sprintf("    insurance: $%v", result))
+}
+
+// what a winning hand that is not a natural is paid, even money unless it
+// earns a bonus, and even money on its free bets
+func (self *Round) winnings(hand *PlayerHand) money.Money {
+	var blackjack *BlackJack = self.blackjack
+	blackjack.Stats.FreeBetWinnings += money.Dollars(hand.FreeBet)
+	var payout money.Payout = money.Payout{}
+	if blackjack.Rules.Spanish21Bonuses {
+		payout = Spanish21BonusPayout(hand)
+	}
+	if payout.IsZero() {
+		payout = FiveCardPayout(hand, blackjack.Rules)
+	}
+	if bonus, name := BonusHandPayout(hand, blackjack.Rules); bonus.Ratio() > payout.Ratio() {
+		payout = bonus
+		blackjack.log(fmt.Sprintf("        bonus hand: %v pays %v", name, bonus))
+	}
+	if payout.Ratio() > 1 {
+		blackjack.Stats.BonusPayoutCount++
+		return payout.Pay(hand.Bet, blackjack.Rules.PayoutRounding) + money.Dollars(hand.FreeBet)
+	}
+	return money.Dollars(hand.Bet + hand.FreeBet)
+}
+
+// what a natural is paid, a suited natural say may pay more
+func (self *Round) naturalWinnings(hand *PlayerHand) money.Money {
+	var blackjack *BlackJack = self.blackjack
+	var payout money.Payout = blackjack.Rules.NaturalBlackjackPayout
+	if bonus, name := BonusHandPayout(hand, blackjack.Rules); bonus.Ratio() > payout.Ratio() {
+		payout = bonus
+		blackjack.Stats.BonusPayoutCount++
+		blackjack.log(fmt.Sprintf("        bonus hand: %v pays %v", name, bonus))
+	}
+	return payout.Pay(hand.Bet, blackjack.Rules.PayoutRounding)
+}
+
+func (self *Round) settle() {
+	var blackjack *BlackJack = self.blackjack
+	var dealer *Dealer = self.Dealer
+
+	blackjack.log("SETTLE HAND")
+	if dealer.DealerHand.OutCome == HandOutcome(DEALER_BLACKJACK) {
+		for i := 0; i < len(self.Players); i++ {
+			var player *Player = self.Players[i]
+			blackjack.log(fmt.Sprintf("Player %v - %v", i+1, player.Name))
+			for j := 0; j < player.NumMasterHands(); j++ {
+				var masterHand *PlayerMasterHand = player.PlayerMasterHands[j]
+				self.settleInsurance(player, masterHand)
+				self.settleSideBets(player, j)
+				for k := 0; k < masterHand.NumHands(); k++ {
+					var hand *PlayerHand = masterHand.Hands[k]
+					if hand.IsNatural() && blackjack.Rules.Player21AlwaysWins {
+						var payout money.Money = self.naturalWinnings(hand)
+						blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), payout)
+						blackjack.log(fmt.Sprintf("    hand %v.%v: natural beats the dealer natural: won $%v", j+1, k+1, payout))
+					} else if hand.IsNatural() && blackjack.Rules.DealerWinsTies && !blackjack.Rules.DoubleExposure {
+						// eg Pontoon, the banker's pontoon beats the player's, where
+						// Double Exposure still pushes tied naturals
+						blackjack.addLoss(player, j, k, hand)
+						blackjack.log(fmt.Sprintf("    hand %v.%v: both had naturals, the dealer wins ties: lost $%v", j+1, k+1, hand.Bet))
+					} else if hand.IsNatural() {
+						blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), 0)
+						blackjack.log(fmt.Sprintf("    hand %v.%v: push both player and dealer had naturals", j+1, k+1))
+					} else {
+						// an early surrender keeps its half
+						blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), -hand.Stake())
+						blackjack.log(fmt.Sprintf("    hand %v.%v: dealer natural: lost $%v", j+1, k+1, hand.Stake()))
+					}
+				}
+			}
+		}
+
+	} else {
+		// dealer does not have a natural
+		for i := 0; i < len(self.Players); i++ {
+			var player *Player = self.Players[i]
+			blackjack.log(fmt.Sprintf("Player %v - %v", i+1, player.Name))
+			for j := 0; j < player.NumMasterHands(); j++ {
+				var masterHand *PlayerMasterHand = player.PlayerMasterHands[j]
+				self.settleInsurance(player, masterHand)
+				self.settleSideBets(player, j)
+				for k := 0; k < masterHand.NumHands(); k++ {
+					var hand *PlayerHand = masterHand.Hands[k]
+					if hand.OutCome == HandOutcome(BUST) {
+						blackjack.addLoss(player, j, k, hand)
+						blackjack.log(fmt.Sprintf("    hand %v.%v: bust: lost $%v", j+1, k+1, hand.Bet))
+
+					} else if hand.OutCome == HandOutcome(SURRENDER) {
+						blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), -hand.Stake())
+						blackjack.log(fmt.Sprintf("    hand %v.%v: surrender: lost $%v", j+1, k+1, hand.Stake()))
+
+					} else {
+						// player has a non-bust, non-surrender hand
+						if hand.IsNatural() {
+							var payout money.Money = self.naturalWinnings(hand)
+							blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), payout)
+							blackjack.log(fmt.Sprintf("    hand %v.%v: natural: won $%v", j+1, k+1, payout))
+
+						} else if strategy.IsFiveCardTrick(hand, blackjack.Rules) {
+							blackjack.Stats.FiveCardTrickCount++
+							var winnings money.Money = self.winnings(hand)
+							blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), winnings)
+							blackjack.log(fmt.Sprintf("    hand %v.%v: five card trick: won $%v", j+1, k+1, winnings))
+
+						} else if strategy.IsCharlie(hand, blackjack.Rules) {
+							blackjack.Stats.CharlieCount++
+							var winnings money.Money = self.winnings(hand)
+							blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), winnings)
+							blackjack.log(fmt.Sprintf("    hand %v.%v: %v card charlie: won $%v", j+1, k+1, hand.NumCards(), winnings))
+
+						} else if hand.Count() == 21 && blackjack.Rules.Player21AlwaysWins {
+							var winnings money.Money = self.winnings(hand)
+							blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), winnings)
+							blackjack.log(fmt.Sprintf("    hand %v.%v: 21 always wins: won $%v", j+1, k+1, winnings))
+
+						} else if dealer.DealerHand.Count() == 22 && blackjack.Rules.Dealer22Pushes {
+							blackjack.Stats.Dealer22PushCount++
+							blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), 0)
+							blackjack.log(fmt.Sprintf("    hand %v.%v: dealer 22: push", j+1, k+1))
+
+						} else if dealer.DealerHand.OutCome == HandOutcome(BUST) {
+							var winnings money.Money = self.winnings(hand)
+							blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), winnings)
+							blackjack.log(fmt.Sprintf("    hand %v.%v: dealer bust: won $%v", j+1, k+1, winnings))
+
+						} else {
+							if hand.Count() < dealer.DealerHand.Count() {
+								blackjack.addLoss(player, j, k, hand)
+								blackjack.log(fmt.Sprintf("    hand %v.%v: lost $%v", j+1, k+1, hand.Bet))
+
+							} else if hand.Count() > dealer.DealerHand.Count() {
+								var winnings money.Money = self.winnings(hand)
+								blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), winnings)
+								blackjack.log(fmt.Sprintf("    hand %v.%v: won $%v", j+1, k+1, winnings))
+
+							} else if blackjack.Rules.DealerWinsTies {
+								blackjack.addLoss(player, j, k, hand)
+								blackjack.log(fmt.Sprintf("    hand %v.%v: the dealer wins ties: lost $%v", j+1, k+1, hand.Bet))
+
+							} else {
+								blackjack.AddResult(player, j, k, hand, money.Dollars(player.Bets[j]), 0)
+								blackjack.log(fmt.Sprintf("    hand %v.%v: push", j+1, k+1))
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	self.Phase = PHASE_ROUND_OVER
+	blackjack.emit(GameEvent{Type: ROUND_ENDED})
+	self.clearTable()
+}
+
+// clearTable() runs the table clock and discards the round's cards, a
+// continuous shuffling machine starts the count over.
+func (self *Round) clearTable() {
+	var blackjack *BlackJack = self.blackjack
+	numHands := 0
+	for i := 0; i < len(self.Players); i++ {
+		for j := 0; j < self.Players[i].NumMasterHands(); j++ {
+			numHands += self.Players[i].PlayerMasterHands[j].NumHands()
+		}
+	}
+	blackjack.Stats.RoundsPlayed++
+	blackjack.roundsThisShoe++
+	blackjack.Stats.TableSeconds += SECONDS_PER_ROUND + SECONDS_PER_HAND*float64(numHands)
+
+	self.discard()
+}
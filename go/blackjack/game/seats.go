@@ -0,0 +1,57 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+)
+
+// A player does not have to play every round.  A back counter watches the
+// table from behind and sits down when the count is good, a wonger gets up
+// when it goes bad, see SeatStrategy.  The house can stop both with no
+// mid-shoe entry: a player who is not at the table when the shoe starts,
+// or who gets up, waits for the next shuffle.
+
+// IsMidShoe() is true once a round has been dealt from the shoe since it was
+// shuffled, a continuous shuffling machine has no shoe to be in the middle of.
+func (self *BlackJack) IsMidShoe() bool {
+	return self.continuousShuffler() == nil && self.roundsThisShoe > 0
+}
+
+// sitOut() takes the player about to bet out of the round, they watch it
+// instead, eg when the bankroll does not cover their bets.
+func (self *Round) sitOut() {
+	var player *Player = self.CurrentPlayer()
+	player.seated = false
+	self.blackjack.Results[player.Name].RoundsSatOut++
+	self.blackjack.log(fmt.Sprintf("%v sits the round out", player.Name))
+	self.Watchers = append(self.Watchers, player)
+	self.Players = slices.Delete(slices.Clone(self.Players), self.playerIndex, self.playerIndex+1)
+	if self.playerIndex == len(self.Players) {
+		self.playerIndex = 0
+		self.Phase = PHASE_DEALING
+	}
+}
+
+// seatPlayers() splits the players into the ones playing the next round and
+// the ones watching it.
+func (self *BlackJack) seatPlayers() ([]*Player, []*Player) {
+	var players []*Player = make([]*Player, 0, len(self.Players))
+	var watchers []*Player = []*Player{}
+	for i := 0; i < len(self.Players); i++ {
+		var player *Player = self.Players[i]
+		seatStrategy, ok := player.Strategy.(SeatStrategy)
+		plays := !ok || seatStrategy.PlaysRound()
+		if plays && !player.seated && self.Rules.NoMidShoeEntry && self.IsMidShoe() {
+			self.log(fmt.Sprintf("%v can not join until the shuffle", player.Name))
+			plays = false
+		}
+		player.seated = plays
+		if plays {
+			players = append(players, player)
+		} else {
+			watchers = append(watchers, player)
+			self.Results[player.Name].RoundsSatOut++
+		}
+	}
+	return players, watchers
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/sidebets"
+)
+
+// SideBetWager is a side bet of Amount on each of the player's master hands,
+// taken off the bankroll with the bets and settled with the hands.
+type SideBetWager struct {
+	SideBet sidebets.SideBet
+	Amount  int
+}
+
+// SideBetResults adds up one side bet over every round, the house edge is
+// -Proceeds / Wagered.
+type SideBetResults struct {
+	Bets     int         `json:"bets"`
+	Wins     int         `json:"wins"`
+	Wagered  int         `json:"wagered"`
+	Proceeds money.Money `json:"proceeds"`
+	// by the winning hand, eg "perfect-pair"
+	Hands map[string]int `json:"hands"`
+}
+
+func (self *Player) sideBetsTotal() int {
+	total := 0
+	for i := 0; i < len(self.SideBets); i++ {
+		total += self.SideBets[i].Amount
+	}
+	return total
+}
+
+// betsTotal() is what the bets take off the bankroll, the side bets go on every master hand
+func (self *Player) betsTotal(bets []int) int {
+	total := 0
+	for i := 0; i < len(bets); i++ {
+		total += bets[i] + self.sideBetsTotal()
+	}
+	return total
+}
+
+// settleSideBets() settles the side bets on the master hand, once the dealer is done
+func (self *Round) settleSideBets(player *Player, masterHandIndex int) {
+	var blackjack *BlackJack = self.blackjack
+	var deal sidebets.Deal = sidebets.Deal{
+		PlayerCards: player.PlayerMasterHands[masterHandIndex].DealtCards,
+		DealerCards: slices.Clone(self.Dealer.DealerHand.Cards),
+	}
+	for i := 0; i < len(player.SideBets); i++ {
+		var wager SideBetWager = player.SideBets[i]
+		name := wager.SideBet.Name()
+		sideBetResults, ok := blackjack.SideBetResults[name]
+		if !ok {
+			sideBetResults = &SideBetResults{Hands: map[string]int{}}
+			blackjack.SideBetResults[name] = sideBetResults
+		}
+
+		hand := wager.SideBet.Evaluate(&deal)
+		result := -money.Dollars(wager.Amount)
+		if hand != "" {
+			result = wager.SideBet.PayTable()[hand].Pay(wager.Amount, blackjack.Rules.PayoutRounding)
+			sideBetResults.Wins++
+			sideBetResults.Hands[hand]++
+		}
+		player.Collect(money.Dollars(wager.Amount) + result)
+		sideBetResults.Bets++
+		sideBetResults.Wagered += wager.Amount
+		sideBetResults.Proceeds += result
+		blackjack.Results[player.Name].Proceeds += result
+		blackjack.Results[player.Name].SideBetProceeds += result
+
+		if hand != "" {
+			blackjack.log(fmt.Sprintf("    %v: %v: won $%v", name, hand, result))
+		} else {
+			blackjack.log(fmt.Sprintf("    %v: lost $%v", name, wager.Amount))
+		}
+		blackjack.emit(GameEvent{
+			Type:            SIDE_BET_SETTLED,
+			PlayerName:      player.Name,
+			MasterHandIndex: masterHandIndex,
+			SideBet:         name,
+			Bet:             wager.Amount,
+			Result:          result,
+		})
+	}
+}
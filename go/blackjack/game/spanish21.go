@@ -0,0 +1,48 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// Spanish21BonusPayout() is what a winning 21 is paid in Spanish 21,
+// the zero payout => no bonus, the hand is paid even money.
+// Only the biggest bonus is paid, and none on a hand that was doubled down on.
+func Spanish21BonusPayout(hand *PlayerHand) money.Payout {
+	if hand.Count() != 21 || hand.DoubleDowns > 0 {
+		return money.Payout{}
+	}
+
+	if hand.NumCards() == 3 {
+		var values []int = []int{}
+		suited := true
+		for i := 0; i < hand.NumCards(); i++ {
+			values = append(values, cards.CardRankValue[hand.Cards[i].Rank])
+			if hand.Cards[i].Suite != hand.Cards[0].Suite {
+				suited = false
+			}
+		}
+		slices.Sort(values)
+		if slices.Equal(values, []int{6, 7, 8}) || slices.Equal(values, []int{7, 7, 7}) {
+			if suited && hand.Cards[0].Suite == cards.SPADES {
+				return house_rules.SPANISH_21_SPADES_PAYOUT
+			} else if suited {
+				return house_rules.SPANISH_21_SUITED_PAYOUT
+			}
+			return house_rules.SPANISH_21_MIXED_SUITS_PAYOUT
+		}
+	}
+
+	switch {
+	case hand.NumCards() >= 7:
+		return house_rules.SPANISH_21_SEVEN_CARD_21_PAYOUT
+	case hand.NumCards() == 6:
+		return house_rules.SPANISH_21_SIX_CARD_21_PAYOUT
+	case hand.NumCards() == 5:
+		return house_rules.SPANISH_21_FIVE_CARD_21_PAYOUT
+	}
+	return money.Payout{}
+}
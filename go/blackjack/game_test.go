@@ -4,7 +4,10 @@ import (
 	"testing"
 
 	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/counting"
 	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
 
 	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
 
@@ -415,6 +418,8 @@ func dumpHands(intro string, expected []cards.Card, actual []cards.Card) {
 
 func TestPlayerMasterHandSplitHand(t *testing.T) {
 	var masterHand *game.PlayerMasterHand = game.CreatePlayerMasterHand()
+	// the aces below are split again and again
+	masterHand.RESPLIT_ACES = true
 
 	bet := 2
 	masterHand.AddStartHand(bet)
@@ -733,3 +738,1110 @@ func TestBlackJackPlayGame(t *testing.T) {
 	var blackjack *game.BlackJack = game.CreateBlackJack()
 	blackjack.PlayGame()
 }
+
+//
+// Round
+//
+
+// stackShoe() puts the given ranks on top of the shoe, in dealing order.
+func stackShoe(blackjack *game.BlackJack, ranks []cards.CardRank) {
+	for i := 0; i < len(ranks); i++ {
+		blackjack.Shoe[i] = cards.Card{Suite: cards.CLUBS, Rank: ranks[i]}
+	}
+	blackjack.ShoeTop = 0
+}
+
+func createQuietBlackJack(names ...string) *game.BlackJack {
+	var blackjack *game.BlackJack = game.CreateBlackJackWithRules(house_rules.CreateHouseRules(), 42)
+	blackjack.Verbose = false
+	var players []*game.Player = []*game.Player{}
+	for i := 0; i < len(names); i++ {
+		var player *game.Player = game.CreatePlayer(names[i])
+		player.Bets = []int{2}
+		players = append(players, player)
+	}
+	blackjack.SetPlayersForGame(players)
+	return blackjack
+}
+
+func TestRoundPhases(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John", "Jane")
+	var round *game.Round = blackjack.StartRound()
+
+	assert.Equal(t, game.PHASE_BETTING, round.Phase, "round starts with betting")
+	assert.Equal(t, "John", round.CurrentPlayer().Name, "John bets first")
+	assert.ErrorIs(t, round.Step(game.PlayAction(strategy.HIT)), game.ErrIllegalAction, "no hitting before the deal")
+	assert.ErrorIs(t, round.Step(game.BetAction([]int{})), game.ErrIllegalAction, "a bet is needed")
+
+	assert.NoError(t, round.Step(game.BetAction([]int{2})), "John bets")
+	assert.NoError(t, round.Step(game.BetAction([]int{2, 4})), "Jane bets on two hands")
+	assert.Equal(t, game.PHASE_DEALING, round.Phase, "dealing follows betting")
+	assert.NoError(t, round.Step(game.CONTINUE), "cards are dealt")
+	assert.Equal(t, 2, round.Dealer.DealerHand.NumCards(), "dealer got two cards")
+
+	phaseOrder := map[game.RoundPhase]int{
+		game.PHASE_INSURANCE:    1,
+		game.PHASE_PLAYER_TURNS: 2,
+		game.PHASE_DEALER_TURN:  3,
+		game.PHASE_SETTLEMENT:   4,
+		game.PHASE_ROUND_OVER:   5,
+	}
+	lastPhase := 0
+	for steps := 0; !round.IsOver(); steps++ {
+		assert.GreaterOrEqual(t, phaseOrder[round.Phase], lastPhase, "phases must not go backwards")
+		lastPhase = phaseOrder[round.Phase]
+		assert.Less(t, steps, 100, "round should end")
+
+		var legalActions []game.RoundAction = round.LegalActions()
+		assert.NotEmpty(t, legalActions, "there is always something to do before the round is over")
+		var action game.RoundAction = legalActions[0]
+		if round.Phase == game.PHASE_INSURANCE {
+			action = game.DECLINE_INSURANCE
+		} else if round.Phase == game.PHASE_PLAYER_TURNS {
+			action = game.PlayAction(strategy.STAND)
+		}
+		assert.NoError(t, round.Step(action), "legal action %v should be accepted", action)
+	}
+
+	assert.Empty(t, round.LegalActions(), "nothing to do once the round is over")
+	assert.Equal(t, 1, blackjack.Results["John"].HandsPlayed, "John played one hand")
+	assert.Equal(t, 2, blackjack.Results["Jane"].HandsPlayed, "Jane played two hands")
+}
+
+func TestRoundInsurance(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	// John: 9, 9   Dealer: A, K
+	stackShoe(blackjack, []cards.CardRank{cards.NINE, cards.ACE, cards.NINE, cards.KING})
+
+	var round *game.Round = blackjack.StartRound()
+	assert.NoError(t, round.Step(game.BetAction([]int{2})), "John bets")
+	assert.NoError(t, round.Step(game.CONTINUE), "cards are dealt")
+	assert.Equal(t, game.PHASE_INSURANCE, round.Phase, "dealer Ace offers insurance")
+	assert.NoError(t, round.Step(game.INSURE), "John takes insurance")
+
+	assert.Equal(t, game.PHASE_SETTLEMENT, round.Phase, "dealer natural ends the round")
+	assert.NoError(t, round.Step(game.CONTINUE), "hands are settled")
+
+	var results *game.BlackJackPlayerResults = blackjack.Results["John"]
+	assert.Equal(t, money.Dollars(2), results.InsuranceProceeds, "insurance pays 2 to 1")
+	assert.Equal(t, money.Dollars(0), results.Proceeds, "insurance covers the lost bet")
+	assert.Equal(t, 1, blackjack.Stats.InsuranceCount, "insurance was taken once")
+
+	// John: 9, 9 bets $5   Dealer: A, K
+	blackjack = createQuietBlackJack("John")
+	stackShoe(blackjack, []cards.CardRank{cards.NINE, cards.ACE, cards.NINE, cards.KING})
+	blackjack.Players[0].Bankroll = money.Dollars(10)
+	round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{5}))
+	round.Step(game.CONTINUE)
+	assert.NoError(t, round.Step(game.INSURE), "John takes insurance")
+	assert.Equal(t, money.Cents(250), blackjack.Players[0].Bankroll, "insurance is half the bet, to the cent")
+	round.Step(game.CONTINUE)
+	results = blackjack.Results["John"]
+	assert.Equal(t, money.Dollars(5), results.InsuranceProceeds, "$2.50 of insurance pays $5")
+	assert.Equal(t, money.Dollars(0), results.Proceeds, "insurance covers an odd bet too")
+	assert.Equal(t, money.Dollars(10), blackjack.Players[0].Bankroll)
+}
+
+func TestRoundDoubleDownBust(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	// John: 10, 6 doubles on a king   Dealer: 9, 5 hits a 10 and busts too
+	stackShoe(blackjack, []cards.CardRank{cards.TEN, cards.NINE, cards.SIX, cards.FIVE, cards.KING, cards.TEN})
+
+	var round *game.Round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	assert.NoError(t, round.Step(game.PlayAction(strategy.DOUBLE)), "John doubles 16")
+	assert.Equal(t, 26, round.Players[0].PlayerMasterHands[0].Hands[0].Count(), "and busts")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, 1, blackjack.Results["John"].HandsLost, "a busted double down is lost, whatever the dealer does")
+	assert.Equal(t, 0, blackjack.Results["John"].HandsWon)
+}
+
+func TestRoundSplitsUpToTheLimit(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	// John: 8, 8   Dealer: 6, 10   then nothing but eights
+	ranks := []cards.CardRank{cards.EIGHT, cards.SIX, cards.EIGHT, cards.TEN}
+	for i := 0; i < 20; i++ {
+		ranks = append(ranks, cards.EIGHT)
+	}
+	stackShoe(blackjack, ranks)
+
+	var round *game.Round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	for i := 0; i < house_rules.SPLITS_PER_HAND; i++ {
+		assert.NoError(t, round.Step(game.PlayAction(strategy.SPLIT)), "split %v should be allowed", i+1)
+	}
+	assert.ErrorIs(
+		t,
+		round.Step(game.PlayAction(strategy.SPLIT)),
+		game.ErrIllegalAction,
+		"a master hand can only be split %v times",
+		house_rules.SPLITS_PER_HAND,
+	)
+	assert.Equal(t, house_rules.SPLITS_PER_HAND+1, round.CurrentMasterHand().NumHands(), "master hand is at its limit")
+}
+
+func TestRoundBankroll(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	// John: 5, 6   Dealer: 9, 8   then a 10 for John
+	stackShoe(blackjack, []cards.CardRank{cards.FIVE, cards.NINE, cards.SIX, cards.EIGHT, cards.TEN})
+	var player *game.Player = blackjack.Players[0]
+	player.Bankroll = money.Dollars(3)
+
+	var round *game.Round = blackjack.StartRound()
+	assert.ErrorIs(t, round.Step(game.BetAction([]int{4})), game.ErrIllegalAction, "cannot bet more than the bankroll")
+	assert.NoError(t, round.Step(game.BetAction([]int{2})), "John bets")
+	assert.Equal(t, money.Dollars(1), player.Bankroll, "the bet is on the table")
+	round.Step(game.CONTINUE)
+
+	assert.ErrorIs(t, round.Step(game.PlayAction(strategy.DOUBLE)), game.ErrIllegalAction, "John cannot cover a double down")
+	assert.NoError(t, round.Step(game.PlayAction(strategy.HIT)), "John hits to 21")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, money.Dollars(5), player.Bankroll, "John collects the bet and what it won")
+}
+
+// SplitAlwaysStrategy asks to split every hand, pair or not
+type SplitAlwaysStrategy struct {
+	strategy.BasicStrategy
+}
+
+func (self *SplitAlwaysStrategy) DeterminePlay(dealerTopCard cards.Card, playerHand strategy.PlayerHandInterface, legalActions []strategy.PlayerDecision) strategy.PlayerDecision {
+	return strategy.SPLIT
+}
+
+func TestPlayGameIllegalActions(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John", "Jane")
+	blackjack.Players[0].Bankroll = money.Dollars(5)
+	blackjack.Players[0].Bets = []int{10}
+	blackjack.Players[1].Strategy = &SplitAlwaysStrategy{*strategy.CreateBasicStrategy(blackjack.Rules)}
+	// Jane: 10, 6   Dealer: 9, 8
+	stackShoe(blackjack, []cards.CardRank{cards.TEN, cards.NINE, cards.SIX, cards.EIGHT, cards.TEN})
+
+	blackjack.PlayGame()
+	assert.Equal(t, 1, blackjack.Results["John"].RoundsSatOut, "$10 bets on a $5 bankroll sit the round out")
+	assert.Equal(t, 0, blackjack.Results["John"].HandsPlayed)
+	assert.Equal(t, money.Dollars(5), blackjack.Players[0].Bankroll)
+	assert.Equal(t, 1, blackjack.Results["Jane"].HandsPlayed, "16 can not split, it stands")
+	assert.Equal(t, money.Dollars(-2), blackjack.Results["Jane"].Proceeds, "16 loses to 17")
+
+	var round *game.Round = blackjack.StartRound()
+	assert.Contains(t, round.LegalActions(), game.SIT_OUT)
+	assert.NoError(t, round.Step(game.SIT_OUT), "John sits out")
+	assert.NoError(t, round.Step(game.SIT_OUT), "so does Jane")
+	assert.Equal(t, game.PHASE_DEALING, round.Phase, "the dealer deals on")
+	assert.Equal(t, 2, len(round.Watchers))
+	for !round.IsOver() {
+		assert.NoError(t, round.Step(round.DefaultAction()))
+	}
+}
+
+func TestPlayerMasterHandNoResplitAces(t *testing.T) {
+	var masterHand *game.PlayerMasterHand = game.CreatePlayerMasterHand()
+	masterHand.RESPLIT_ACES = false
+	masterHand.AddStartHand(2)
+	masterHand.Hands[0].Cards = []cards.Card{{Suite: cards.HEARTS, Rank: cards.ACE}, {Suite: cards.SPADES, Rank: cards.ACE}}
+
+	assert.True(t, masterHand.CanSplit(0), "a pair of aces can be split")
+	masterHand.SplitHand(0, [2]cards.Card{{Suite: cards.CLUBS, Rank: cards.ACE}, {Suite: cards.DIAMONDS, Rank: cards.ACE}})
+	assert.False(t, masterHand.CanSplit(0), "split aces can not be split again")
+	assert.False(t, masterHand.CanSplit(1), "split aces can not be split again")
+	assert.Panics(
+		t,
+		func() {
+			masterHand.SplitHand(0, [2]cards.Card{{Suite: cards.CLUBS, Rank: cards.TWO}, {Suite: cards.DIAMONDS, Rank: cards.TWO}})
+		},
+		"SplitHand() will not re-split aces",
+	)
+}
+
+// splitAces() plays a round where John is dealt A, A against the dealer's 6, 10
+// and splits them, then every card after is the given rank.
+func splitAces(rules *house_rules.HouseRules, rank cards.CardRank) (*game.BlackJack, *game.Round) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.Rules = rules
+	ranks := []cards.CardRank{cards.ACE, cards.SIX, cards.ACE, cards.TEN}
+	for i := 0; i < 20; i++ {
+		ranks = append(ranks, rank)
+	}
+	stackShoe(blackjack, ranks)
+
+	var round *game.Round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	round.Step(game.PlayAction(strategy.SPLIT))
+	return blackjack, round
+}
+
+func TestRoundSplitAcesRules(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	blackjack, round := splitAces(rules, cards.ACE)
+	assert.Equal(t, game.PHASE_DEALER_TURN, round.Phase, "one card to each ace and that is it")
+	assert.Equal(t, 2, round.Players[0].PlayerMasterHands[0].NumHands(), "no re-splitting aces")
+
+	rules = house_rules.CreateHouseRules()
+	rules.ResplitAces = true
+	blackjack, round = splitAces(rules, cards.ACE)
+	assert.Equal(t, game.PHASE_PLAYER_TURNS, round.Phase, "A, A can be split again")
+	assert.ErrorIs(t, round.Step(game.PlayAction(strategy.HIT)), game.ErrIllegalAction, "no hitting split aces")
+	assert.NoError(t, round.Step(game.PlayAction(strategy.SPLIT)), "re-split aces")
+	assert.Equal(t, 1, blackjack.Stats.AcesResplit, "aces were re-split once")
+
+	rules = house_rules.CreateHouseRules()
+	rules.HitSplitAces = true
+	rules.DoubleDownAfterSplittingAces = true
+	blackjack, round = splitAces(rules, cards.TWO)
+	assert.Equal(t, game.PHASE_PLAYER_TURNS, round.Phase, "split aces play on")
+	assert.NoError(t, round.Step(game.PlayAction(strategy.HIT)), "hit split aces")
+	assert.NoError(t, round.Step(game.PlayAction(strategy.STAND)), "stand on A, 2, 2")
+	assert.NoError(t, round.Step(game.PlayAction(strategy.DOUBLE)), "double split aces")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, 1, blackjack.Stats.SplitAcesHit, "split aces were hit once")
+	assert.Equal(t, 1, blackjack.Stats.SplitAcesDoubled, "split aces were doubled once")
+	assert.Equal(t, 2, blackjack.Stats.AcesSplit, "two hands from split aces were settled")
+}
+
+// earlySurrenderRound() deals John 10, 6 against the dealer's given cards.
+func earlySurrenderRound(surrender house_rules.SurrenderMode, dealerTop cards.CardRank, dealerHole cards.CardRank) (*game.BlackJack, *game.Round) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.Rules.Surrender = surrender
+	stackShoe(blackjack, []cards.CardRank{cards.TEN, dealerTop, cards.SIX, dealerHole, cards.FIVE, cards.FIVE})
+	var round *game.Round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{4}))
+	round.Step(game.CONTINUE)
+	return blackjack, round
+}
+
+func TestRoundEarlySurrender(t *testing.T) {
+	// early surrender against a dealer natural keeps half the bet
+	blackjack, round := earlySurrenderRound(house_rules.SURRENDER_EARLY, cards.ACE, cards.KING)
+	assert.Equal(t, game.PHASE_EARLY_SURRENDER, round.Phase, "early surrender comes before the peek")
+	assert.Equal(t, game.SURRENDER_EARLY, round.DefaultAction(), "basic strategy surrenders 16 against an Ace")
+	assert.NoError(t, round.Step(game.SURRENDER_EARLY), "John surrenders early")
+	assert.Equal(t, game.PHASE_SETTLEMENT, round.Phase, "no insurance for a surrendered hand, dealer natural")
+	round.Step(game.CONTINUE)
+	assert.Equal(t, money.Dollars(-2), blackjack.Results["John"].Proceeds, "half the bet is lost")
+	assert.Equal(t, 1, blackjack.Stats.EarlySurrenderCount, "one early surrender")
+	assert.Equal(t, 1, blackjack.Stats.SurrenderCount, "one surrender")
+
+	// late surrender against a dealer natural loses it all
+	blackjack, round = earlySurrenderRound(house_rules.SURRENDER_LATE, cards.ACE, cards.KING)
+	assert.Equal(t, game.PHASE_INSURANCE, round.Phase, "no early surrender")
+	round.Step(game.DECLINE_INSURANCE)
+	round.Step(game.CONTINUE)
+	assert.Equal(t, money.Dollars(-4), blackjack.Results["John"].Proceeds, "the whole bet is lost")
+
+	// early surrender against a 10 only
+	_, round = earlySurrenderRound(house_rules.SURRENDER_EARLY_VS_TEN, cards.ACE, cards.SEVEN)
+	assert.Equal(t, game.PHASE_INSURANCE, round.Phase, "no early surrender against an Ace")
+	_, round = earlySurrenderRound(house_rules.SURRENDER_EARLY_VS_TEN, cards.KING, cards.SEVEN)
+	assert.Equal(t, game.PHASE_EARLY_SURRENDER, round.Phase, "early surrender against a 10")
+
+	// declining early surrender still leaves late surrender
+	blackjack, round = earlySurrenderRound(house_rules.SURRENDER_EARLY, cards.KING, cards.SEVEN)
+	assert.NoError(t, round.Step(game.DECLINE_SURRENDER), "John waits")
+	assert.Equal(t, game.PHASE_PLAYER_TURNS, round.Phase, "no dealer natural")
+	assert.NoError(t, round.Step(game.PlayAction(strategy.SURRENDER)), "John surrenders late")
+	assert.Equal(t, 0, blackjack.Stats.EarlySurrenderCount, "no early surrender")
+
+	// John: 10, 6 bets $5   Dealer: K, 7
+	blackjack = createQuietBlackJack("John")
+	stackShoe(blackjack, []cards.CardRank{cards.TEN, cards.KING, cards.SIX, cards.SEVEN})
+	blackjack.Players[0].Bankroll = money.Dollars(10)
+	round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{5}))
+	round.Step(game.CONTINUE)
+	assert.NoError(t, round.Step(game.PlayAction(strategy.SURRENDER)), "John surrenders")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, money.Cents(-250), blackjack.Results["John"].Proceeds, "surrendering $5 loses $2.50")
+	assert.Equal(t, money.Cents(750), blackjack.Players[0].Bankroll)
+}
+
+func TestRoundDoubleDownAfterHit(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.Rules.DoubleDownAnyNumberOfCards = true
+	// John: 2, 3   Dealer: 10, 7   John hits a 6 then doubles on a 10
+	stackShoe(blackjack, []cards.CardRank{cards.TWO, cards.TEN, cards.THREE, cards.SEVEN, cards.SIX, cards.TEN})
+
+	var round *game.Round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	assert.NoError(t, round.Step(game.PlayAction(strategy.HIT)), "John hits to 11")
+	assert.Equal(t, game.PlayAction(strategy.DOUBLE), round.DefaultAction(), "basic strategy doubles 11 against a 10")
+	assert.NoError(t, round.Step(game.PlayAction(strategy.DOUBLE)), "John doubles on three cards")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, money.Dollars(4), blackjack.Results["John"].Proceeds, "21 beats 17, double the bet")
+	assert.Equal(t, 1, blackjack.Stats.DoubleDownAfterHitCount, "one double down after hitting")
+}
+
+// always doubles down for a dollar
+type CheapDoubleStrategy struct {
+	strategy.BasicStrategy
+}
+
+func (self *CheapDoubleStrategy) DoubleDownAmount(dealerTopCard cards.Card, playerHand strategy.PlayerHandInterface, maxAmount int) int {
+	return 1
+}
+
+// doubleDownRound() deals John 5, 6 against the dealer's 9, 8, and then a 10
+func doubleDownRound(doubleForLess bool, bankroll money.Money) (*game.BlackJack, *game.Round) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.Rules.DoubleForLess = doubleForLess
+	stackShoe(blackjack, []cards.CardRank{cards.FIVE, cards.NINE, cards.SIX, cards.EIGHT, cards.TEN})
+	blackjack.Players[0].Bets = []int{4}
+	blackjack.Players[0].Bankroll = bankroll
+
+	var round *game.Round = blackjack.StartRound()
+	round.Step(round.DefaultAction())
+	round.Step(game.CONTINUE)
+	return blackjack, round
+}
+
+func TestRoundDoubleForLess(t *testing.T) {
+	// short of the full bet, John doubles down for what he has left
+	blackjack, round := doubleDownRound(true, money.Dollars(6))
+	var player *game.Player = blackjack.Players[0]
+	assert.Equal(t, game.PlayAction(strategy.DOUBLE), round.DefaultAction(), "basic strategy doubles 11")
+	assert.ErrorIs(t, round.Step(game.DoubleAction(3)), game.ErrIllegalAction, "John only has $2 left")
+	assert.NoError(t, round.Step(round.DefaultAction()), "John doubles for less")
+	assert.Equal(t, 6, round.Players[0].PlayerMasterHands[0].Hands[0].Bet, "$4 and $2 on the table")
+	assert.Equal(t, money.Dollars(0), player.Bankroll, "all in")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, money.Dollars(6), blackjack.Results["John"].Proceeds, "21 wins the $6 on the table")
+	assert.Equal(t, money.Dollars(12), player.Bankroll, "the $6 bet and the $6 won")
+	assert.Equal(t, 1, blackjack.Stats.DoubleDownCount, "one double down")
+	assert.Equal(t, 1, blackjack.Stats.DoubleForLessCount, "one double for less")
+
+	// doubling for less by choice
+	blackjack, round = doubleDownRound(true, money.UNLIMITED)
+	assert.ErrorIs(t, round.Step(game.DoubleAction(5)), game.ErrIllegalAction, "no doubling for more")
+	assert.NoError(t, round.Step(game.DoubleAction(1)), "John doubles for a dollar")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, money.Dollars(5), blackjack.Results["John"].Proceeds, "21 wins the $5 on the table")
+	assert.Equal(t, 1, blackjack.Stats.DoubleForLessCount, "one double for less")
+
+	// the house does not allow it
+	blackjack, round = doubleDownRound(false, money.UNLIMITED)
+	assert.ErrorIs(t, round.Step(game.DoubleAction(1)), game.ErrIllegalAction, "double for the full bet or not at all")
+	assert.NoError(t, round.Step(game.DoubleAction(4)), "John doubles for the full bet")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, money.Dollars(8), blackjack.Results["John"].Proceeds, "21 wins the $8 on the table")
+	assert.Equal(t, 0, blackjack.Stats.DoubleForLessCount, "no double for less")
+
+	// a strategy can choose the amount
+	blackjack, round = doubleDownRound(true, money.UNLIMITED)
+	blackjack.Players[0].Strategy = &CheapDoubleStrategy{*strategy.CreateBasicStrategy(blackjack.Rules)}
+	assert.Equal(t, game.DoubleAction(1), round.DefaultAction(), "the strategy doubles for a dollar")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, money.Dollars(5), blackjack.Results["John"].Proceeds, "21 wins the $5 on the table")
+}
+
+func TestRoundDealerPolicy(t *testing.T) {
+	// John: 10, 9   Dealer: A, 6   then a 3 for the dealer
+	ranks := []cards.CardRank{cards.TEN, cards.ACE, cards.NINE, cards.SIX, cards.THREE}
+
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.DealerPolicy = house_rules.CreateS17Policy()
+	stackShoe(blackjack, ranks)
+	blackjack.PlayGame()
+	assert.Equal(t, money.Dollars(2), blackjack.Results["John"].Proceeds, "the dealer stands on soft 17, 19 wins")
+
+	blackjack = createQuietBlackJack("John")
+	blackjack.DealerPolicy = house_rules.CreateH17Policy()
+	stackShoe(blackjack, ranks)
+	blackjack.PlayGame()
+	assert.Equal(t, money.Dollars(-2), blackjack.Results["John"].Proceeds, "the dealer hits soft 17 to 20, 19 loses")
+
+	// no policy set, the house rules decide
+	blackjack = createQuietBlackJack("John")
+	blackjack.Rules.DealerHitsSoftOn = 16
+	stackShoe(blackjack, ranks)
+	blackjack.PlayGame()
+	assert.Equal(t, money.Dollars(2), blackjack.Results["John"].Proceeds, "the house rules are S17")
+}
+
+func createSuitedHand(cardList ...cards.Card) *game.PlayerHand {
+	var hand *game.PlayerHand = game.CreatePlayerHand(false, 2)
+	for i := 0; i < len(cardList); i++ {
+		hand.AddCard(cardList[i])
+	}
+	return hand
+}
+
+func TestSpanish21BonusPayout(t *testing.T) {
+	six := cards.Card{Suite: cards.HEARTS, Rank: cards.SIX}
+	seven := cards.Card{Suite: cards.HEARTS, Rank: cards.SEVEN}
+	eight := cards.Card{Suite: cards.HEARTS, Rank: cards.EIGHT}
+	spade := func(rank cards.CardRank) cards.Card { return cards.Card{Suite: cards.SPADES, Rank: rank} }
+	club := func(rank cards.CardRank) cards.Card { return cards.Card{Suite: cards.CLUBS, Rank: rank} }
+
+	assert.Equal(t, house_rules.SPANISH_21_MIXED_SUITS_PAYOUT, game.Spanish21BonusPayout(createSuitedHand(six, club(cards.EIGHT), seven)), "mixed 6-7-8")
+	assert.Equal(t, house_rules.SPANISH_21_SUITED_PAYOUT, game.Spanish21BonusPayout(createSuitedHand(eight, six, seven)), "suited 6-7-8")
+	assert.Equal(t, house_rules.SPANISH_21_SPADES_PAYOUT, game.Spanish21BonusPayout(createSuitedHand(spade(cards.SEVEN), spade(cards.SEVEN), spade(cards.SEVEN))), "spades 7-7-7")
+	assert.Equal(t, house_rules.SPANISH_21_MIXED_SUITS_PAYOUT, game.Spanish21BonusPayout(createSuitedHand(seven, club(cards.SEVEN), spade(cards.SEVEN))), "mixed 7-7-7")
+	assert.Equal(t, money.Payout{}, game.Spanish21BonusPayout(createSuitedHand(seven, club(cards.KING), club(cards.FOUR))), "a three card 21 with no bonus")
+	assert.Equal(t, money.Payout{}, game.Spanish21BonusPayout(createSuitedHand(club(cards.ACE), club(cards.KING))), "a natural is paid as a natural")
+
+	fiveCards := createSuitedHand(club(cards.TWO), club(cards.THREE), club(cards.TWO), club(cards.FOUR), club(cards.KING))
+	assert.Equal(t, house_rules.SPANISH_21_FIVE_CARD_21_PAYOUT, game.Spanish21BonusPayout(fiveCards), "five card 21")
+	fiveCards.AddCard(club(cards.TWO))
+	assert.Equal(t, money.Payout{}, game.Spanish21BonusPayout(fiveCards), "23 is bust")
+	sixCards := createSuitedHand(club(cards.TWO), club(cards.THREE), club(cards.TWO), club(cards.FOUR), club(cards.ACE), club(cards.NINE))
+	assert.Equal(t, house_rules.SPANISH_21_SIX_CARD_21_PAYOUT, game.Spanish21BonusPayout(sixCards), "six card 21")
+	sevenCards := createSuitedHand(club(cards.TWO), club(cards.THREE), club(cards.TWO), club(cards.FOUR), club(cards.ACE), club(cards.TWO), club(cards.SEVEN))
+	assert.Equal(t, house_rules.SPANISH_21_SEVEN_CARD_21_PAYOUT, game.Spanish21BonusPayout(sevenCards), "seven card 21")
+
+	doubled := createSuitedHand(six, club(cards.EIGHT), seven)
+	doubled.DoubleDowns = 1
+	assert.Equal(t, money.Payout{}, game.Spanish21BonusPayout(doubled), "no bonus on a double down")
+}
+
+// gameRound() plays John's stacked cards under the rules with a $10 bankroll,
+// the given actions in the switch and on his turns and his strategy after
+// that.  An illegal action fails the test.  Blackjack Switch seats him with
+// the two hands and the strategy it needs.
+func gameRound(t *testing.T, rules *house_rules.HouseRules, ranks []cards.CardRank, actions ...game.RoundAction) *game.BlackJack {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.Rules = rules
+	var player *game.Player = blackjack.Players[0]
+	player.Bankroll = money.Dollars(10)
+	if rules.BlackjackSwitch {
+		player.Bets = []int{2, 2}
+		player.Strategy = strategy.CreateBlackjackSwitchStrategy(rules)
+	}
+	stackShoe(blackjack, ranks)
+
+	var round *game.Round = blackjack.StartRound()
+	for !round.IsOver() {
+		var action game.RoundAction = round.DefaultAction()
+		if (round.Phase == game.PHASE_SWITCH || round.Phase == game.PHASE_PLAYER_TURNS) && len(actions) > 0 {
+			action = actions[0]
+			actions = actions[1:]
+		}
+		err := round.Step(action)
+		if err != nil {
+			t.Fatalf("%v: %v", action, err)
+		}
+	}
+	return blackjack
+}
+
+func TestRoundSpanish21(t *testing.T) {
+	var blackjack *game.BlackJack
+
+	// John: 5, 6, 10 = 21   Dealer: K, 6, 5 = 21
+	twentyOnes := []cards.CardRank{cards.FIVE, cards.KING, cards.SIX, cards.SIX, cards.TEN, cards.FIVE}
+	blackjack = gameRound(t, house_rules.CreateHouseRules(), twentyOnes, game.PlayAction(strategy.HIT))
+	assert.Equal(t, money.Dollars(0), blackjack.Results["John"].Proceeds, "21 pushes 21")
+	blackjack = gameRound(t, house_rules.CreateSpanish21Rules(), twentyOnes, game.PlayAction(strategy.HIT))
+	assert.Equal(t, money.Dollars(2), blackjack.Results["John"].Proceeds, "a player 21 always wins")
+
+	// John: A, K   Dealer: A, K
+	naturals := []cards.CardRank{cards.ACE, cards.ACE, cards.KING, cards.KING}
+	blackjack = gameRound(t, house_rules.CreateHouseRules(), naturals)
+	assert.Equal(t, money.Dollars(0), blackjack.Results["John"].Proceeds, "naturals push")
+	blackjack = gameRound(t, house_rules.CreateSpanish21Rules(), naturals)
+	assert.Equal(t, money.Dollars(3), blackjack.Results["John"].Proceeds, "the player natural beats the dealer natural")
+
+	// John: 2, 3, 2, 4, 10 = five card 21   Dealer: K, 8
+	fiveCards := []cards.CardRank{cards.TWO, cards.KING, cards.THREE, cards.EIGHT, cards.TWO, cards.FOUR, cards.TEN}
+	var hits []game.RoundAction = []game.RoundAction{game.PlayAction(strategy.HIT), game.PlayAction(strategy.HIT), game.PlayAction(strategy.HIT)}
+	blackjack = gameRound(t, house_rules.CreateHouseRules(), fiveCards, hits...)
+	assert.Equal(t, money.Dollars(2), blackjack.Results["John"].Proceeds, "even money")
+	blackjack = gameRound(t, house_rules.CreateSpanish21Rules(), fiveCards, hits...)
+	assert.Equal(t, money.Dollars(3), blackjack.Results["John"].Proceeds, "the five card 21 pays 3 to 2")
+	assert.Equal(t, 1, blackjack.Stats.BonusPayoutCount, "one bonus")
+
+	// John: 5, 6 doubles and draws a 2   Dealer: K, 7
+	rescue := []cards.CardRank{cards.FIVE, cards.KING, cards.SIX, cards.SEVEN, cards.TWO}
+	blackjack = gameRound(t, house_rules.CreateSpanish21Rules(), rescue, game.PlayAction(strategy.DOUBLE), game.PlayAction(strategy.SURRENDER))
+	assert.Equal(t, money.Dollars(-2), blackjack.Results["John"].Proceeds, "the rescue gives up the original bet")
+	assert.Equal(t, money.Dollars(8), blackjack.Players[0].Bankroll, "and takes back the double down bet")
+	assert.Equal(t, 1, blackjack.Stats.DoubleDownRescueCount, "one rescue")
+	assert.Equal(t, 1, blackjack.Stats.SurrenderCount, "a rescue is a surrender")
+
+	// John: 3, 3 doubles and draws a 5, re-doubles and draws a K   Dealer: K, 7
+	redouble := []cards.CardRank{cards.THREE, cards.KING, cards.THREE, cards.SEVEN, cards.FIVE, cards.KING}
+	blackjack = gameRound(t, house_rules.CreateSpanish21Rules(), redouble, game.PlayAction(strategy.DOUBLE), game.PlayAction(strategy.DOUBLE))
+	assert.Equal(t, money.Dollars(8), blackjack.Results["John"].Proceeds, "21 wins the re-doubled bet, no bonus")
+	assert.Equal(t, money.Dollars(18), blackjack.Players[0].Bankroll, "the $8 bet and the $8 won")
+	assert.Equal(t, 1, blackjack.Stats.RedoubleCount, "one re-double")
+	assert.Equal(t, 1, blackjack.Stats.DoubleDownCount, "one hand doubled down on")
+}
+
+func TestRoundBlackjackSwitch(t *testing.T) {
+	var blackjack *game.BlackJack
+
+	var switchBlackJack *game.BlackJack = createQuietBlackJack("John")
+	switchBlackJack.Rules = house_rules.CreateBlackjackSwitchRules()
+	var round *game.Round = switchBlackJack.StartRound()
+	assert.ErrorIs(t, round.Step(game.BetAction([]int{2})), game.ErrIllegalAction, "two hands are needed")
+	assert.ErrorIs(t, round.Step(game.BetAction([]int{2, 4})), game.ErrIllegalAction, "of the same bet")
+	assert.NoError(t, round.Step(game.BetAction([]int{2, 2})))
+
+	// John: K-6 and 5-K switches to K-K and 5-6, doubles and draws a 9
+	// Dealer: 6-10 draws a 6 = 22
+	dealer22 := []cards.CardRank{
+		cards.KING, cards.FIVE, cards.SIX,
+		cards.SIX, cards.KING, cards.TEN,
+		cards.NINE, cards.SIX,
+	}
+	blackjack = gameRound(t, house_rules.CreateBlackjackSwitchRules(), dealer22)
+	assert.Equal(t, 1, blackjack.Stats.SwitchCount, "John switched")
+	assert.Equal(t, 1, blackjack.Stats.DoubleDownCount, "and doubled 11")
+	assert.Equal(t, money.Dollars(0), blackjack.Results["John"].Proceeds, "dealer 22 pushes both hands")
+	assert.Equal(t, 2, blackjack.Stats.Dealer22PushCount)
+
+	var rules *house_rules.HouseRules = house_rules.CreateBlackjackSwitchRules()
+	rules.Dealer22Pushes = false
+	blackjack = gameRound(t, rules, dealer22)
+	assert.Equal(t, money.Dollars(6), blackjack.Results["John"].Proceeds, "dealer 22 busts")
+
+	// John: A-6 and 9-K switches to A-K and 9-6, stands
+	// Dealer: 10-7
+	natural := []cards.CardRank{
+		cards.ACE, cards.NINE, cards.TEN,
+		cards.SIX, cards.KING, cards.SEVEN,
+	}
+	blackjack = gameRound(t, house_rules.CreateBlackjackSwitchRules(), natural, game.SWITCH, game.PlayAction(strategy.STAND), game.PlayAction(strategy.STAND))
+	assert.Equal(t, 1, blackjack.Results["John"].HandsWon, "a switched 21 wins")
+	assert.Equal(t, money.Dollars(0), blackjack.Results["John"].Proceeds, "at even money, 15 loses")
+
+	blackjack = gameRound(t, house_rules.CreateBlackjackSwitchRules(), natural, game.DECLINE_SWITCH, game.PlayAction(strategy.STAND), game.PlayAction(strategy.STAND))
+	assert.Equal(t, 0, blackjack.Stats.SwitchCount, "no switch")
+	assert.Equal(t, money.Dollars(2), blackjack.Results["John"].Proceeds, "19 wins, soft 17 pushes")
+
+	// John: A-6 and 9-K switches to A-K and 9-6, stands
+	// Dealer: 6-10 draws a 6 = 22
+	naturalVs22 := []cards.CardRank{
+		cards.ACE, cards.NINE, cards.SIX,
+		cards.SIX, cards.KING, cards.TEN,
+		cards.SIX,
+	}
+	blackjack = gameRound(t, house_rules.CreateBlackjackSwitchRules(), naturalVs22, game.SWITCH, game.PlayAction(strategy.STAND), game.PlayAction(strategy.STAND))
+	assert.Equal(t, money.Dollars(0), blackjack.Results["John"].Proceeds, "a switched 21 is no natural, the dealer 22 pushes both")
+	assert.Equal(t, 2, blackjack.Stats.Dealer22PushCount)
+
+	// John: A-K and 9-6, stands   Dealer: 6-10 draws a 6 = 22
+	dealtNaturalVs22 := []cards.CardRank{
+		cards.ACE, cards.NINE, cards.SIX,
+		cards.KING, cards.SIX, cards.TEN,
+		cards.SIX,
+	}
+	blackjack = gameRound(t, house_rules.CreateBlackjackSwitchRules(), dealtNaturalVs22, game.DECLINE_SWITCH, game.PlayAction(strategy.STAND), game.PlayAction(strategy.STAND))
+	assert.Equal(t, money.Dollars(2), blackjack.Results["John"].Proceeds, "a dealt natural beats a dealer 22, 15 pushes")
+	assert.Equal(t, 1, blackjack.Stats.Dealer22PushCount)
+
+	// John: A-6 and 9-K switches to A-K and 9-6, stands
+	// Dealer: 6-10 draws a 5 = 21
+	switched21Vs21 := []cards.CardRank{
+		cards.ACE, cards.NINE, cards.SIX,
+		cards.SIX, cards.KING, cards.TEN,
+		cards.FIVE,
+	}
+	blackjack = gameRound(t, house_rules.CreateBlackjackSwitchRules(), switched21Vs21, game.SWITCH, game.PlayAction(strategy.STAND), game.PlayAction(strategy.STAND))
+	assert.Equal(t, money.Dollars(-2), blackjack.Results["John"].Proceeds, "a switched 21 ties the dealer's three card 21, 15 loses")
+	assert.Equal(t, 1, blackjack.Results["John"].HandsPushed)
+}
+
+func TestRoundFreeBet(t *testing.T) {
+	var blackjack *game.BlackJack
+
+	// John bets $2 of his $10 on 8-8, splits for free: 8-3 doubles for free and draws a 9, 8-10
+	// Dealer: 6-10 draws
+	freeSplit := func(dealerDraws cards.CardRank) []cards.CardRank {
+		return []cards.CardRank{
+			cards.EIGHT, cards.SIX, cards.EIGHT, cards.TEN,
+			cards.THREE, cards.TEN, cards.NINE,
+			dealerDraws,
+		}
+	}
+	decisions := []game.RoundAction{game.PlayAction(strategy.SPLIT), game.PlayAction(strategy.DOUBLE), game.PlayAction(strategy.STAND)}
+
+	// Dealer: 18
+	blackjack = gameRound(t, house_rules.CreateFreeBetRules(), freeSplit(cards.TWO), decisions...)
+	assert.Equal(t, 1, blackjack.Stats.FreeSplitCount)
+	assert.Equal(t, 1, blackjack.Stats.FreeDoubleCount)
+	assert.Equal(t, money.Dollars(4), blackjack.Results["John"].Proceeds, "20 wins the bet and the free double, 18 pushes")
+	assert.Equal(t, money.Dollars(2), blackjack.Stats.FreeBetWinnings, "the free double won")
+	assert.Equal(t, money.Dollars(14), blackjack.Players[0].Bankroll)
+
+	// Dealer: 20
+	blackjack = gameRound(t, house_rules.CreateFreeBetRules(), freeSplit(cards.FOUR), decisions...)
+	assert.Equal(t, money.Dollars(0), blackjack.Results["John"].Proceeds, "20 pushes, the free split 18 loses nothing")
+	assert.Equal(t, 1, blackjack.Results["John"].HandsLost, "and is lost all the same")
+	assert.Equal(t, 1, blackjack.Results["John"].HandsPushed)
+	assert.Equal(t, money.Dollars(0), blackjack.Stats.FreeBetWinnings)
+	assert.Equal(t, money.Dollars(10), blackjack.Players[0].Bankroll, "the free bets cost John nothing")
+
+	// Dealer: 22
+	blackjack = gameRound(t, house_rules.CreateFreeBetRules(), freeSplit(cards.SIX), decisions...)
+	assert.Equal(t, money.Dollars(0), blackjack.Results["John"].Proceeds, "dealer 22 pushes both hands")
+	assert.Equal(t, 2, blackjack.Stats.Dealer22PushCount)
+
+	// Dealer: 23
+	blackjack = gameRound(t, house_rules.CreateFreeBetRules(), freeSplit(cards.SEVEN), decisions...)
+	assert.Equal(t, money.Dollars(6), blackjack.Results["John"].Proceeds, "the dealer busts, the free bets are paid")
+	assert.Equal(t, money.Dollars(4), blackjack.Stats.FreeBetWinnings)
+}
+
+func TestRoundDoubleExposure(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.Rules = house_rules.CreateDoubleExposureRules()
+	blackjack.Players[0].Strategy = strategy.CreateDoubleExposureStrategy(blackjack.Rules)
+	// John: 10, 8   Dealer: A, 8
+	stackShoe(blackjack, []cards.CardRank{cards.TEN, cards.ACE, cards.EIGHT, cards.EIGHT})
+	var counter *counting.HiLoCounter = counting.CreateHiLoCounter(blackjack.Rules.DecksInShoe)
+	blackjack.AddListener(counter)
+	var round *game.Round = blackjack.StartRound()
+	_, holeCardShown := round.DealerHoleCard()
+	assert.False(t, holeCardShown, "nothing is dealt yet")
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	assert.Equal(t, game.PHASE_PLAYER_TURNS, round.Phase, "no insurance with the hole card face up")
+	holeCard, holeCardShown := round.DealerHoleCard()
+	assert.True(t, holeCardShown, "the hole card is face up")
+	assert.Equal(t, cards.EIGHT, holeCard.Rank)
+	assert.Equal(t, game.PlayAction(strategy.HIT), round.DefaultAction(), "hit 18 vs a dealer soft 19")
+	round.Step(game.PlayAction(strategy.STAND))
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, 4, counter.CardsSeen, "the hole card is counted once")
+
+	// John: 10, 8 stands   Dealer: 10, 8
+	blackjack = gameRound(t, house_rules.CreateDoubleExposureRules(), []cards.CardRank{cards.TEN, cards.TEN, cards.EIGHT, cards.EIGHT}, game.PlayAction(strategy.STAND))
+	assert.Equal(t, money.Dollars(-2), blackjack.Results["John"].Proceeds, "the dealer wins ties")
+
+	// John: A, K   Dealer: 10, 9
+	blackjack = gameRound(t, house_rules.CreateDoubleExposureRules(), []cards.CardRank{cards.ACE, cards.TEN, cards.KING, cards.NINE})
+	assert.Equal(t, money.Dollars(2), blackjack.Results["John"].Proceeds, "a natural pays even money")
+
+	// John: A, K   Dealer: A, K
+	blackjack = gameRound(t, house_rules.CreateDoubleExposureRules(), []cards.CardRank{cards.ACE, cards.ACE, cards.KING, cards.KING})
+	assert.Equal(t, money.Dollars(0), blackjack.Results["John"].Proceeds, "naturals push")
+}
+
+func TestRoundPontoon(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.Rules = house_rules.CreatePontoonRules()
+	// John: 2, 3 twists 2, 4, 3   Dealer: 10, 10
+	stackShoe(blackjack, []cards.CardRank{cards.TWO, cards.TEN, cards.THREE, cards.TEN, cards.TWO, cards.FOUR, cards.THREE})
+	var round *game.Round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	assert.ErrorIs(t, round.Step(game.PlayAction(strategy.STICK)), game.ErrIllegalAction, "no sticking on 5")
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, round.Step(game.PlayAction(strategy.TWIST)))
+	}
+	assert.Equal(t, game.PHASE_DEALER_TURN, round.Phase, "the five card trick is done")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, money.Dollars(4), blackjack.Results["John"].Proceeds, "a five card trick pays 2 to 1")
+	assert.Equal(t, 1, blackjack.Stats.FiveCardTrickCount)
+
+	// John: 10, 8 sticks   Dealer: 10, 8
+	blackjack = gameRound(t, house_rules.CreatePontoonRules(), []cards.CardRank{cards.TEN, cards.TEN, cards.EIGHT, cards.EIGHT}, game.PlayAction(strategy.STICK))
+	assert.Equal(t, money.Dollars(-2), blackjack.Results["John"].Proceeds, "the dealer wins ties")
+
+	// John: A, K   Dealer: 10, 9
+	blackjack = gameRound(t, house_rules.CreatePontoonRules(), []cards.CardRank{cards.ACE, cards.TEN, cards.KING, cards.NINE})
+	assert.Equal(t, money.Dollars(3), blackjack.Results["John"].Proceeds, "a pontoon pays 3 to 2")
+
+	// John: A, K   Dealer: A, Q
+	blackjack = gameRound(t, house_rules.CreatePontoonRules(), []cards.CardRank{cards.ACE, cards.ACE, cards.KING, cards.QUEEN})
+	assert.Equal(t, money.Dollars(-2), blackjack.Results["John"].Proceeds, "the banker's pontoon beats the player's")
+	assert.Equal(t, 1, blackjack.Results["John"].HandsLost)
+
+	// John: 4, 2 buys a 5 and twists a 9   Dealer: 10, 8
+	blackjack = createQuietBlackJack("John")
+	blackjack.Rules = house_rules.CreatePontoonRules()
+	stackShoe(blackjack, []cards.CardRank{cards.FOUR, cards.TEN, cards.TWO, cards.EIGHT, cards.FIVE, cards.NINE})
+	round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	assert.NoError(t, round.Step(game.PlayAction(strategy.DOUBLE)))
+	assert.ErrorIs(t, round.Step(game.PlayAction(strategy.STICK)), game.ErrIllegalAction, "no sticking on a bought 11")
+	assert.NoError(t, round.Step(game.PlayAction(strategy.TWIST)))
+	assert.Equal(t, game.PHASE_DEALER_TURN, round.Phase, "sticks on 20")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, money.Dollars(4), blackjack.Results["John"].Proceeds, "the bought hand wins twice the bet")
+}
+
+func TestRoundCutCard(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.PlayGame()
+	assert.Equal(t, blackjack.Rules.ForceReshuffle, blackjack.CutCard, "no spread")
+
+	// the cut card comes out in this round, which is finished first
+	blackjack.ShoeTop = blackjack.CutCard - 1
+	blackjack.PlayGame()
+	assert.True(t, blackjack.IsCutCardOut())
+	shoeTop := blackjack.ShoeTop
+	assert.Greater(t, shoeTop, blackjack.CutCard)
+	assert.Equal(t, 0, blackjack.Stats.ShoesShuffled)
+
+	var round *game.Round = blackjack.StartRound()
+	assert.Equal(t, 0, blackjack.ShoeTop, "reshuffled before the next round")
+	assert.False(t, blackjack.IsCutCardOut())
+	assert.Equal(t, 1, blackjack.Stats.ShoesShuffled)
+	assert.Equal(t, shoeTop, blackjack.Stats.ShoeCardsDealt)
+	assert.Equal(t, float64(shoeTop)/float64(len(blackjack.Shoe)), blackjack.Penetration())
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+
+	blackjack.Rules.CutCardSpread = 20
+	cutCards := map[int]bool{}
+	for i := 0; i < 50; i++ {
+		blackjack.ReshuffleShoe()
+		assert.InDelta(t, blackjack.Rules.ForceReshuffle, blackjack.CutCard, 20)
+		cutCards[blackjack.CutCard] = true
+	}
+	assert.Greater(t, len(cutCards), 1, "the cut card goes in somewhere else every shuffle")
+}
+
+func TestRoundShoeExhausted(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	// the last cards, John: 10, 6 stands   Dealer: 5, 6 hits from the reshuffled discards
+	var ranks []cards.CardRank = []cards.CardRank{cards.TEN, cards.FIVE, cards.SIX, cards.SIX}
+	for i := 0; i < len(ranks); i++ {
+		blackjack.Shoe[len(blackjack.Shoe)-len(ranks)+i] = cards.Card{Suite: cards.CLUBS, Rank: ranks[i]}
+	}
+	blackjack.ShoeTop = len(blackjack.Shoe) - len(ranks)
+	// a cut card that never came out
+	blackjack.CutCard = len(blackjack.Shoe) - 1
+	blackjack.PlayGame()
+
+	assert.Equal(t, 1, blackjack.Stats.ShoeExhaustedCount)
+	assert.Equal(t, 1, blackjack.Results["John"].HandsPlayed, "the round was finished")
+	assert.Greater(t, blackjack.ShoeTop, len(ranks))
+	assert.Equal(t, cards.TEN, blackjack.Shoe[0].Rank, "the cards on the table stay out of the reshuffle")
+	assert.True(t, blackjack.IsCutCardOut())
+
+	blackjack.PlayGame()
+	assert.Equal(t, 1, blackjack.Stats.ShoesShuffled, "a fresh shoe for the next round")
+	assert.Greater(t, blackjack.Penetration(), 1.0, "more than the whole shoe was dealt")
+}
+
+func TestRoundContinuousShuffler(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John", "Jane")
+	blackjack.Rules.Shuffler = house_rules.SHUFFLE_CSM
+	var counter *counting.HiLoCounter = counting.CreateHiLoCounter(blackjack.Rules.DecksInShoe)
+	blackjack.AddListener(counter)
+	var csm *cards.ShelfShuffler
+	for i := 0; i < 200; i++ {
+		blackjack.PlayGame()
+		if i == 0 {
+			csm = blackjack.Shuffler.(*cards.ShelfShuffler)
+		}
+		assert.Equal(t, len(blackjack.Shoe), csm.NumCards(), "the discards go back in after every round")
+		assert.Equal(t, 0, counter.CardsSeen, "the count starts over every round")
+	}
+	assert.Equal(t, 0, blackjack.ShoeTop, "nothing is dealt from the shoe")
+	assert.Equal(t, 0, blackjack.Stats.ShoesShuffled, "never shuffled")
+	assert.Equal(t, 200, blackjack.Stats.RoundsPlayed)
+
+	// the same table dealt from a shoe stops to shuffle
+	var shoe *game.BlackJack = createQuietBlackJack("John", "Jane")
+	for i := 0; i < 200; i++ {
+		shoe.PlayGame()
+	}
+	assert.Greater(t, shoe.Stats.ShoesShuffled, 0)
+	assert.Greater(t, blackjack.RoundsPerHour(), shoe.RoundsPerHour(), "more rounds an hour")
+	assert.Greater(t, blackjack.HandsPerHour("John"), shoe.HandsPerHour("John"))
+}
+
+func TestRoundHandShuffle(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.Rules.Shuffler = house_rules.SHUFFLE_CASINO
+	var shoe []cards.Card = append([]cards.Card{}, blackjack.Shoe...)
+	blackjack.ShoeTop = len(blackjack.Shoe) - 1
+	blackjack.ReshuffleShoe()
+	assert.Equal(t, house_rules.SHUFFLE_CASINO, blackjack.Shuffler.Kind())
+	assert.ElementsMatch(t, shoe, blackjack.Shoe, "the same cards")
+	assert.NotEqual(t, shoe, blackjack.Shoe, "shuffled")
+	assert.Equal(t, blackjack.Shuffler.ShuffleSeconds(len(shoe)), blackjack.Stats.TableSeconds, "the table waits for the dealer")
+}
+
+func TestRoundDiscardTray(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	var seat *counting.HiLoCounter = counting.CreateHiLoCounter(blackjack.Rules.DecksInShoe)
+	seat.Seat = "John"
+	blackjack.AddListener(seat)
+	// John: 10, 6 stands   dealer: 5, 9 hits a 10 and busts
+	var ranks []cards.CardRank = []cards.CardRank{cards.TEN, cards.FIVE, cards.SIX, cards.NINE, cards.TEN}
+	stackShoe(blackjack, ranks)
+
+	var round *game.Round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	assert.Equal(t, 0, seat.CardsSeen, "counted once the round is over")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	var seen []cards.Card = []cards.Card{}
+	for i := 0; i < len(ranks); i++ {
+		seen = append(seen, cards.Card{Suite: cards.CLUBS, Rank: ranks[i]})
+	}
+	assert.Equal(t, seen, blackjack.Discards.Seen, "in the order they were dealt, the hole card once turned over")
+	assert.Equal(t, 0, len(blackjack.Discards.Unseen))
+	assert.Equal(t, 5, seat.CardsSeen)
+	assert.Equal(t, 0, seat.RunningCount, "10, 5, 6, 9, 10")
+	assert.Equal(t, len(blackjack.Shoe)-5, blackjack.CardsNeverSeen())
+
+	blackjack.Rules.BurnCards = 2
+	blackjack.ReshuffleShoe()
+	assert.Equal(t, 0, seat.CardsSeen)
+	assert.Equal(t, 0, len(blackjack.Discards.Seen), "the tray went back into the shoe")
+	assert.Equal(t, []cards.Card{blackjack.Shoe[0], blackjack.Shoe[1]}, blackjack.Discards.Burned)
+	assert.Equal(t, 2, blackjack.ShoeTop, "the burn cards come off the top")
+	assert.Equal(t, len(blackjack.Shoe), blackjack.CardsNeverSeen())
+	blackjack.PlayGame()
+	assert.Equal(t, blackjack.ShoeTop-2, seat.CardsSeen+len(blackjack.Discards.Unseen))
+}
+
+func TestHoleCardVisibility(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John", "Jane")
+	blackjack.Rules.HoleCardFlash = 1
+	blackjack.Rules.DealerTell = 1
+	for i := 0; i < len(blackjack.Players); i++ {
+		blackjack.Players[i].Strategy = strategy.CreateExposedHoleCardStrategy(blackjack.Rules)
+	}
+	// John: 10, 6   Jane: 9, 7   dealer: 10, 6
+	stackShoe(blackjack, []cards.CardRank{cards.TEN, cards.NINE, cards.TEN, cards.SIX, cards.SEVEN, cards.SIX, cards.TEN})
+
+	var round *game.Round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	assert.Equal(t, game.PHASE_PLAYER_TURNS, round.Phase)
+	_, holeCardShown := round.DealerHoleCard()
+	assert.False(t, holeCardShown, "still face down")
+	var holeCard cards.Card = cards.Card{Suite: cards.CLUBS, Rank: cards.SIX}
+	assert.Equal(t, game.HoleCardView{Seen: true, Card: holeCard, Told: true, IsTen: false}, round.HoleCardVisibility(blackjack.Players[0]), "flashed to first base")
+	assert.Equal(t, game.HoleCardView{Told: true, IsTen: false}, round.HoleCardVisibility(blackjack.Players[1]), "the tell is all Jane has")
+
+	assert.Equal(t, game.PlayAction(strategy.STAND), round.DefaultAction(), "John stands 16 vs a dealer 16")
+	round.Step(game.PlayAction(strategy.STAND))
+	assert.Equal(t, game.PlayAction(strategy.STAND), round.DefaultAction(), "Jane stands 16 vs a ten and a small card")
+	var basic *strategy.BasicStrategy = strategy.CreateBasicStrategy(blackjack.Rules)
+	assert.Equal(t, strategy.SURRENDER, basic.DeterminePlay(round.DealerTopCard(), round.CurrentHand(), []strategy.PlayerDecision{strategy.STAND, strategy.HIT, strategy.SURRENDER}), "basic strategy gives 16 vs 10 up")
+
+	blackjack.Rules.HoleCardFlash = 0
+	blackjack.Rules.DealerTell = 0
+	round = blackjack.StartRound()
+	assert.Equal(t, game.HoleCardView{}, round.HoleCardVisibility(blackjack.Players[0]), "nothing dealt yet")
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	if round.Phase == game.PHASE_PLAYER_TURNS {
+		assert.Equal(t, game.HoleCardView{}, round.HoleCardVisibility(blackjack.Players[0]), "a careful dealer")
+	}
+}
+
+// SittingOutStrategy plays basic strategy, when it plays at all
+type SittingOutStrategy struct {
+	strategy.BasicStrategy
+	plays bool
+}
+
+func (self *SittingOutStrategy) PlaysRound() bool {
+	return self.plays
+}
+
+func TestPlayerResultsSince(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.PlayGame()
+	var before game.BlackJackPlayerResults = *blackjack.Results["John"]
+	blackjack.PlayGame()
+	var round game.BlackJackPlayerResults = blackjack.Results["John"].Since(before)
+	assert.Equal(t, blackjack.Results["John"].HandsPlayed-before.HandsPlayed, round.HandsPlayed)
+	assert.Equal(t, round.HandsPlayed, round.HandsWon+round.HandsLost+round.HandsPushed, "the second round's hands")
+	assert.Equal(t, blackjack.Results["John"].Proceeds-before.Proceeds, round.Proceeds)
+	assert.Equal(t, *blackjack.Results["John"], blackjack.Results["John"].Since(game.BlackJackPlayerResults{}))
+}
+
+func TestSeatStrategy(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John", "Jane")
+	var sittingOut *SittingOutStrategy = &SittingOutStrategy{*strategy.CreateBasicStrategy(blackjack.Rules), false}
+	blackjack.Players[1].Strategy = sittingOut
+	var seat *counting.HiLoCounter = counting.CreateHiLoCounter(blackjack.Rules.DecksInShoe)
+	seat.Seat = "Jane"
+	blackjack.AddListener(seat)
+	// John: 10, 6 stands   dealer: 5, 9 hits a 10 and busts
+	stackShoe(blackjack, []cards.CardRank{cards.TEN, cards.FIVE, cards.SIX, cards.NINE, cards.TEN})
+
+	var round *game.Round = blackjack.StartRound()
+	assert.Equal(t, []*game.Player{blackjack.Players[0]}, round.Players)
+	assert.Equal(t, []*game.Player{blackjack.Players[1]}, round.Watchers, "Jane watches")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, 5, seat.CardsSeen, "Jane saw every card from behind John")
+	assert.Equal(t, 1, blackjack.Results["Jane"].RoundsSatOut)
+	assert.Equal(t, 0, blackjack.Results["Jane"].HandsPlayed)
+	assert.True(t, blackjack.IsMidShoe())
+
+	sittingOut.plays = true
+	round = blackjack.StartRound()
+	assert.Equal(t, 2, len(round.Players), "Jane sits down")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+
+	blackjack.Rules.NoMidShoeEntry = true
+	sittingOut.plays = false
+	blackjack.PlayGame()
+	sittingOut.plays = true
+	round = blackjack.StartRound()
+	assert.Equal(t, []*game.Player{blackjack.Players[1]}, round.Watchers, "no coming back in mid-shoe")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	blackjack.ReshuffleShoe()
+	assert.False(t, blackjack.IsMidShoe())
+	round = blackjack.StartRound()
+	assert.Equal(t, 2, len(round.Players), "a new shoe, Jane is back")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Equal(t, 3, blackjack.Results["Jane"].RoundsSatOut)
+
+	blackjack.Players[0].Strategy = &SittingOutStrategy{*strategy.CreateBasicStrategy(blackjack.Rules), false}
+	sittingOut.plays = false
+	shoeTop := blackjack.ShoeTop
+	round = blackjack.StartRound()
+	assert.Equal(t, game.PHASE_DEALING, round.Phase, "nobody bets")
+	for !round.IsOver() {
+		round.Step(round.DefaultAction())
+	}
+	assert.Greater(t, blackjack.ShoeTop, shoeTop, "the dealer deals on for the rest of the table")
+}
+
+func TestBonusHandPayout(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	sameColor := house_rules.BonusHand{Hand: house_rules.BONUS_BLACKJACK, Suits: house_rules.SAME_COLOR, Payout: money.PAYS_7_TO_5}
+	sixSevenEight := house_rules.BonusHand{Hand: house_rules.BONUS_678, Payout: money.PAYS_2_TO_1}
+	rules.BonusHands = []house_rules.BonusHand{house_rules.SUITED_BLACKJACK, sameColor, house_rules.SEVENS_BONUS, sixSevenEight, house_rules.CreateCharlie(6)}
+	heart := func(rank cards.CardRank) cards.Card { return cards.Card{Suite: cards.HEARTS, Rank: rank} }
+	diamond := func(rank cards.CardRank) cards.Card { return cards.Card{Suite: cards.DIAMONDS, Rank: rank} }
+	club := func(rank cards.CardRank) cards.Card { return cards.Card{Suite: cards.CLUBS, Rank: rank} }
+
+	payout, name := game.BonusHandPayout(createSuitedHand(heart(cards.ACE), heart(cards.KING)), rules)
+	assert.Equal(t, money.PAYS_2_TO_1, payout, "the best bonus")
+	assert.Equal(t, "suited blackjack", name)
+	payout, _ = game.BonusHandPayout(createSuitedHand(heart(cards.ACE), diamond(cards.KING)), rules)
+	assert.Equal(t, money.PAYS_7_TO_5, payout, "same color blackjack")
+	payout, _ = game.BonusHandPayout(createSuitedHand(heart(cards.ACE), club(cards.KING)), rules)
+	assert.Equal(t, money.Payout{}, payout, "red and black")
+
+	payout, _ = game.BonusHandPayout(createSuitedHand(club(cards.SEVEN), heart(cards.SEVEN), diamond(cards.SEVEN)), rules)
+	assert.Equal(t, money.PAYS_3_TO_2, payout, "7-7-7 any suits")
+	payout, _ = game.BonusHandPayout(createSuitedHand(club(cards.EIGHT), heart(cards.SIX), diamond(cards.SEVEN)), rules)
+	assert.Equal(t, money.PAYS_2_TO_1, payout, "6-7-8 in any order")
+	payout, _ = game.BonusHandPayout(createSuitedHand(club(cards.SEVEN), club(cards.SEVEN), club(cards.FIVE), club(cards.TWO)), rules)
+	assert.Equal(t, money.Payout{}, payout, "a four card 21")
+
+	charlie := createSuitedHand(club(cards.TWO), club(cards.THREE), club(cards.TWO), club(cards.FOUR), club(cards.ACE))
+	payout, _ = game.BonusHandPayout(charlie, rules)
+	assert.Equal(t, money.Payout{}, payout, "five cards is not a six card charlie")
+	charlie.AddCard(club(cards.TWO))
+	payout, name = game.BonusHandPayout(charlie, rules)
+	assert.Equal(t, money.EVEN_MONEY, payout)
+	assert.Equal(t, "6 card charlie", name)
+	charlie.AddCard(club(cards.TEN))
+	payout, _ = game.BonusHandPayout(charlie, rules)
+	assert.Equal(t, money.Payout{}, payout, "bust")
+}
+
+func TestRoundBonusHands(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	rules.BonusHands = []house_rules.BonusHand{house_rules.SUITED_BLACKJACK, house_rules.SEVENS_BONUS, house_rules.CreateCharlie(5)}
+	var blackjack *game.BlackJack
+
+	// John: A, K of clubs   Dealer: 10, 9
+	blackjack = gameRound(t, rules, []cards.CardRank{cards.ACE, cards.TEN, cards.KING, cards.NINE})
+	assert.Equal(t, money.Dollars(4), blackjack.Results["John"].Proceeds, "a suited natural pays 2 to 1")
+	assert.Equal(t, 1, blackjack.Stats.BonusPayoutCount)
+
+	// John: 2, 3 hits 2, 4, 3   Dealer: 10, 10
+	var hits []game.RoundAction = []game.RoundAction{game.PlayAction(strategy.HIT), game.PlayAction(strategy.HIT), game.PlayAction(strategy.HIT)}
+	blackjack = gameRound(t, rules, []cards.CardRank{cards.TWO, cards.TEN, cards.THREE, cards.TEN, cards.TWO, cards.FOUR, cards.THREE}, hits...)
+	assert.Equal(t, 7, blackjack.ShoeTop, "the charlie is done at five cards, John draws no more")
+	assert.Equal(t, money.Dollars(2), blackjack.Results["John"].Proceeds, "a 14 beats the dealer's 20")
+	assert.Equal(t, 1, blackjack.Stats.CharlieCount)
+
+	// John: 7, 7 hits 7   Dealer: 10, 8
+	var sevens []cards.CardRank = []cards.CardRank{cards.SEVEN, cards.TEN, cards.SEVEN, cards.EIGHT, cards.SEVEN}
+	blackjack = gameRound(t, rules, sevens, game.PlayAction(strategy.HIT))
+	assert.Equal(t, money.Dollars(3), blackjack.Results["John"].Proceeds, "7-7-7 pays 3 to 2")
+
+	// John: 7, 7 hits 7   Dealer: 10, 8, no bonus hands
+	blackjack = gameRound(t, house_rules.CreateHouseRules(), sevens, game.PlayAction(strategy.HIT))
+	assert.Equal(t, money.Dollars(2), blackjack.Results["John"].Proceeds, "even money")
+}
+
+func TestRoundNoHoleCard(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.Rules = house_rules.CreateAustralianRules()
+	var counter *counting.HiLoCounter = counting.CreateHiLoCounter(blackjack.Rules.DecksInShoe)
+	blackjack.AddListener(counter)
+	// John: 8, 8 splits, 8-10 and 8-10   Dealer: 10, then A
+	stackShoe(blackjack, []cards.CardRank{cards.EIGHT, cards.TEN, cards.EIGHT, cards.TEN, cards.TEN, cards.ACE})
+	var round *game.Round = blackjack.StartRound()
+	round.Step(game.BetAction([]int{2}))
+	round.Step(game.CONTINUE)
+	assert.Equal(t, game.PHASE_PLAYER_TURNS, round.Phase)
+	assert.Equal(t, 1, round.Dealer.DealerHand.NumCards(), "no hole card")
+	assert.Equal(t, 3, counter.CardsSeen)
+	_, holeCardShown := round.DealerHoleCard()
+	assert.False(t, holeCardShown)
+	round.Step(game.PlayAction(strategy.SPLIT))
+	round.Step(game.PlayAction(strategy.STAND))
+	round.Step(game.PlayAction(strategy.STAND))
+	assert.Equal(t, game.PHASE_DEALER_TURN, round.Phase)
+	round.Step(game.CONTINUE)
+	assert.Equal(t, game.PHASE_SETTLEMENT, round.Phase, "a dealer natural")
+	round.Step(game.CONTINUE)
+	assert.Equal(t, money.Dollars(-4), blackjack.Results["John"].Proceeds, "the dealer natural takes the split bet too")
+	assert.Equal(t, 6, counter.CardsSeen)
+
+	// John: A, K   Dealer: A, then K
+	blackjack = gameRound(t, house_rules.CreateAustralianRules(), []cards.CardRank{cards.ACE, cards.ACE, cards.KING, cards.KING})
+	assert.Equal(t, money.Dollars(0), blackjack.Results["John"].Proceeds, "naturals push")
+
+	// John: 10, 7 stands   Dealer: 9, then 7, 5
+	blackjack = gameRound(t, house_rules.CreateAustralianRules(), []cards.CardRank{cards.TEN, cards.NINE, cards.SEVEN, cards.SEVEN, cards.FIVE}, game.PlayAction(strategy.STAND))
+	assert.Equal(t, money.Dollars(-2), blackjack.Results["John"].Proceeds, "the dealer draws to 21")
+
+	// John: 2, 3 hits 4, 5, 7   Dealer: 10, then 8
+	var ranks []cards.CardRank = []cards.CardRank{cards.TWO, cards.TEN, cards.THREE, cards.FOUR, cards.FIVE, cards.SEVEN, cards.EIGHT}
+	var hits []game.RoundAction = []game.RoundAction{game.PlayAction(strategy.HIT), game.PlayAction(strategy.HIT), game.PlayAction(strategy.HIT), game.PlayAction(strategy.STAND)}
+	blackjack = gameRound(t, house_rules.CreateAustralianRules(), ranks, hits...)
+	assert.Equal(t, money.Dollars(2), blackjack.Results["John"].Proceeds, "even money")
+	blackjack = gameRound(t, house_rules.CreateAustralianFiveCard21Rules(), ranks, hits...)
+	assert.Equal(t, money.Dollars(3), blackjack.Results["John"].Proceeds, "a five card 21 pays 3 to 2")
+}
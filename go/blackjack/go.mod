@@ -2,7 +2,10 @@ module github.com/bnwest/GoBlackjackSimulation/go/blackjack
 
 go 1.23.3
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/gorilla/websocket v1.5.3
+	github.com/stretchr/testify v1.10.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
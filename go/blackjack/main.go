@@ -54,12 +54,57 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"log"
+	"net/http"
+	"runtime"
 
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/env"
 	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/server"
 )
 
 func main() {
+	// go run main.go -serve :8080
+	// runs simulation jobs submitted over HTTP instead of the games below.
+	serveAddress := flag.String("serve", "", "address for the simulation job server, eg :8080")
+	workers := flag.Int("workers", runtime.NumCPU(), "simulation jobs run at the same time")
+	queueSize := flag.Int("queue", 100, "simulation jobs waiting for a worker")
+	tables := flag.Int("tables", 1, "blackjack tables to play at over web sockets")
+	// go run main.go -qlearn 1000000
+	// trains a Q-learning agent instead of playing the games below.
+	qlearnEpisodes := flag.Int("qlearn", 0, "episodes to train a Q-learning agent for")
+	flag.Parse()
+
+	if *qlearnEpisodes > 0 {
+		var environment *env.Environment = env.CreateEnvironment(house_rules.CreateHouseRules())
+		environment.Reset(1)
+		var learner *env.QLearner = env.CreateQLearner(1)
+		const evaluationEpisodes int = 100_000
+		for trained := 0; trained < *qlearnEpisodes; {
+			episodes := min(*qlearnEpisodes-trained, max(*qlearnEpisodes/10, 1))
+			learner.Train(environment, episodes)
+			trained += episodes
+			var evaluation env.QEvaluation = learner.Evaluate(environment, evaluationEpisodes)
+			fmt.Printf("episodes: %v, states: %v, evaluation: %+v\n", trained, len(learner.Table), evaluation)
+		}
+		return
+	}
+
+	if *serveAddress != "" {
+		var jobs *server.JobManager = server.CreateJobManager(*workers, *queueSize)
+		var httpServer *server.Server = server.CreateServer(jobs)
+		for i := 0; i < *tables; i++ {
+			httpServer.AddTable(server.CreateTable(fmt.Sprintf("table-%v", i+1), server.CreateTableConfig()))
+		}
+		httpServer.RunTables(context.Background())
+		log.Printf("simulation job server listening on %v", *serveAddress)
+		log.Fatal(http.ListenAndServe(*serveAddress, httpServer))
+	}
+
 	var blackjack *game.BlackJack = game.CreateBlackJack()
 	for i := 0; i < 100; i++ {
 		blackjack.PlayGame()
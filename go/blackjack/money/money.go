@@ -0,0 +1,83 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Money is an exact amount, in cents.  Bets are made in whole dollars but
+// what they win need not be, eg 3 to 2 on $5 is $7.50 and 6 to 5 on $7 is
+// $8.40, which int(float32(bet) * payout) silently turned into $7 and $8.
+type Money int64
+
+const CENTS_PER_DOLLAR int64 = 100
+
+// a bankroll that never runs out
+const UNLIMITED Money = math.MaxInt64
+
+func Dollars(dollars int) Money {
+	return Money(int64(dollars) * CENTS_PER_DOLLAR)
+}
+
+func Cents(cents int64) Money {
+	return Money(cents)
+}
+
+func (self Money) Cents() int64 {
+	return int64(self)
+}
+
+// WholeDollars() is the money rounded down to the dollar, eg what can be bet with it.
+func (self Money) WholeDollars() int {
+	if self == UNLIMITED {
+		return math.MaxInt
+	}
+	dollars := int64(self) / CENTS_PER_DOLLAR
+	if int64(self)%CENTS_PER_DOLLAR < 0 {
+		dollars--
+	}
+	return int(dollars)
+}
+
+// Float() is the money in dollars, for averages and ratios, never for adding up.
+func (self Money) Float() float64 {
+	return float64(self) / float64(CENTS_PER_DOLLAR)
+}
+
+// eg "7" or "7.50", "-7.50"
+func (self Money) decimal() string {
+	cents := int64(self)
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	if cents%CENTS_PER_DOLLAR == 0 {
+		return fmt.Sprintf("%v%v", sign, cents/CENTS_PER_DOLLAR)
+	}
+	return fmt.Sprintf("%v%v.%02d", sign, cents/CENTS_PER_DOLLAR, cents%CENTS_PER_DOLLAR)
+}
+
+// String() leaves the cents off whole dollars, eg "7" and "7.50",
+// so that the play by play reads "won $7" and "won $7.50".
+func (self Money) String() string {
+	if self == UNLIMITED {
+		return "unlimited"
+	}
+	return self.decimal()
+}
+
+// Money is a number of dollars in JSON, eg 7.5
+func (self Money) MarshalJSON() ([]byte, error) {
+	return []byte(self.decimal()), nil
+}
+
+func (self *Money) UnmarshalJSON(data []byte) error {
+	dollars, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("money must be a number of dollars, got %s", data)
+	}
+	*self = Money(math.Round(dollars * float64(CENTS_PER_DOLLAR)))
+	return nil
+}
@@ -0,0 +1,150 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Payout is what a win pays as odds, eg 3:2 => $3 for every $2 bet.
+// The zero Payout pays nothing.
+type Payout struct {
+	Win int
+	Bet int
+}
+
+// the usual payouts for a natural
+var PAYS_3_TO_2 Payout = Payout{Win: 3, Bet: 2}
+var PAYS_6_TO_5 Payout = Payout{Win: 6, Bet: 5}
+var PAYS_7_TO_5 Payout = Payout{Win: 7, Bet: 5}
+var PAYS_2_TO_1 Payout = Payout{Win: 2, Bet: 1}
+var EVEN_MONEY Payout = Payout{Win: 1, Bet: 1}
+
+// PaysToOne() is the payout of win to 1, eg PaysToOne(25) for a 25 to 1 side bet.
+func PaysToOne(win int) Payout {
+	return Payout{Win: win, Bet: 1}
+}
+
+func (self Payout) IsZero() bool {
+	return self.Win == 0 || self.Bet == 0
+}
+
+// Ratio() is what the payout wins per dollar bet, eg 1.5 for 3:2
+func (self Payout) Ratio() float64 {
+	if self.IsZero() {
+		return 0
+	}
+	return float64(self.Win) / float64(self.Bet)
+}
+
+// eg "3:2", "25:1"
+func (self Payout) String() string {
+	if self.IsZero() {
+		return "0"
+	}
+	return fmt.Sprintf("%v:%v", self.Win, self.Bet)
+}
+
+// Pay() is what bet wins, the fraction of a cent and then whatever the
+// rounding leaves over is kept by the house.
+func (self Payout) Pay(bet int, rounding Rounding) Money {
+	return self.PayAmount(Dollars(bet), rounding)
+}
+
+// PayAmount() is Pay() on a bet that need not be whole dollars, eg insurance
+// on half of a $5 bet.
+func (self Payout) PayAmount(bet Money, rounding Rounding) Money {
+	if self.IsZero() {
+		return 0
+	}
+	var cents int64 = bet.Cents() * int64(self.Win) / int64(self.Bet)
+	return rounding.Round(Money(cents))
+}
+
+// ParsePayout() takes "3:2", "3 to 2" or the ratio, eg "1.5", which is
+// turned into the odds it is closest to, with at most 100 on the bet side.
+func ParsePayout(text string) (Payout, error) {
+	text = strings.TrimSpace(text)
+	for _, separator := range []string{":", " to "} {
+		win, bet, found := strings.Cut(text, separator)
+		if !found {
+			continue
+		}
+		winNumber, err1 := strconv.Atoi(strings.TrimSpace(win))
+		betNumber, err2 := strconv.Atoi(strings.TrimSpace(bet))
+		if err1 != nil || err2 != nil || winNumber < 0 || betNumber < 1 {
+			return Payout{}, fmt.Errorf("payout must look like 3:2, got %q", text)
+		}
+		return Payout{Win: winNumber, Bet: betNumber}, nil
+	}
+	ratio, err := strconv.ParseFloat(text, 64)
+	if err != nil || ratio < 0 {
+		return Payout{}, fmt.Errorf("payout must look like 3:2, got %q", text)
+	}
+	return payoutFromRatio(ratio), nil
+}
+
+// eg 1.5 => 3:2, 1.2 => 6:5
+func payoutFromRatio(ratio float64) Payout {
+	if ratio == 0 {
+		return Payout{}
+	}
+	for bet := 1; bet <= 100; bet++ {
+		win := math.Round(ratio * float64(bet))
+		if math.Abs(win/float64(bet)-ratio) < 1e-6 {
+			return Payout{Win: int(win), Bet: bet}
+		}
+	}
+	return Payout{Win: int(math.Round(ratio * 100)), Bet: 100}
+}
+
+// a Payout is "3:2" in JSON, a number like 1.5 is taken too
+func (self Payout) MarshalJSON() ([]byte, error) {
+	return json.Marshal(self.String())
+}
+
+func (self *Payout) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		text = string(data)
+	}
+	payout, err := ParsePayout(text)
+	if err != nil {
+		return err
+	}
+	*self = payout
+	return nil
+}
+
+// Rounding is how far down a payout is rounded, to the smallest chip the
+// table pays with.  A table with no 50 cent chips pays $7 for 3 to 2 on $5.
+type Rounding string
+
+const (
+	// exact, to the cent
+	ROUND_TO_CENT             Rounding = "cent"
+	ROUND_DOWN_TO_HALF_DOLLAR Rounding = "half-dollar"
+	ROUND_DOWN_TO_DOLLAR      Rounding = "dollar"
+)
+
+var rounding_cents map[Rounding]int64 = map[Rounding]int64{
+	ROUND_TO_CENT:             1,
+	ROUND_DOWN_TO_HALF_DOLLAR: CENTS_PER_DOLLAR / 2,
+	ROUND_DOWN_TO_DOLLAR:      CENTS_PER_DOLLAR,
+}
+
+func (self Rounding) IsValid() bool {
+	_, ok := rounding_cents[self]
+	return ok
+}
+
+// Round() rounds a win down, unknown roundings round to the cent
+func (self Rounding) Round(amount Money) Money {
+	step, ok := rounding_cents[self]
+	if !ok || amount < 0 {
+		return amount
+	}
+	return Money(int64(amount) - int64(amount)%step)
+}
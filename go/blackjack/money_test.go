@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney(t *testing.T) {
+	assert.Equal(t, money.Cents(750), money.Dollars(7)+money.Cents(50))
+	assert.Equal(t, "7", money.Dollars(7).String())
+	assert.Equal(t, "7.50", money.Cents(750).String())
+	assert.Equal(t, "-0.40", money.Cents(-40).String())
+	assert.Equal(t, 7, money.Cents(799).WholeDollars(), "rounded down")
+	assert.Equal(t, -1, money.Cents(-40).WholeDollars(), "rounded down")
+	assert.Equal(t, 7.5, money.Cents(750).Float())
+
+	data, err := json.Marshal(money.Cents(-750))
+	assert.NoError(t, err)
+	assert.Equal(t, "-7.50", string(data))
+	var amount money.Money
+	assert.NoError(t, json.Unmarshal([]byte("8.4"), &amount))
+	assert.Equal(t, money.Cents(840), amount)
+}
+
+func TestPayout(t *testing.T) {
+	cases := []struct {
+		payout   money.Payout
+		bet      int
+		rounding money.Rounding
+		expected money.Money
+	}{
+		{money.PAYS_3_TO_2, 5, money.ROUND_TO_CENT, money.Cents(750)},
+		{money.PAYS_3_TO_2, 5, money.ROUND_DOWN_TO_HALF_DOLLAR, money.Cents(750)},
+		{money.PAYS_3_TO_2, 5, money.ROUND_DOWN_TO_DOLLAR, money.Dollars(7)},
+		{money.PAYS_6_TO_5, 5, money.ROUND_DOWN_TO_DOLLAR, money.Dollars(6)},
+		{money.PAYS_6_TO_5, 7, money.ROUND_TO_CENT, money.Cents(840)},
+		{money.PAYS_6_TO_5, 7, money.ROUND_DOWN_TO_HALF_DOLLAR, money.Dollars(8)},
+		{money.PAYS_7_TO_5, 3, money.ROUND_TO_CENT, money.Cents(420)},
+		{money.PAYS_7_TO_5, 3, money.ROUND_DOWN_TO_HALF_DOLLAR, money.Dollars(4)},
+		{money.PAYS_2_TO_1, 3, money.ROUND_DOWN_TO_DOLLAR, money.Dollars(6)},
+		{money.EVEN_MONEY, 3, money.ROUND_TO_CENT, money.Dollars(3)},
+		{money.Payout{Win: 4, Bet: 3}, 1, money.ROUND_TO_CENT, money.Cents(133)},
+		{money.Payout{}, 5, money.ROUND_TO_CENT, money.Dollars(0)},
+	}
+	for i := 0; i < len(cases); i++ {
+		assert.Equal(t, cases[i].expected, cases[i].payout.Pay(cases[i].bet, cases[i].rounding), "%v on $%v to the %v", cases[i].payout, cases[i].bet, cases[i].rounding)
+	}
+	assert.Equal(t, 1.2, money.PAYS_6_TO_5.Ratio())
+	assert.False(t, money.Rounding("penny").IsValid())
+}
+
+func TestParsePayout(t *testing.T) {
+	expected := map[string]money.Payout{
+		"3:2":    money.PAYS_3_TO_2,
+		"6 to 5": money.PAYS_6_TO_5,
+		"1.4":    money.PAYS_7_TO_5,
+		"1.5":    money.PAYS_3_TO_2,
+		"2":      money.PAYS_2_TO_1,
+		"0":      {},
+	}
+	for text, payout := range expected {
+		parsed, err := money.ParsePayout(text)
+		assert.NoError(t, err, text)
+		assert.Equal(t, payout, parsed, text)
+	}
+	for _, text := range []string{"", "3:0", "three to two", "-1"} {
+		_, err := money.ParsePayout(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestHouseRulesPayoutJSON(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	assert.NoError(t, json.Unmarshal([]byte(`{"natural_blackjack_payout": "6:5", "payout_rounding": "dollar"}`), rules))
+	assert.Equal(t, money.PAYS_6_TO_5, rules.NaturalBlackjackPayout)
+	assert.Equal(t, money.ROUND_DOWN_TO_DOLLAR, rules.PayoutRounding)
+	assert.NoError(t, rules.Validate())
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"natural_blackjack_payout": 1.5}`), rules), "the ratio still works")
+	assert.Equal(t, money.PAYS_3_TO_2, rules.NaturalBlackjackPayout)
+
+	data, err := json.Marshal(rules)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"natural_blackjack_payout":"3:2"`)
+
+	rules.PayoutRounding = "penny"
+	assert.Error(t, rules.Validate())
+	rules = house_rules.CreateHouseRules()
+	rules.NaturalBlackjackPayout = money.Payout{Win: 5, Bet: 2}
+	assert.Error(t, rules.Validate(), "no more than 2 to 1")
+}
+
+// naturalRound() deals John a natural against the dealer's 17 for a $5 bet
+func naturalRound(rules *house_rules.HouseRules) *game.BlackJack {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	blackjack.Rules = rules
+	stackShoe(blackjack, []cards.CardRank{cards.ACE, cards.TEN, cards.KING, cards.SEVEN})
+	blackjack.Players[0].Bets = []int{5}
+	blackjack.Players[0].Bankroll = money.Dollars(5)
+	blackjack.PlayGame()
+	return blackjack
+}
+
+func TestRoundNaturalPayouts(t *testing.T) {
+	var blackjack *game.BlackJack = naturalRound(house_rules.CreateHouseRules())
+	assert.Equal(t, money.Cents(750), blackjack.Results["John"].Proceeds, "3 to 2 on $5, to the cent")
+	assert.Equal(t, money.Cents(1250), blackjack.Players[0].Bankroll, "the $5 bet and the $7.50 won")
+
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	rules.PayoutRounding = money.ROUND_DOWN_TO_DOLLAR
+	blackjack = naturalRound(rules)
+	assert.Equal(t, money.Dollars(7), blackjack.Results["John"].Proceeds, "no 50 cent chips")
+
+	rules = house_rules.CreateHouseRules()
+	rules.NaturalBlackjackPayout = money.PAYS_6_TO_5
+	blackjack = naturalRound(rules)
+	assert.Equal(t, money.Dollars(6), blackjack.Results["John"].Proceeds, "6 to 5 on $5")
+
+	rules.NaturalBlackjackPayout = money.PAYS_7_TO_5
+	blackjack = naturalRound(rules)
+	assert.Equal(t, money.Dollars(7), blackjack.Results["John"].Proceeds, "7 to 5 on $5")
+}
@@ -0,0 +1,30 @@
+package rules
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
+// Australian blackjack deals the dealer no hole card (ENHC): the dealer's
+// second card comes after the players have played, and a dealer natural
+// then takes every bet on the table, doubles and splits included.
+// The dealer stands on all 17s, doubling down is on 9, 10 or 11 only.
+func CreateAustralianRules() *HouseRules {
+	var rules *HouseRules = CreateHouseRules()
+	rules.DecksInShoe = 6
+	rules.ForceReshuffle = rules.DefaultForceReshuffle()
+	rules.NoHoleCard = true
+	rules.DealerHitsSoftOn = 16
+	rules.Surrender = SURRENDER_NONE
+	rules.DoubleDownOn = DOUBLE_DOWN_9_TO_11
+	rules.DoubleDownAfterSplit = true
+	return rules
+}
+
+// the Australian game that pays 3 to 2 on a 21 of five or more cards
+var AUSTRALIAN_FIVE_CARD_21_PAYOUT money.Payout = money.PAYS_3_TO_2
+
+func CreateAustralianFiveCard21Rules() *HouseRules {
+	var rules *HouseRules = CreateAustralianRules()
+	rules.FiveCard21Payout = AUSTRALIAN_FIVE_CARD_21_PAYOUT
+	return rules
+}
@@ -0,0 +1,111 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
+// Bonus hands are paid more than the table's usual payout, eg a suited
+// natural at 2 to 1, or a 7-7-7 at 3 to 2.  A Charlie is a hand of that many
+// cards that has not bust, it is over and wins automatically, unless the
+// dealer has a natural.  Only the biggest bonus a hand earns is paid, and,
+// a Charlie aside, only when the hand wins.  The Spanish 21 bonuses and
+// Pontoon's five card trick have rules of their own, see SPANISH_21_BONUSES
+// and FIVE_CARD_TRICK.
+type BonusHandKind string
+
+const (
+	// a natural, paid instead of NaturalBlackjackPayout when that is more
+	BONUS_BLACKJACK BonusHandKind = "blackjack"
+	BONUS_CHARLIE   BonusHandKind = "charlie"
+	BONUS_777       BonusHandKind = "7-7-7"
+	BONUS_678       BonusHandKind = "6-7-8"
+)
+
+// the suits the cards of a bonus hand have to be
+type BonusSuits string
+
+const (
+	ANY_SUITS  BonusSuits = ""
+	SAME_COLOR BonusSuits = "same-color"
+	SUITED     BonusSuits = "suited"
+)
+
+type BonusHand struct {
+	Hand  BonusHandKind `json:"hand"`
+	Suits BonusSuits    `json:"suits,omitempty"`
+	// BONUS_CHARLIE: the number of cards, 5 thru 7
+	Cards  int          `json:"cards,omitempty"`
+	Payout money.Payout `json:"payout"`
+}
+
+// the usual bonus hands
+var SUITED_BLACKJACK BonusHand = BonusHand{Hand: BONUS_BLACKJACK, Suits: SUITED, Payout: money.PAYS_2_TO_1}
+var SEVENS_BONUS BonusHand = BonusHand{Hand: BONUS_777, Payout: money.PAYS_3_TO_2}
+
+func CreateCharlie(numCards int) BonusHand {
+	return BonusHand{Hand: BONUS_CHARLIE, Cards: numCards, Payout: money.EVEN_MONEY}
+}
+
+// eg "suited blackjack", "6 card charlie"
+func (self BonusHand) String() string {
+	if self.Hand == BONUS_CHARLIE {
+		return fmt.Sprintf("%v card charlie", self.Cards)
+	}
+	if self.Suits != ANY_SUITS {
+		return fmt.Sprintf("%v %v", self.Suits, self.Hand)
+	}
+	return string(self.Hand)
+}
+
+func (self BonusHand) Validate() error {
+	switch self.Hand {
+	case BONUS_BLACKJACK, BONUS_777, BONUS_678:
+		if self.Cards != 0 {
+			return fmt.Errorf("only a charlie has a number of cards, got %v for %v", self.Cards, self.Hand)
+		}
+	case BONUS_CHARLIE:
+		if self.Cards < 5 || self.Cards > 7 {
+			return fmt.Errorf("a charlie is 5 thru 7 cards, got %v", self.Cards)
+		}
+		if self.Suits != ANY_SUITS {
+			return fmt.Errorf("a charlie is any suits, got %q", self.Suits)
+		}
+	default:
+		return fmt.Errorf("unknown bonus hand %q", self.Hand)
+	}
+	switch self.Suits {
+	case ANY_SUITS, SAME_COLOR, SUITED:
+	default:
+		return fmt.Errorf("unknown bonus suits %q", self.Suits)
+	}
+	if self.Payout.Ratio() < 1 || self.Payout.Ratio() > 10 {
+		return fmt.Errorf("%v payout must be between 1:1 and 10:1, got %v", self, self.Payout)
+	}
+	return nil
+}
+
+// CharlieCards() is the fewest cards a Charlie takes, 0 => no Charlie.
+func (self *HouseRules) CharlieCards() int {
+	numCards := 0
+	for i := 0; i < len(self.BonusHands); i++ {
+		if self.BonusHands[i].Hand == BONUS_CHARLIE && (numCards == 0 || self.BonusHands[i].Cards < numCards) {
+			numCards = self.BonusHands[i].Cards
+		}
+	}
+	return numCards
+}
+
+// CharliePayout() is what the Charlie of numCards pays, the best of them,
+// the zero payout => it is no Charlie.
+func (self *HouseRules) CharliePayout(numCards int) money.Payout {
+	var payout money.Payout = money.Payout{}
+	for i := 0; i < len(self.BonusHands); i++ {
+		var bonusHand BonusHand = self.BonusHands[i]
+		if bonusHand.Hand == BONUS_CHARLIE && numCards >= bonusHand.Cards && bonusHand.Payout.Ratio() > payout.Ratio() {
+			payout = bonusHand.Payout
+		}
+	}
+	return payout
+}
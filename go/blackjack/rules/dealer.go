@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+)
+
+// A DealerPolicy is the rule the dealer has to play by: hit or stand.
+// The common ones are set by the dealer's hard and soft totals:
+//     S17        stand on all 17s                 hits hard 16, soft 16
+//     H17        hit soft 17                      hits hard 16, soft 17
+//     H soft 18  hit soft 17 and soft 18          hits hard 16, soft 18
+// and a house can make exceptions for certain cards, eg stand on 10-6,
+// see DealerComposition.
+//
+// The dealer's cards are given by value, Ace => 1, face cards => 10,
+// so that a policy can look at more than the totals.
+
+type DealerPolicy interface {
+	Name() string
+	Hits(cardValues []int) bool
+}
+
+// HandTotals() gives the hard total and the soft total, where one Ace counts
+// as 11 if that does not bust the hand.  The two are the same when there is
+// no Ace to count as 11.
+func HandTotals(cardValues []int) (int, int) {
+	hardTotal := 0
+	hasAce := false
+	for i := 0; i < len(cardValues); i++ {
+		hardTotal += cardValues[i]
+		if cardValues[i] == 1 {
+			hasAce = true
+		}
+	}
+	softTotal := hardTotal
+	if hasAce && hardTotal+10 <= 21 {
+		softTotal = hardTotal + 10
+	}
+	return hardTotal, softTotal
+}
+
+//
+// TotalsPolicy
+//
+
+// hit a hard total of HitsHardOn or less, a soft total of HitsSoftOn or less
+type TotalsPolicy struct {
+	HitsHardOn int
+	HitsSoftOn int
+}
+
+func CreateTotalsPolicy(hitsHardOn int, hitsSoftOn int) *TotalsPolicy {
+	var policy TotalsPolicy = TotalsPolicy{
+		HitsHardOn: hitsHardOn,
+		HitsSoftOn: hitsSoftOn,
+	}
+	return &policy
+}
+
+func CreateS17Policy() *TotalsPolicy {
+	return CreateTotalsPolicy(16, 16)
+}
+
+func CreateH17Policy() *TotalsPolicy {
+	return CreateTotalsPolicy(16, 17)
+}
+
+func CreateHitSoft18Policy() *TotalsPolicy {
+	return CreateTotalsPolicy(16, 18)
+}
+
+func (self *TotalsPolicy) Name() string {
+	if self.HitsHardOn == 16 {
+		switch self.HitsSoftOn {
+		case 16:
+			return "S17"
+		case 17:
+			return "H17"
+		case 18:
+			return "H soft 18"
+		}
+	}
+	return fmt.Sprintf("hits hard %v soft %v", self.HitsHardOn, self.HitsSoftOn)
+}
+
+func (self *TotalsPolicy) Hits(cardValues []int) bool {
+	hardTotal, softTotal := HandTotals(cardValues)
+	if softTotal > hardTotal {
+		return softTotal <= self.HitsSoftOn
+	}
+	return hardTotal <= self.HitsHardOn
+}
+
+//
+// CompositionPolicy
+//
+
+// DealerComposition is an exception to the dealer's totals for a hand of
+// exactly these card values, in any order, eg {10, 6} => stand on 10-6.
+type DealerComposition struct {
+	// Ace => 1, face cards => 10
+	Values []int `json:"values"`
+	Hits   bool  `json:"hits"`
+}
+
+func (self *DealerComposition) Matches(cardValues []int) bool {
+	if len(cardValues) != len(self.Values) {
+		return false
+	}
+	var sortedCardValues []int = slices.Clone(cardValues)
+	var sortedValues []int = slices.Clone(self.Values)
+	slices.Sort(sortedCardValues)
+	slices.Sort(sortedValues)
+	return slices.Equal(sortedCardValues, sortedValues)
+}
+
+// the first composition matching the hand decides, otherwise the base policy does
+type CompositionPolicy struct {
+	Base         DealerPolicy
+	Compositions []DealerComposition
+}
+
+func CreateCompositionPolicy(base DealerPolicy, compositions []DealerComposition) *CompositionPolicy {
+	var policy CompositionPolicy = CompositionPolicy{
+		Base:         base,
+		Compositions: compositions,
+	}
+	return &policy
+}
+
+func (self *CompositionPolicy) Name() string {
+	return fmt.Sprintf("%v with %v exceptions", self.Base.Name(), len(self.Compositions))
+}
+
+func (self *CompositionPolicy) Hits(cardValues []int) bool {
+	for i := 0; i < len(self.Compositions); i++ {
+		if self.Compositions[i].Matches(cardValues) {
+			return self.Compositions[i].Hits
+		}
+	}
+	return self.Base.Hits(cardValues)
+}
+
+// DealerPolicy() is the policy the house rules call for.
+func (self *HouseRules) DealerPolicy() DealerPolicy {
+	var policy DealerPolicy = CreateTotalsPolicy(self.DealerHitsHardOn, self.DealerHitsSoftOn)
+	if len(self.DealerCompositions) > 0 {
+		policy = CreateCompositionPolicy(policy, self.DealerCompositions)
+	}
+	return policy
+}
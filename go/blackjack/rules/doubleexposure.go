@@ -0,0 +1,23 @@
+package rules
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
+// Double Exposure deals both of the dealer's cards face up.  A natural pays
+// even money and the dealer wins every tie but two naturals, which push.
+// Doubling down is on hard 9 thru 11 only, with one split per hand.
+func CreateDoubleExposureRules() *HouseRules {
+	var rules *HouseRules = CreateHouseRules()
+	rules.DecksInShoe = 8
+	rules.ForceReshuffle = rules.DefaultForceReshuffle()
+	rules.DoubleExposure = true
+	rules.DealerWinsTies = true
+	rules.NaturalBlackjackPayout = money.EVEN_MONEY
+	rules.DealerHitsSoftOn = 17
+	rules.Surrender = SURRENDER_NONE
+	rules.DoubleDownOn = DOUBLE_DOWN_9_TO_11
+	rules.DoubleDownSoftHands = false
+	rules.SplitsPerHand = 1
+	return rules
+}
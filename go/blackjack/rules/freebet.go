@@ -0,0 +1,31 @@
+package rules
+
+// Free Bet blackjack: the house puts up the extra bet for a double down on
+// a two card hard 9, 10 or 11, and for splitting any pair but 10s, re-splits
+// included.  A free bet wins even money and costs nothing when it loses.
+// A dealer 22 pushes all but a natural.
+
+func CreateFreeBetRules() *HouseRules {
+	var rules *HouseRules = CreateHouseRules()
+	rules.DecksInShoe = 6
+	rules.ForceReshuffle = rules.DefaultForceReshuffle()
+	rules.FreeBet = true
+	rules.Dealer22Pushes = true
+	rules.DealerHitsSoftOn = 17
+	rules.Surrender = SURRENDER_NONE
+	rules.DoubleDownOn = DOUBLE_DOWN_ANY_TWO
+	rules.DoubleDownAfterSplit = true
+	return rules
+}
+
+// FreeDouble() is true when the house puts up the double down bet for a hand
+// of numCards cards with these totals: two cards, a hard 9, 10 or 11.
+func (self *HouseRules) FreeDouble(numCards int, hardTotal int, softTotal int) bool {
+	return self.FreeBet && numCards == 2 && hardTotal == softTotal && hardTotal >= 9 && hardTotal <= 11
+}
+
+// FreeSplit() is true when the house puts up the bet for splitting a pair of
+// this card value, Ace => 1: any pair but 10s.
+func (self *HouseRules) FreeSplit(pairValue int) bool {
+	return self.FreeBet && pairValue != 10
+}
@@ -0,0 +1,48 @@
+package rules
+
+import (
+	"fmt"
+)
+
+// The games, by name, that a simulation can be asked to play.  Each one is
+// a family of house rules, which can be tuned like any other house rules.
+
+const (
+	GAME_BLACKJACK               string = "blackjack"
+	GAME_SPANISH_21              string = "spanish-21"
+	GAME_BLACKJACK_SWITCH        string = "blackjack-switch"
+	GAME_FREE_BET                string = "free-bet"
+	GAME_DOUBLE_EXPOSURE         string = "double-exposure"
+	GAME_PONTOON                 string = "pontoon"
+	GAME_AUSTRALIAN              string = "australian"
+	GAME_AUSTRALIAN_FIVE_CARD_21 string = "australian-five-card-21"
+)
+
+func GameNames() []string {
+	return []string{
+		GAME_BLACKJACK, GAME_SPANISH_21, GAME_BLACKJACK_SWITCH, GAME_FREE_BET, GAME_DOUBLE_EXPOSURE,
+		GAME_PONTOON, GAME_AUSTRALIAN, GAME_AUSTRALIAN_FIVE_CARD_21,
+	}
+}
+
+func CreateGameRules(name string) (*HouseRules, error) {
+	switch name {
+	case GAME_BLACKJACK, "":
+		return CreateHouseRules(), nil
+	case GAME_SPANISH_21:
+		return CreateSpanish21Rules(), nil
+	case GAME_BLACKJACK_SWITCH:
+		return CreateBlackjackSwitchRules(), nil
+	case GAME_FREE_BET:
+		return CreateFreeBetRules(), nil
+	case GAME_DOUBLE_EXPOSURE:
+		return CreateDoubleExposureRules(), nil
+	case GAME_PONTOON:
+		return CreatePontoonRules(), nil
+	case GAME_AUSTRALIAN:
+		return CreateAustralianRules(), nil
+	case GAME_AUSTRALIAN_FIVE_CARD_21:
+		return CreateAustralianFiveCard21Rules(), nil
+	}
+	return nil, fmt.Errorf("unknown game %q", name)
+}
@@ -1,5 +1,12 @@
 package rules
 
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
 // instead of having a bag of constants in a struct,
 // have a bag of constants via a package namespace.
 
@@ -7,17 +14,107 @@ const DECKS_IN_SHOE int = 6
 
 const FORCE_RESHUFFLE int = ((52 * DECKS_IN_SHOE) * 3) / 4
 
-// True => Must stand after the Ace split (stand on the Ace plus the one card dealt after split)
-// True => no double down after the Ace split,
-// True => no splitting Aces after the Ace split
-const NO_MORE_CARDS_AFTER_SPLITTING_ACES bool = true
+// The cut card goes in at FORCE_RESHUFFLE, give or take up to this many cards,
+// picked at random every shuffle, 0 => always at FORCE_RESHUFFLE.
+// The round the cut card comes out in is finished, then the shoe is reshuffled.
+const CUT_CARD_SPREAD int = 0
 
-// [9, 10, 11] aka range(9, 12) => "Reno Rules"
-var double_down_on_total []int = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21}
+// no dealer cuts deeper than this, the last round needs cards behind the cut card
+const MAX_PENETRATION float64 = 0.9
 
-func CanDoubleDown(total int) bool {
+// the cards the dealer burns face down after every shuffle, eg 1,
+// a continuous shuffling machine burns none
+const BURN_CARDS int = 0
+
+const MAX_BURN_CARDS int = 10
+
+// the chance the dealer flashes the hole card while dealing it, seen from
+// first base, the seat the dealer deals to first, 0 => a careful dealer
+const HOLE_CARD_FLASH float64 = 0
+
+// the chance the dealer gives away whether the hole card is a ten when
+// peeking under a ten, eg by how long the peek takes, seen by every seat
+const DEALER_TELL float64 = 0
+
+// True => a player who sits a round out can not come back in until the
+// shoe is shuffled, nor can anyone join after the shoe's first round
+const NO_MID_SHOE_ENTRY bool = false
+
+// How the cards are shuffled, by name, see cards.CreateShuffler().
+type ShufflerKind string
+
+const (
+	// a perfect shuffle once the cut card comes out, as good as a batch shuffling machine
+	SHUFFLE_RANDOM ShufflerKind = "random"
+	// a continuous shuffling machine, aka CSM: the discards go back in after
+	// every round and the cards are dealt from the machine, there is no cut card
+	SHUFFLE_CSM ShufflerKind = "csm"
+	// the dealer shuffles by hand, see SHUFFLE_PROCEDURES
+	SHUFFLE_RIFFLE ShufflerKind = "riffle"
+	SHUFFLE_ZONE   ShufflerKind = "zone"
+	SHUFFLE_CASINO ShufflerKind = "casino"
+)
+
+const SHUFFLER ShufflerKind = SHUFFLE_RANDOM
+
+func ShufflerKinds() []ShufflerKind {
+	return []ShufflerKind{SHUFFLE_RANDOM, SHUFFLE_CSM, SHUFFLE_RIFFLE, SHUFFLE_ZONE, SHUFFLE_CASINO}
+}
+
+// Splitting Aces comes with rules of its own, casinos mix and match these three.
+// The common game gets one card on each Ace and that is it.
+
+// True => an Ace split that is dealt another Ace can be split again, aka RSA
+const RESPLIT_ACES bool = false
+
+// True => the hands from split Aces play on like any other hand
+// False => stand on the Ace plus the one card dealt after the split
+const HIT_SPLIT_ACES bool = false
+
+// True => the hands from split Aces can double down, for just the one more card
+const DOUBLE_DOWN_AFTER_SPLITTING_ACES bool = false
+
+// The totals a hand can double down on, by name.
+type DoubleDownRule string
+
+const (
+	DOUBLE_DOWN_ANY_TWO DoubleDownRule = "any-two"
+	// [9, 10, 11] aka range(9, 12) => "Reno Rules"
+	DOUBLE_DOWN_9_TO_11 DoubleDownRule = "9-11"
+	// [10, 11] => European rules
+	DOUBLE_DOWN_10_TO_11 DoubleDownRule = "10-11"
+)
+
+const DOUBLE_DOWN_ON DoubleDownRule = DOUBLE_DOWN_ANY_TWO
+
+// False => no doubling down on a soft hand, eg A-7, whatever its total
+const DOUBLE_DOWN_SOFT_HANDS bool = true
+
+// True => double down after hitting, on any number of cards, found in some variants
+const DOUBLE_DOWN_ANY_NUMBER_OF_CARDS bool = false
+
+// True => a player can double down for less than the bet, eg for whatever is
+// left of the bankroll.  Doubling for less by choice is rarely a good idea.
+const DOUBLE_FOR_LESS bool = false
+
+// Re-doubling is doubling down again on a hand that was doubled down on,
+// for as much again as the hand's bet, eg 2 => double, re-double, re-double.
+const REDOUBLES int = 0
+
+// True => after doubling down, the player can take back the double down
+// bet and give up the original bet, aka double down rescue
+const DOUBLE_DOWN_RESCUE bool = false
+
+var double_down_on_totals map[DoubleDownRule][]int = map[DoubleDownRule][]int{
+	DOUBLE_DOWN_ANY_TWO:  {1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21},
+	DOUBLE_DOWN_9_TO_11:  {9, 10, 11},
+	DOUBLE_DOWN_10_TO_11: {10, 11},
+}
+
+func CanDoubleDownOn(rule DoubleDownRule, total int) bool {
 	// Go does not support constant arrays, maps or slices.
 	// Go also does not have the "in" operator, eg no "total in double_down_on_total"
+	var double_down_on_total []int = double_down_on_totals[rule]
 	for i := 0; i < len(double_down_on_total); i++ {
 		if double_down_on_total[i] == total {
 			return true
@@ -26,7 +123,11 @@ func CanDoubleDown(total int) bool {
 	return false
 }
 
-// Does not apply tp Aces if NO_MORE_CARDS_AFTER_SPLITTING_ACES is true
+func CanDoubleDown(total int) bool {
+	return CanDoubleDownOn(DOUBLE_DOWN_ON, total)
+}
+
+// Does not apply to Aces, see DOUBLE_DOWN_AFTER_SPLITTING_ACES
 const DOUBLE_DOWN_AFTER_SPLIT bool = true
 
 // 3 => turn one hand into no more than 4 hands
@@ -36,13 +137,368 @@ const SPLITS_PER_HAND int = 3
 const SPLIT_ON_VALUE_MATCH bool = true
 
 // Hit on soft 17 (6/8 decks) is more common on low bet tables.
+// 16 / 16 => S17, 16 / 17 => H17, 16 / 18 => H soft 18, see DealerPolicy.
 const DEALER_HITS_HARD_ON int = 16 // or less
 const DEALER_HITS_SOFT_ON int = 17 // or less
 
-// 1.5 => 3 to 2 payout, 1.2 => 6 to 5 payout
+// 3:2 or 6:5, 7:5 and even money at the worse tables.
 // 6 to 5 is more common in two deck games
-const NATURAL_BLACKJACK_PAYOUT float32 = 1.5
+var NATURAL_BLACKJACK_PAYOUT money.Payout = money.PAYS_3_TO_2
+
+// What a payout is rounded down to, eg a $5 natural at 3 to 2 is paid $7.50
+// at a table with 50 cent chips and $7 at a table without.
+const PAYOUT_ROUNDING money.Rounding = money.ROUND_TO_CENT
+
+// True => the 10s are taken out of every deck, leaving 48 cards, as in Spanish 21.
+// The face cards stay in.
+const REMOVE_TENS bool = false
+
+// True => a player 21 wins no matter what the dealer has, as in Spanish 21,
+// which includes a player natural against a dealer natural.
+const PLAYER_21_ALWAYS_WINS bool = false
+
+// True => 21 with five or more cards, 6-7-8 and 7-7-7 are paid a bonus,
+// see SPANISH_21_FIVE_CARD_21_PAYOUT and friends.
+const SPANISH_21_BONUSES bool = false
+
+// True => Blackjack Switch: every player plays two hands of the same bet and
+// may switch the second cards dealt to them, see CreateBlackjackSwitchRules().
+const BLACKJACK_SWITCH bool = false
+
+// True => a dealer 22 pushes every hand still standing, except a natural,
+// the price of switching in Blackjack Switch.
+const DEALER_22_PUSHES bool = false
+
+// True => Free Bet blackjack: free double downs on hard 9 thru 11 and free
+// splits of any pair but 10s, see CreateFreeBetRules().
+const FREE_BET bool = false
+
+// True => Double Exposure: both of the dealer's cards are dealt face up,
+// see CreateDoubleExposureRules().
+const DOUBLE_EXPOSURE bool = false
+
+// True => the dealer wins ties, except a natural against a dealer natural,
+// which still pushes.
+const DEALER_WINS_TIES bool = false
+
+// True => European no hole card, aka ENHC: the dealer takes a second card
+// only after the players have played, so there is no checking for a natural.
+// A dealer natural then takes every bet on the table, doubles and splits included.
+const NO_HOLE_CARD bool = false
+
+// True => Pontoon's five card trick: a hand of five cards that has not bust
+// is over, and beats anything but a dealer natural (a pontoon).
+const FIVE_CARD_TRICK bool = false
+
+var FIVE_CARD_TRICK_PAYOUT money.Payout = money.PAYS_2_TO_1
+
+// A winning 21 of five or more cards is paid this, as in some Australian
+// games.  The zero payout => even money, like any other 21.
+var FIVE_CARD_21_PAYOUT money.Payout = money.Payout{}
+
+// The player can not stand below this count and has to hit, 15 in Pontoon.
+// 0 => stand on anything.
+const PLAYER_MINIMUM_STAND int = 0
+
+// True => Pontoon words for the decisions in the play by play and at the
+// table: stick, twist and buy for stand, hit and double down.
+const PONTOON_TERMS bool = false
+
+// Surrender gives up half the bet to get out of a bad hand.
+// Late surrender comes after the dealer checks for a natural, so against
+// a dealer natural the whole bet is lost anyway.  Early surrender comes before
+// the check, against an Ace and/or a 10, and is worth several tenths of
+// a percent more to the player.  Against a 2 thru 9 there is no check,
+// early and late surrender are the same thing.
+type SurrenderMode string
+
+const (
+	SURRENDER_NONE         SurrenderMode = "none"
+	SURRENDER_LATE         SurrenderMode = "late"
+	SURRENDER_EARLY        SurrenderMode = "early"
+	SURRENDER_EARLY_VS_ACE SurrenderMode = "early-vs-ace"
+	SURRENDER_EARLY_VS_TEN SurrenderMode = "early-vs-ten"
+)
 
 // Usually 8 deck game, no Ace re-splitting, 50-100 minimum bet ...
-// Setting True here since I am a high roller ;) and want to shake out the code.
-const SURRENDER_ALLOWED bool = true
+// Allowing late surrender here since I am a high roller ;) and want to shake out the code.
+const SURRENDER SurrenderMode = SURRENDER_LATE
+
+// HouseRules bundles the constants above into a value, so that a simulation
+// can be run with rules other than the compile time defaults, eg a job
+// submitted to the simulation server.  The constants remain the defaults.
+type HouseRules struct {
+	DecksInShoe                  int            `json:"decks_in_shoe"`
+	ForceReshuffle               int            `json:"force_reshuffle"`
+	CutCardSpread                int            `json:"cut_card_spread"`
+	Shuffler                     ShufflerKind   `json:"shuffler"`
+	ResplitAces                  bool           `json:"resplit_aces"`
+	HitSplitAces                 bool           `json:"hit_split_aces"`
+	DoubleDownAfterSplittingAces bool           `json:"double_down_after_splitting_aces"`
+	DoubleDownAfterSplit         bool           `json:"double_down_after_split"`
+	DoubleDownOn                 DoubleDownRule `json:"double_down_on"`
+	DoubleDownSoftHands          bool           `json:"double_down_soft_hands"`
+	DoubleDownAnyNumberOfCards   bool           `json:"double_down_any_number_of_cards"`
+	DoubleForLess                bool           `json:"double_for_less"`
+	Redoubles                    int            `json:"redoubles"`
+	DoubleDownRescue             bool           `json:"double_down_rescue"`
+	SplitsPerHand                int            `json:"splits_per_hand"`
+	SplitOnValueMatch            bool           `json:"split_on_value_match"`
+	DealerHitsHardOn             int            `json:"dealer_hits_hard_on"`
+	DealerHitsSoftOn             int            `json:"dealer_hits_soft_on"`
+	// exceptions to the dealer's totals, eg stand on 10-6
+	DealerCompositions     []DealerComposition `json:"dealer_compositions,omitempty"`
+	NaturalBlackjackPayout money.Payout        `json:"natural_blackjack_payout"`
+	PayoutRounding         money.Rounding      `json:"payout_rounding"`
+	Surrender              SurrenderMode       `json:"surrender"`
+	RemoveTens             bool                `json:"remove_tens"`
+	Player21AlwaysWins     bool                `json:"player_21_always_wins"`
+	Spanish21Bonuses       bool                `json:"spanish_21_bonuses"`
+	BlackjackSwitch        bool                `json:"blackjack_switch"`
+	Dealer22Pushes         bool                `json:"dealer_22_pushes"`
+	FreeBet                bool                `json:"free_bet"`
+	DoubleExposure         bool                `json:"double_exposure"`
+	DealerWinsTies         bool                `json:"dealer_wins_ties"`
+	NoHoleCard             bool                `json:"no_hole_card"`
+	FiveCardTrick          bool                `json:"five_card_trick"`
+	FiveCardTrickPayout    money.Payout        `json:"five_card_trick_payout"`
+	FiveCard21Payout       money.Payout        `json:"five_card_21_payout"`
+	PlayerMinimumStand     int                 `json:"player_minimum_stand"`
+	PontoonTerms           bool                `json:"pontoon_terms"`
+	// eg a suited natural paid 2 to 1, see BonusHand
+	BonusHands []BonusHand `json:"bonus_hands,omitempty"`
+	// the dealer's own shuffle, instead of the Shuffler's usual one, see ShuffleStep
+	ShuffleProcedure []ShuffleStep `json:"shuffle_procedure,omitempty"`
+	BurnCards        int           `json:"burn_cards"`
+	HoleCardFlash    float64       `json:"hole_card_flash"`
+	DealerTell       float64       `json:"dealer_tell"`
+	NoMidShoeEntry   bool          `json:"no_mid_shoe_entry"`
+}
+
+func CreateHouseRules() *HouseRules {
+	var rules HouseRules = HouseRules{
+		DecksInShoe:                  DECKS_IN_SHOE,
+		ForceReshuffle:               FORCE_RESHUFFLE,
+		CutCardSpread:                CUT_CARD_SPREAD,
+		Shuffler:                     SHUFFLER,
+		ResplitAces:                  RESPLIT_ACES,
+		HitSplitAces:                 HIT_SPLIT_ACES,
+		DoubleDownAfterSplittingAces: DOUBLE_DOWN_AFTER_SPLITTING_ACES,
+		DoubleDownAfterSplit:         DOUBLE_DOWN_AFTER_SPLIT,
+		DoubleDownOn:                 DOUBLE_DOWN_ON,
+		DoubleDownSoftHands:          DOUBLE_DOWN_SOFT_HANDS,
+		DoubleDownAnyNumberOfCards:   DOUBLE_DOWN_ANY_NUMBER_OF_CARDS,
+		DoubleForLess:                DOUBLE_FOR_LESS,
+		Redoubles:                    REDOUBLES,
+		DoubleDownRescue:             DOUBLE_DOWN_RESCUE,
+		SplitsPerHand:                SPLITS_PER_HAND,
+		SplitOnValueMatch:            SPLIT_ON_VALUE_MATCH,
+		DealerHitsHardOn:             DEALER_HITS_HARD_ON,
+		DealerHitsSoftOn:             DEALER_HITS_SOFT_ON,
+		NaturalBlackjackPayout:       NATURAL_BLACKJACK_PAYOUT,
+		PayoutRounding:               PAYOUT_ROUNDING,
+		Surrender:                    SURRENDER,
+		RemoveTens:                   REMOVE_TENS,
+		Player21AlwaysWins:           PLAYER_21_ALWAYS_WINS,
+		Spanish21Bonuses:             SPANISH_21_BONUSES,
+		BlackjackSwitch:              BLACKJACK_SWITCH,
+		Dealer22Pushes:               DEALER_22_PUSHES,
+		FreeBet:                      FREE_BET,
+		DoubleExposure:               DOUBLE_EXPOSURE,
+		DealerWinsTies:               DEALER_WINS_TIES,
+		NoHoleCard:                   NO_HOLE_CARD,
+		FiveCardTrick:                FIVE_CARD_TRICK,
+		FiveCardTrickPayout:          FIVE_CARD_TRICK_PAYOUT,
+		FiveCard21Payout:             FIVE_CARD_21_PAYOUT,
+		PlayerMinimumStand:           PLAYER_MINIMUM_STAND,
+		PontoonTerms:                 PONTOON_TERMS,
+		BurnCards:                    BURN_CARDS,
+		HoleCardFlash:                HOLE_CARD_FLASH,
+		DealerTell:                   DEALER_TELL,
+		NoMidShoeEntry:               NO_MID_SHOE_ENTRY,
+	}
+	return &rules
+}
+
+// same three quarters penetration as FORCE_RESHUFFLE, for any shoe size
+func DefaultForceReshuffle(decksInShoe int) int {
+	return ((52 * decksInShoe) * 3) / 4
+}
+
+func (self *HouseRules) CardsPerDeck() int {
+	if self.RemoveTens {
+		return 48
+	}
+	return 52
+}
+
+// the same three quarters penetration, for the rules' deck
+func (self *HouseRules) DefaultForceReshuffle() int {
+	return ((self.CardsPerDeck() * self.DecksInShoe) * 3) / 4
+}
+
+func (self *HouseRules) ShoeSize() int {
+	return self.CardsPerDeck() * self.DecksInShoe
+}
+
+// Penetration() is how much of the shoe is dealt before the cut card comes out, eg 0.75
+func (self *HouseRules) Penetration() float64 {
+	return float64(self.ForceReshuffle) / float64(self.ShoeSize())
+}
+
+// ForceReshuffleAt() is where the cut card goes for the penetration, eg 0.8 => 4/5ths of the shoe
+func (self *HouseRules) ForceReshuffleAt(penetration float64) int {
+	return int(penetration * float64(self.ShoeSize()))
+}
+
+func (self *HouseRules) CanDoubleDown(total int) bool {
+	return CanDoubleDownOn(self.DoubleDownOn, total)
+}
+
+// CanDoubleDownHand() takes both counts of the hand, a soft hand can be
+// doubled down on either of its totals, eg A-8 is a 9 for Reno rules.
+func (self *HouseRules) CanDoubleDownHand(hardCount int, softCount int) bool {
+	isSoft := hardCount < softCount && softCount <= 21
+	if isSoft && !self.DoubleDownSoftHands {
+		return false
+	}
+	return self.CanDoubleDown(hardCount) || (isSoft && self.CanDoubleDown(softCount))
+}
+
+// late surrender is there in every mode but SURRENDER_NONE, an early surrender
+// mode lets a hand surrender before the check too.
+func (self *HouseRules) SurrenderAllowed() bool {
+	return self.Surrender != SURRENDER_NONE
+}
+
+// EarlySurrenderAgainst() takes the value of the dealer's top card, 1 for an Ace.
+func (self *HouseRules) EarlySurrenderAgainst(dealerTopCardValue int) bool {
+	switch self.Surrender {
+	case SURRENDER_EARLY:
+		return dealerTopCardValue == 1 || dealerTopCardValue == 10
+	case SURRENDER_EARLY_VS_ACE:
+		return dealerTopCardValue == 1
+	case SURRENDER_EARLY_VS_TEN:
+		return dealerTopCardValue == 10
+	}
+	return false
+}
+
+// StartingBets() is what a player betting bet per hand puts down,
+// the one bet, or the two hands Blackjack Switch is played with.
+func (self *HouseRules) StartingBets(bet int) []int {
+	if self.BlackjackSwitch {
+		return []int{bet, bet}
+	}
+	return []int{bet}
+}
+
+// ValidateBets() checks that the bets can be played, the table limits are
+// up to whoever takes the bets.
+func (self *HouseRules) ValidateBets(bets []int) error {
+	if len(bets) == 0 {
+		return fmt.Errorf("at least one bet is needed")
+	}
+	for i := 0; i < len(bets); i++ {
+		if bets[i] < 1 {
+			return fmt.Errorf("bets must be positive, got %v", bets[i])
+		}
+	}
+	if self.BlackjackSwitch && (len(bets) != 2 || bets[0] != bets[1]) {
+		return fmt.Errorf("blackjack switch is played with two bets of the same size, got %v", bets)
+	}
+	return nil
+}
+
+func (self *HouseRules) Validate() error {
+	if self.DecksInShoe < 1 || self.DecksInShoe > 8 {
+		return fmt.Errorf("decks in shoe must be between 1 and 8, got %v", self.DecksInShoe)
+	}
+	// leave enough cards behind the cut card to finish the round
+	if self.ForceReshuffle < 1 || self.ForceReshuffle > self.ForceReshuffleAt(MAX_PENETRATION) {
+		return fmt.Errorf("force reshuffle %v does not fit a %v deck shoe", self.ForceReshuffle, self.DecksInShoe)
+	}
+	if self.CutCardSpread < 0 || self.CutCardSpread >= self.ForceReshuffle ||
+		self.ForceReshuffle+self.CutCardSpread > self.ForceReshuffleAt(MAX_PENETRATION) {
+		return fmt.Errorf("cut card spread %v does not fit force reshuffle %v in a %v deck shoe", self.CutCardSpread, self.ForceReshuffle, self.DecksInShoe)
+	}
+	if !slices.Contains(ShufflerKinds(), self.Shuffler) {
+		return fmt.Errorf("unknown shuffler %q", self.Shuffler)
+	}
+	if len(self.ShuffleProcedure) > 0 && SHUFFLE_PROCEDURES[self.Shuffler] == nil {
+		return fmt.Errorf("the %v shuffler has no shuffle procedure", self.Shuffler)
+	}
+	for i := 0; i < len(self.ShuffleProcedure); i++ {
+		if err := self.ShuffleProcedure[i].Validate(); err != nil {
+			return fmt.Errorf("shuffle procedure step %v: %v", i+1, err)
+		}
+	}
+	if self.BurnCards < 0 || self.BurnCards > MAX_BURN_CARDS {
+		return fmt.Errorf("burn cards must be between 0 and %v, got %v", MAX_BURN_CARDS, self.BurnCards)
+	}
+	if self.HoleCardFlash < 0 || self.HoleCardFlash > 1 {
+		return fmt.Errorf("hole card flash must be between 0 and 1, got %v", self.HoleCardFlash)
+	}
+	if self.DealerTell < 0 || self.DealerTell > 1 {
+		return fmt.Errorf("dealer tell must be between 0 and 1, got %v", self.DealerTell)
+	}
+	if self.SplitsPerHand < 0 {
+		return fmt.Errorf("splits per hand can not be negative, got %v", self.SplitsPerHand)
+	}
+	if self.DealerHitsSoftOn < 16 || self.DealerHitsSoftOn > 18 {
+		return fmt.Errorf("dealer hits soft on must be between 16 and 18, got %v", self.DealerHitsSoftOn)
+	}
+	if self.DealerHitsHardOn < 15 || self.DealerHitsHardOn > 17 {
+		return fmt.Errorf("dealer hits hard on must be between 15 and 17, got %v", self.DealerHitsHardOn)
+	}
+	if self.NaturalBlackjackPayout.Ratio() < 1 || self.NaturalBlackjackPayout.Ratio() > 2 {
+		return fmt.Errorf("natural blackjack payout must be between 1:1 and 2:1, got %v", self.NaturalBlackjackPayout)
+	}
+	if !self.PayoutRounding.IsValid() {
+		return fmt.Errorf("unknown payout rounding %q", self.PayoutRounding)
+	}
+	if self.FiveCardTrick && (self.FiveCardTrickPayout.Ratio() < 1 || self.FiveCardTrickPayout.Ratio() > 3) {
+		return fmt.Errorf("five card trick payout must be between 1:1 and 3:1, got %v", self.FiveCardTrickPayout)
+	}
+	if !self.FiveCard21Payout.IsZero() && (self.FiveCard21Payout.Ratio() < 1 || self.FiveCard21Payout.Ratio() > 3) {
+		return fmt.Errorf("five card 21 payout must be 0 or between 1:1 and 3:1, got %v", self.FiveCard21Payout)
+	}
+	if self.PlayerMinimumStand < 0 || self.PlayerMinimumStand > 21 {
+		return fmt.Errorf("player minimum stand must be between 0 and 21, got %v", self.PlayerMinimumStand)
+	}
+	for i := 0; i < len(self.BonusHands); i++ {
+		if err := self.BonusHands[i].Validate(); err != nil {
+			return err
+		}
+	}
+	if self.FiveCardTrick && self.CharlieCards() > 0 {
+		return fmt.Errorf("a five card trick and a charlie can not both end a hand")
+	}
+	if self.NoHoleCard && self.DoubleExposure {
+		return fmt.Errorf("double exposure needs a hole card to expose")
+	}
+	if self.Redoubles < 0 || self.Redoubles > 3 {
+		return fmt.Errorf("redoubles must be between 0 and 3, got %v", self.Redoubles)
+	}
+	for i := 0; i < len(self.DealerCompositions); i++ {
+		var values []int = self.DealerCompositions[i].Values
+		if len(values) < 2 {
+			return fmt.Errorf("a dealer composition needs at least two cards, got %v", values)
+		}
+		for j := 0; j < len(values); j++ {
+			if values[j] < 1 || values[j] > 10 {
+				return fmt.Errorf("dealer composition card values must be between 1 and 10, got %v", values)
+			}
+		}
+	}
+	_, ok := double_down_on_totals[self.DoubleDownOn]
+	if !ok {
+		return fmt.Errorf("unknown double down on %q", self.DoubleDownOn)
+	}
+	switch self.Surrender {
+	case SURRENDER_NONE, SURRENDER_LATE, SURRENDER_EARLY, SURRENDER_EARLY_VS_ACE, SURRENDER_EARLY_VS_TEN:
+	default:
+		return fmt.Errorf("unknown surrender %q", self.Surrender)
+	}
+	return nil
+}
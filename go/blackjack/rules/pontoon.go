@@ -0,0 +1,28 @@
+package rules
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
+// Pontoon is the British cousin of blackjack: a natural is a pontoon and
+// pays 3 to 2, five cards without busting is a five card trick and pays
+// 2 to 1, beating anything but the dealer's pontoon.  The player has to
+// twist (hit) to at least 15 before they can stick (stand), can buy (double
+// down) on any number of cards, and the dealer wins ties.
+func CreatePontoonRules() *HouseRules {
+	var rules *HouseRules = CreateHouseRules()
+	rules.DecksInShoe = 6
+	rules.ForceReshuffle = rules.DefaultForceReshuffle()
+	rules.NaturalBlackjackPayout = money.PAYS_3_TO_2
+	rules.FiveCardTrick = true
+	rules.FiveCardTrickPayout = money.PAYS_2_TO_1
+	rules.PlayerMinimumStand = 15
+	rules.DealerWinsTies = true
+	rules.PontoonTerms = true
+	rules.DealerHitsSoftOn = 17
+	rules.Surrender = SURRENDER_NONE
+	rules.DoubleDownOn = DOUBLE_DOWN_ANY_TWO
+	rules.DoubleDownAnyNumberOfCards = true
+	rules.DoubleDownAfterSplit = true
+	return rules
+}
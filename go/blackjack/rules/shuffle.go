@@ -0,0 +1,89 @@
+package rules
+
+import "fmt"
+
+// A dealer's shuffle is a procedure, a few steps done the same way every
+// time, eg pick up a half deck from each half of the discards, riffle them
+// together, strip them, riffle them again.  A hand shuffle is far from
+// perfect, cards that were together in the discards tend to stay together,
+// which is what shuffle trackers count on.  See cards.ProcedureShuffler.
+
+type ShuffleAction string
+
+const (
+	// a Gilbert-Shannon-Reeds riffle: cut about in half, then the cards drop
+	// from each half in turn, more likely from the bigger one
+	RIFFLE ShuffleAction = "riffle"
+	// packets taken off the top one after the other, which reverses their order
+	STRIP ShuffleAction = "strip"
+	// cut about in the middle
+	CUT ShuffleAction = "cut"
+	// the cards spread face down on the table and mixed about, aka a chemmy
+	WASH ShuffleAction = "wash"
+)
+
+type ShuffleStep struct {
+	Action ShuffleAction `json:"action"`
+	// 0 => once
+	Times int `json:"times,omitempty"`
+	// the cards are split into this many zones one on top of the other,
+	// every zone is shuffled on its own and stays where it was
+	Zones int `json:"zones,omitempty"`
+	// pick up this many cards, half from the top half and half from the
+	// bottom half, shuffle them and put them on the finished pile, until
+	// the cards run out, 0 => the step is done on all the cards at once
+	Grab int `json:"grab,omitempty"`
+}
+
+func (self ShuffleStep) Validate() error {
+	switch self.Action {
+	case RIFFLE, STRIP, CUT, WASH:
+	default:
+		return fmt.Errorf("unknown shuffle action %q", self.Action)
+	}
+	if self.Times < 0 || self.Times > 10 {
+		return fmt.Errorf("a %v is done 1 thru 10 times, got %v", self.Action, self.Times)
+	}
+	if self.Zones < 0 || self.Grab < 0 {
+		return fmt.Errorf("zones and grab can not be negative, got %v and %v", self.Zones, self.Grab)
+	}
+	if self.Zones > 0 && self.Grab > 0 {
+		return fmt.Errorf("a %v is done on zones or on grabs, not both", self.Action)
+	}
+	return nil
+}
+
+// TimesDone() is the number of times the action is done
+func (self ShuffleStep) TimesDone() int {
+	return max(self.Times, 1)
+}
+
+// the usual hand shuffles, by shuffler
+var SHUFFLE_PROCEDURES map[ShufflerKind][]ShuffleStep = map[ShufflerKind][]ShuffleStep{
+	// seven riffles are enough to mix a deck, Bayer and Diaconis
+	SHUFFLE_RIFFLE: {
+		{Action: RIFFLE, Times: 7},
+		{Action: CUT},
+	},
+	// every zone is shuffled on its own, the cards never leave their zone
+	SHUFFLE_ZONE: {
+		{Action: RIFFLE, Times: 2, Zones: 4},
+		{Action: STRIP, Zones: 4},
+		{Action: CUT},
+	},
+	// riffle, strip, riffle a deck's worth of cards at a time, then cut
+	SHUFFLE_CASINO: {
+		{Action: RIFFLE, Grab: 52},
+		{Action: STRIP, Grab: 52},
+		{Action: RIFFLE, Grab: 52},
+		{Action: CUT},
+	},
+}
+
+// ShuffleSteps() is the dealer's shuffle, nil => the cards are not shuffled by hand
+func (self *HouseRules) ShuffleSteps() []ShuffleStep {
+	if len(self.ShuffleProcedure) > 0 {
+		return self.ShuffleProcedure
+	}
+	return SHUFFLE_PROCEDURES[self.Shuffler]
+}
@@ -0,0 +1,41 @@
+package rules
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
+// Spanish 21 is blackjack with the 10s taken out of the deck, which is
+// made up for by rules that favor the player: a player 21 always wins,
+// bonuses for some 21s, late surrender, doubling down on any number of
+// cards, re-doubling and double down rescue.
+
+// the bonuses are paid on a winning 21, instead of even money,
+// and are not paid on a hand that was doubled down on
+var SPANISH_21_FIVE_CARD_21_PAYOUT money.Payout = money.PAYS_3_TO_2
+var SPANISH_21_SIX_CARD_21_PAYOUT money.Payout = money.PAYS_2_TO_1
+
+// seven or more cards
+var SPANISH_21_SEVEN_CARD_21_PAYOUT money.Payout = money.PaysToOne(3)
+
+// 6-7-8 or 7-7-7, in three cards
+var SPANISH_21_MIXED_SUITS_PAYOUT money.Payout = money.PAYS_3_TO_2
+var SPANISH_21_SUITED_PAYOUT money.Payout = money.PAYS_2_TO_1
+var SPANISH_21_SPADES_PAYOUT money.Payout = money.PaysToOne(3)
+
+func CreateSpanish21Rules() *HouseRules {
+	var rules *HouseRules = CreateHouseRules()
+	rules.RemoveTens = true
+	rules.ForceReshuffle = rules.DefaultForceReshuffle()
+	rules.Player21AlwaysWins = true
+	rules.Spanish21Bonuses = true
+	rules.Surrender = SURRENDER_LATE
+	rules.DoubleDownOn = DOUBLE_DOWN_ANY_TWO
+	rules.DoubleDownAnyNumberOfCards = true
+	rules.DoubleDownAfterSplit = true
+	rules.Redoubles = 2
+	rules.DoubleDownRescue = true
+	rules.ResplitAces = true
+	rules.HitSplitAces = true
+	rules.DoubleDownAfterSplittingAces = true
+	return rules
+}
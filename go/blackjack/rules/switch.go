@@ -0,0 +1,22 @@
+package rules
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
+// Blackjack Switch deals every player two hands, after which the player may
+// switch the second cards between them, eg turning 10-6 and 5-10 into 10-10
+// and 5-6.  A natural pays even money, a dealer 22 pushes all but a natural.
+func CreateBlackjackSwitchRules() *HouseRules {
+	var rules *HouseRules = CreateHouseRules()
+	rules.DecksInShoe = 6
+	rules.ForceReshuffle = rules.DefaultForceReshuffle()
+	rules.BlackjackSwitch = true
+	rules.Dealer22Pushes = true
+	rules.NaturalBlackjackPayout = money.EVEN_MONEY
+	rules.DealerHitsSoftOn = 17
+	rules.Surrender = SURRENDER_NONE
+	rules.DoubleDownOn = DOUBLE_DOWN_ANY_TWO
+	rules.DoubleDownAfterSplit = true
+	return rules
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandTotals(t *testing.T) {
+	hardTotal, softTotal := house_rules.HandTotals([]int{1, 6})
+	assert.Equal(t, []int{7, 17}, []int{hardTotal, softTotal}, "A-6 is soft 17")
+	hardTotal, softTotal = house_rules.HandTotals([]int{1, 4, 6})
+	assert.Equal(t, []int{11, 21}, []int{hardTotal, softTotal}, "A-4-6 is soft 21")
+	hardTotal, softTotal = house_rules.HandTotals([]int{1, 1, 10})
+	assert.Equal(t, []int{12, 12}, []int{hardTotal, softTotal}, "A-A-10 has no Ace to count as 11")
+	hardTotal, softTotal = house_rules.HandTotals([]int{10, 6})
+	assert.Equal(t, []int{16, 16}, []int{hardTotal, softTotal}, "10-6 is hard 16")
+}
+
+func TestDealerPolicies(t *testing.T) {
+	var s17 house_rules.DealerPolicy = house_rules.CreateS17Policy()
+	var h17 house_rules.DealerPolicy = house_rules.CreateH17Policy()
+	var hitSoft18 house_rules.DealerPolicy = house_rules.CreateHitSoft18Policy()
+	assert.Equal(t, "S17", s17.Name())
+	assert.Equal(t, "H17", h17.Name())
+	assert.Equal(t, "H soft 18", hitSoft18.Name())
+
+	cases := []struct {
+		name       string
+		cardValues []int
+		// S17, H17, H soft 18
+		hits [3]bool
+	}{
+		{"hard 16", []int{10, 6}, [3]bool{true, true, true}},
+		{"hard 17", []int{10, 7}, [3]bool{false, false, false}},
+		{"soft 16", []int{1, 5}, [3]bool{true, true, true}},
+		{"soft 17", []int{1, 6}, [3]bool{false, true, true}},
+		{"three card soft 17", []int{1, 2, 4}, [3]bool{false, true, true}},
+		{"soft 18", []int{1, 7}, [3]bool{false, false, true}},
+		{"soft 19", []int{1, 8}, [3]bool{false, false, false}},
+		{"soft 21", []int{1, 4, 6}, [3]bool{false, false, false}},
+		{"hard 12 with aces", []int{1, 1, 10}, [3]bool{true, true, true}},
+	}
+	var policies [3]house_rules.DealerPolicy = [3]house_rules.DealerPolicy{s17, h17, hitSoft18}
+	for i := 0; i < len(cases); i++ {
+		for j := 0; j < len(policies); j++ {
+			assert.Equal(t, cases[i].hits[j], policies[j].Hits(cases[i].cardValues), "%v %v", policies[j].Name(), cases[i].name)
+		}
+	}
+}
+
+func TestCompositionPolicy(t *testing.T) {
+	// S17, but stand on 10-6 and a five card 16, and hit 10-7
+	var policy house_rules.DealerPolicy = house_rules.CreateCompositionPolicy(
+		house_rules.CreateS17Policy(),
+		[]house_rules.DealerComposition{
+			{Values: []int{10, 6}, Hits: false},
+			{Values: []int{2, 3, 4, 2, 5}, Hits: false},
+			{Values: []int{7, 10}, Hits: true},
+		},
+	)
+	assert.False(t, policy.Hits([]int{10, 6}), "stands on 10-6")
+	assert.False(t, policy.Hits([]int{6, 10}), "in any order")
+	assert.True(t, policy.Hits([]int{9, 7}), "hits 9-7")
+	assert.False(t, policy.Hits([]int{2, 2, 3, 4, 5}), "stands on the five card 16")
+	assert.True(t, policy.Hits([]int{10, 7}), "hits 10-7")
+	assert.False(t, policy.Hits([]int{10, 4, 3}), "stands on any other 17")
+
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	assert.IsType(t, &house_rules.TotalsPolicy{}, rules.DealerPolicy(), "no compositions, just the totals")
+	rules.DealerCompositions = []house_rules.DealerComposition{{Values: []int{10, 6}, Hits: false}}
+	assert.NoError(t, rules.Validate())
+	assert.False(t, rules.DealerPolicy().Hits([]int{10, 6}), "the house rules stand on 10-6")
+	rules.DealerCompositions = []house_rules.DealerComposition{{Values: []int{16}, Hits: false}}
+	assert.Error(t, rules.Validate(), "a single card is not a hand")
+	rules.DealerCompositions = []house_rules.DealerComposition{{Values: []int{11, 5}, Hits: false}}
+	assert.Error(t, rules.Validate(), "an Ace is a 1")
+}
+
+func TestSpanish21Rules(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateSpanish21Rules()
+	assert.NoError(t, rules.Validate())
+	assert.Equal(t, 48, rules.CardsPerDeck())
+	assert.Equal(t, (48*rules.DecksInShoe*3)/4, rules.ForceReshuffle, "three quarters of the Spanish shoe")
+
+	rules.ForceReshuffle = house_rules.DefaultForceReshuffle(rules.DecksInShoe) + 48
+	assert.Error(t, rules.Validate(), "a 52 card deck reshuffle point is too deep for a 48 card deck shoe")
+
+	rules = house_rules.CreateSpanish21Rules()
+	rules.Redoubles = 4
+	assert.Error(t, rules.Validate(), "at most 3 re-doubles")
+}
+
+func TestBlackjackSwitchRules(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateBlackjackSwitchRules()
+	assert.NoError(t, rules.Validate())
+	assert.Equal(t, money.EVEN_MONEY, rules.NaturalBlackjackPayout, "naturals pay even money")
+	assert.True(t, rules.Dealer22Pushes)
+
+	assert.Equal(t, []int{5, 5}, rules.StartingBets(5), "two hands")
+	assert.NoError(t, rules.ValidateBets([]int{5, 5}))
+	assert.Error(t, rules.ValidateBets([]int{5}), "two hands are needed")
+	assert.Error(t, rules.ValidateBets([]int{5, 10}), "of the same bet")
+	assert.Error(t, rules.ValidateBets([]int{5, 5, 5}), "no more than two")
+
+	rules = house_rules.CreateHouseRules()
+	assert.Equal(t, []int{5}, rules.StartingBets(5), "one hand")
+	assert.NoError(t, rules.ValidateBets([]int{5, 10, 20}))
+	assert.Error(t, rules.ValidateBets([]int{}), "a bet is needed")
+	assert.Error(t, rules.ValidateBets([]int{5, 0}), "bets are positive")
+}
+
+func TestFreeBetRules(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateFreeBetRules()
+	assert.NoError(t, rules.Validate())
+	assert.True(t, rules.Dealer22Pushes)
+
+	assert.True(t, rules.FreeDouble(2, 9, 9), "hard 9")
+	assert.True(t, rules.FreeDouble(2, 11, 11), "hard 11")
+	assert.False(t, rules.FreeDouble(2, 8, 8), "hard 8")
+	assert.False(t, rules.FreeDouble(2, 9, 19), "A-8 is soft")
+	assert.False(t, rules.FreeDouble(3, 10, 10), "two cards only")
+	assert.True(t, rules.FreeSplit(1), "Aces")
+	assert.True(t, rules.FreeSplit(8), "8s")
+	assert.False(t, rules.FreeSplit(10), "not 10s")
+
+	rules = house_rules.CreateHouseRules()
+	assert.False(t, rules.FreeDouble(2, 11, 11), "nothing is free")
+	assert.False(t, rules.FreeSplit(8), "nothing is free")
+}
+
+func TestDoubleExposureRules(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateDoubleExposureRules()
+	assert.NoError(t, rules.Validate())
+	assert.True(t, rules.DoubleExposure)
+	assert.True(t, rules.DealerWinsTies)
+	assert.Equal(t, money.EVEN_MONEY, rules.NaturalBlackjackPayout, "naturals pay even money")
+	assert.False(t, rules.CanDoubleDownHand(8, 8), "no doubling 8")
+	assert.True(t, rules.CanDoubleDownHand(11, 11), "double 11")
+	assert.False(t, rules.CanDoubleDownHand(9, 19), "no doubling soft hands")
+}
+
+func TestPontoonRules(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreatePontoonRules()
+	assert.NoError(t, rules.Validate())
+	assert.True(t, rules.FiveCardTrick)
+	assert.Equal(t, money.PAYS_2_TO_1, rules.FiveCardTrickPayout, "a five card trick pays 2 to 1")
+	assert.Equal(t, 15, rules.PlayerMinimumStand)
+	assert.True(t, rules.DealerWinsTies)
+	assert.True(t, rules.PontoonTerms)
+
+	rules.FiveCardTrickPayout = money.Payout{}
+	assert.Error(t, rules.Validate(), "a five card trick pays something")
+	rules = house_rules.CreatePontoonRules()
+	rules.PlayerMinimumStand = 22
+	assert.Error(t, rules.Validate(), "no hand could ever stand")
+}
+
+func TestAustralianRules(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateAustralianRules()
+	assert.NoError(t, rules.Validate())
+	assert.True(t, rules.NoHoleCard)
+	assert.Equal(t, money.Payout{}, rules.FiveCard21Payout)
+	assert.Equal(t, "S17", rules.DealerPolicy().Name(), "the dealer stands on all 17s")
+
+	rules = house_rules.CreateAustralianFiveCard21Rules()
+	assert.NoError(t, rules.Validate())
+	assert.True(t, rules.NoHoleCard)
+	assert.Equal(t, money.PAYS_3_TO_2, rules.FiveCard21Payout, "a five card 21 pays 3 to 2")
+
+	rules.DoubleExposure = true
+	assert.Error(t, rules.Validate(), "no hole card to expose")
+}
+
+func TestBonusHandRules(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	assert.Equal(t, 0, rules.CharlieCards(), "no Charlie by default")
+	rules.BonusHands = []house_rules.BonusHand{house_rules.SUITED_BLACKJACK, house_rules.SEVENS_BONUS, house_rules.CreateCharlie(6), house_rules.CreateCharlie(7)}
+	rules.BonusHands[3].Payout = money.PAYS_2_TO_1
+	assert.NoError(t, rules.Validate())
+	assert.Equal(t, 6, rules.CharlieCards())
+	assert.Equal(t, money.Payout{}, rules.CharliePayout(5))
+	assert.Equal(t, money.EVEN_MONEY, rules.CharliePayout(6))
+	assert.Equal(t, money.PAYS_2_TO_1, rules.CharliePayout(7), "the better Charlie")
+	assert.Equal(t, "suited blackjack", house_rules.SUITED_BLACKJACK.String())
+
+	badHands := []house_rules.BonusHand{
+		house_rules.CreateCharlie(4),
+		house_rules.CreateCharlie(8),
+		{Hand: house_rules.BONUS_CHARLIE, Cards: 5, Suits: house_rules.SUITED, Payout: money.EVEN_MONEY},
+		{Hand: house_rules.BONUS_777, Cards: 3, Payout: money.EVEN_MONEY},
+		{Hand: house_rules.BONUS_777, Suits: "spades", Payout: money.EVEN_MONEY},
+		{Hand: "royal", Payout: money.EVEN_MONEY},
+		{Hand: house_rules.BONUS_BLACKJACK, Payout: money.Payout{}},
+		{Hand: house_rules.BONUS_BLACKJACK, Payout: money.PaysToOne(11)},
+	}
+	for i := 0; i < len(badHands); i++ {
+		assert.Error(t, badHands[i].Validate(), "%+v", badHands[i])
+	}
+
+	rules = house_rules.CreatePontoonRules()
+	rules.BonusHands = []house_rules.BonusHand{house_rules.CreateCharlie(5)}
+	assert.Error(t, rules.Validate(), "a five card trick or a Charlie, not both")
+
+	rules = house_rules.CreateHouseRules()
+	assert.NoError(t, json.Unmarshal([]byte(`{"bonus_hands": [{"hand": "blackjack", "suits": "suited", "payout": "2:1"}, {"hand": "charlie", "cards": 6, "payout": "1:1"}]}`), rules))
+	assert.Equal(t, []house_rules.BonusHand{house_rules.SUITED_BLACKJACK, house_rules.CreateCharlie(6)}, rules.BonusHands)
+	assert.NoError(t, rules.Validate())
+}
+
+func TestCutCardRules(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	assert.Equal(t, 312, rules.ShoeSize())
+	assert.Equal(t, 0.75, rules.Penetration(), "three quarters of the shoe")
+	rules.ForceReshuffle = rules.ForceReshuffleAt(0.85)
+	assert.Equal(t, 265, rules.ForceReshuffle)
+	assert.NoError(t, rules.Validate(), "deeper than the default")
+	rules.CutCardSpread = 20
+	assert.Error(t, rules.Validate(), "the cut card could go past 90%")
+	rules.ForceReshuffle = rules.DefaultForceReshuffle()
+	assert.NoError(t, rules.Validate())
+	rules.ForceReshuffle = 10
+	assert.Error(t, rules.Validate(), "the cut card could go in before the first card")
+	rules.ForceReshuffle = rules.ForceReshuffleAt(0.95)
+	rules.CutCardSpread = 0
+	assert.Error(t, rules.Validate(), "too deep")
+
+	rules = house_rules.CreateHouseRules()
+	rules.Shuffler = house_rules.SHUFFLE_CSM
+	assert.NoError(t, rules.Validate())
+	rules.Shuffler = "dealer-choice"
+	assert.Error(t, rules.Validate())
+
+	rules = house_rules.CreateHouseRules()
+	assert.Nil(t, rules.ShuffleSteps(), "a perfect shuffle")
+	assert.NoError(t, json.Unmarshal([]byte(`{"shuffler": "zone", "shuffle_procedure": [{"action": "riffle", "times": 3, "zones": 6}, {"action": "cut"}]}`), rules))
+	assert.NoError(t, rules.Validate())
+	assert.Equal(t, []house_rules.ShuffleStep{{Action: house_rules.RIFFLE, Times: 3, Zones: 6}, {Action: house_rules.CUT}}, rules.ShuffleSteps())
+	rules.ShuffleProcedure[0].Grab = 52
+	assert.Error(t, rules.Validate(), "zones or grabs")
+	rules.ShuffleProcedure[0] = house_rules.ShuffleStep{Action: "overhand"}
+	assert.Error(t, rules.Validate())
+	rules.ShuffleProcedure[0] = house_rules.ShuffleStep{Action: house_rules.STRIP, Times: 11}
+	assert.Error(t, rules.Validate())
+	rules.ShuffleProcedure[0] = house_rules.ShuffleStep{Action: house_rules.STRIP}
+	rules.Shuffler = house_rules.SHUFFLE_CSM
+	assert.Error(t, rules.Validate(), "a machine has no shuffle procedure")
+
+	rules = house_rules.CreateHouseRules()
+	assert.Equal(t, 0, rules.BurnCards)
+	rules.BurnCards = 1
+	assert.NoError(t, rules.Validate())
+	rules.BurnCards = house_rules.MAX_BURN_CARDS + 1
+	assert.Error(t, rules.Validate())
+	rules.BurnCards = -1
+	assert.Error(t, rules.Validate())
+}
+
+func TestHoleCardExposureRules(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	assert.Equal(t, 0.0, rules.HoleCardFlash, "a careful dealer")
+	assert.Equal(t, 0.0, rules.DealerTell)
+	rules.HoleCardFlash = 0.05
+	rules.DealerTell = 1
+	assert.NoError(t, rules.Validate())
+	rules.HoleCardFlash = 1.5
+	assert.Error(t, rules.Validate(), "more than always")
+	rules.HoleCardFlash = 0
+	rules.DealerTell = -0.1
+	assert.Error(t, rules.Validate())
+}
+
+func TestGameRules(t *testing.T) {
+	for _, name := range house_rules.GameNames() {
+		rules, err := house_rules.CreateGameRules(name)
+		assert.NoError(t, err, name)
+		assert.NoError(t, rules.Validate(), name)
+	}
+	rules, err := house_rules.CreateGameRules("")
+	assert.NoError(t, err, "blackjack by default")
+	assert.Equal(t, house_rules.CreateHouseRules(), rules)
+
+	_, err = house_rules.CreateGameRules("baccarat")
+	assert.Error(t, err)
+}
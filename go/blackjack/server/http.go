@@ -0,0 +1,180 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/sidebets"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
+)
+
+// Endpoints:
+//     POST   /jobs              submit a simulation job, returns its progress
+//     GET    /jobs              progress of every job
+//     GET    /jobs/{id}         progress of one job
+//     DELETE /jobs/{id}         cancel a job
+//     GET    /jobs/{id}/results results and stats of a completed or cancelled job
+//     DELETE /jobs/{id}/results forget a completed or cancelled job and its results
+//     GET    /strategies        player strategies a job can ask for
+//     GET    /games             the house rules of every game, by name, for a job's rules
+//     GET    /sidebets          the pay table of every side bet a job can make
+
+type Server struct {
+	Jobs *JobManager
+	mux  *http.ServeMux
+
+	tablesMutex sync.Mutex
+	tables      map[string]*Table
+}
+
+func CreateServer(jobs *JobManager) *Server {
+	var server Server = Server{
+		Jobs:   jobs,
+		mux:    http.NewServeMux(),
+		tables: make(map[string]*Table),
+	}
+	server.mux.HandleFunc("POST /jobs", server.submitJob)
+	server.mux.HandleFunc("GET /jobs", server.listJobs)
+	server.mux.HandleFunc("GET /jobs/{id}", server.getJob)
+	server.mux.HandleFunc("DELETE /jobs/{id}", server.cancelJob)
+	server.mux.HandleFunc("GET /jobs/{id}/results", server.getJobResults)
+	server.mux.HandleFunc("DELETE /jobs/{id}/results", server.deleteJob)
+	server.mux.HandleFunc("GET /strategies", server.listStrategies)
+	server.mux.HandleFunc("GET /games", server.listGames)
+	server.mux.HandleFunc("GET /sidebets", server.listSideBets)
+	server.mux.HandleFunc("GET /tables", server.listTables)
+	server.mux.HandleFunc("GET /tables/{id}/ws", server.joinTable)
+	return &server
+}
+
+func (self *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	self.mux.ServeHTTP(w, r)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func (self *Server) submitJob(w http.ResponseWriter, r *http.Request) {
+	var request *JobRequest = CreateJobRequest()
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	job, err := self.Jobs.Submit(request)
+	if errors.Is(err, ErrQueueFull) || errors.Is(err, ErrShutdown) {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job.Progress())
+}
+
+func (self *Server) listJobs(w http.ResponseWriter, r *http.Request) {
+	var jobs []*Job = self.Jobs.List()
+	var progress []JobProgress = make([]JobProgress, 0, len(jobs))
+	for i := 0; i < len(jobs); i++ {
+		progress = append(progress, jobs[i].Progress())
+	}
+	writeJSON(w, http.StatusOK, progress)
+}
+
+func (self *Server) lookupJob(w http.ResponseWriter, r *http.Request) (*Job, bool) {
+	job, ok := self.Jobs.Get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, ErrJobNotFound)
+	}
+	return job, ok
+}
+
+func (self *Server) getJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := self.lookupJob(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, job.Progress())
+}
+
+func (self *Server) cancelJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := self.lookupJob(w, r)
+	if !ok {
+		return
+	}
+	if !job.Cancel() {
+		writeError(w, http.StatusConflict, errors.New("job has already finished"))
+		return
+	}
+	writeJSON(w, http.StatusAccepted, job.Progress())
+}
+
+func (self *Server) getJobResults(w http.ResponseWriter, r *http.Request) {
+	job, ok := self.lookupJob(w, r)
+	if !ok {
+		return
+	}
+	var results *JobResults = job.Results()
+	if err := job.Err(); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	} else if results == nil {
+		writeError(w, http.StatusConflict, errors.New("job is still "+string(job.Status())))
+		return
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (self *Server) deleteJob(w http.ResponseWriter, r *http.Request) {
+	err := self.Jobs.Delete(r.PathValue("id"))
+	if errors.Is(err, ErrJobNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (self *Server) listStrategies(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, strategy.PlayerStrategyNames())
+}
+
+func (self *Server) listGames(w http.ResponseWriter, r *http.Request) {
+	var games map[string]*house_rules.HouseRules = map[string]*house_rules.HouseRules{}
+	var names []string = house_rules.GameNames()
+	for i := 0; i < len(names); i++ {
+		// only known names
+		games[names[i]], _ = house_rules.CreateGameRules(names[i])
+	}
+	writeJSON(w, http.StatusOK, games)
+}
+
+func (self *Server) listSideBets(w http.ResponseWriter, r *http.Request) {
+	var payTables map[string]sidebets.PayTable = map[string]sidebets.PayTable{}
+	var names []string = sidebets.SideBetNames()
+	for i := 0; i < len(names); i++ {
+		sideBet, _ := sidebets.CreateSideBet(names[i])
+		payTables[names[i]] = sideBet.PayTable()
+	}
+	writeJSON(w, http.StatusOK, payTables)
+}
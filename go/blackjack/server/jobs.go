@@ -0,0 +1,572 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/counting"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/sidebets"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
+)
+
+// a simulation job runs a number of blackjack games (aka rounds)
+// on a table of its own, with its own rules, players and seed.
+
+type JobStatus string
+
+const (
+	JOB_QUEUED    JobStatus = "queued"
+	JOB_RUNNING   JobStatus = "running"
+	JOB_COMPLETED JobStatus = "completed"
+	JOB_CANCELLED JobStatus = "cancelled"
+	// the simulation panicked, see JobProgress.Error
+	JOB_FAILED JobStatus = "failed"
+)
+
+// keep a runaway request from tying up a worker for days
+const MAX_ROUNDS_PER_JOB int = 100_000_000
+
+// the finished jobs a JobManager keeps the results of, the ones that
+// finished first are forgotten first, see JobManager.Retention
+const FINISHED_JOBS_KEPT int = 100
+
+type PlayerConfig struct {
+	Name     string `json:"name"`
+	Strategy string `json:"strategy"`
+	Bets     []int  `json:"bets"`
+	// side bet name => the amount bet on each master hand
+	SideBets map[string]int `json:"side_bets,omitempty"`
+	// "" => the same bets every round, otherwise one of counting.BettingNames(),
+	// Bets are then the bets of one unit
+	Betting string `json:"betting,omitempty"`
+	// "" => plays every round, otherwise one of counting.WongingNames(), a
+	// betting is needed to know the count, nil => counting.WONG_IN_COUNT and counting.WONG_OUT_COUNT
+	Wonging string   `json:"wonging,omitempty"`
+	WongIn  *float64 `json:"wong_in,omitempty"`
+	WongOut *float64 `json:"wong_out,omitempty"`
+}
+
+// countingStrategy() is the player's betting and wonging around playStrategy.
+func (self *PlayerConfig) countingStrategy(playStrategy strategy.PlayerStrategy, rules *house_rules.HouseRules) (*counting.CountingStrategy, error) {
+	countingStrategy, err := counting.CreateCountingStrategy(self.Betting, self.Name, self.Bets, playStrategy, rules)
+	if err != nil {
+		return nil, err
+	}
+	if self.Wonging == "" {
+		return countingStrategy, nil
+	}
+	wongIn := counting.WONG_IN_COUNT
+	if self.WongIn != nil {
+		wongIn = *self.WongIn
+	}
+	wongOut := counting.WONG_OUT_COUNT
+	if self.WongOut != nil {
+		wongOut = *self.WongOut
+	}
+	if err := countingStrategy.SetWonging(self.Wonging, wongIn, wongOut); err != nil {
+		return nil, fmt.Errorf("player %q: %v", self.Name, err)
+	}
+	return countingStrategy, nil
+}
+
+type JobRequest struct {
+	Rules   *house_rules.HouseRules `json:"rules"`
+	Players []PlayerConfig          `json:"players"`
+	Rounds  int                     `json:"rounds"`
+	Seed    int64                   `json:"seed"`
+	// the share of the shoe dealt before the cut card, eg 0.8, instead of
+	// the rules' force_reshuffle, 0 => the rules decide
+	Penetration float64 `json:"penetration,omitempty"`
+}
+
+// CreateJobRequest() starts a request from the default house rules,
+// so that a client only has to send the rules it wants changed.
+func CreateJobRequest() *JobRequest {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	// zero => derived from the number of decks, see Validate()
+	rules.ForceReshuffle = 0
+
+	var request JobRequest = JobRequest{
+		Rules:       rules,
+		Players:     []PlayerConfig{},
+		Rounds:      0,
+		Seed:        0,
+		Penetration: 0,
+	}
+	return &request
+}
+
+// tableRules() is a copy of the request's rules with the cut card put where
+// the penetration says, the request's own rules are left as they were sent.
+func (self *JobRequest) tableRules() *house_rules.HouseRules {
+	var rules house_rules.HouseRules = *self.Rules
+	if self.Penetration > 0 {
+		rules.ForceReshuffle = rules.ForceReshuffleAt(self.Penetration)
+	}
+	if rules.ForceReshuffle == 0 {
+		rules.ForceReshuffle = rules.DefaultForceReshuffle()
+	}
+	return &rules
+}
+
+// Validate() checks the request against the rules the table would play by,
+// see tableRules(), without changing the request.
+func (self *JobRequest) Validate() error {
+	if self.Rules == nil {
+		return errors.New("rules are required")
+	}
+	if self.Penetration < 0 || self.Penetration > house_rules.MAX_PENETRATION {
+		return fmt.Errorf("penetration must be between 0 and %v, got %v", house_rules.MAX_PENETRATION, self.Penetration)
+	}
+	var rules *house_rules.HouseRules = self.tableRules()
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+
+	if self.Rounds < 1 || self.Rounds > MAX_ROUNDS_PER_JOB {
+		return fmt.Errorf("rounds must be between 1 and %v, got %v", MAX_ROUNDS_PER_JOB, self.Rounds)
+	}
+
+	if len(self.Players) == 0 {
+		return errors.New("at least one player is required")
+	}
+	masterHands := 0
+	names := map[string]bool{}
+	for i := 0; i < len(self.Players); i++ {
+		var player PlayerConfig = self.Players[i]
+		if player.Name == "" {
+			return fmt.Errorf("player %v needs a name", i+1)
+		}
+		if names[player.Name] {
+			return fmt.Errorf("player name %q is used more than once", player.Name)
+		}
+		names[player.Name] = true
+		if len(player.Bets) == 0 {
+			return fmt.Errorf("player %q needs at least one bet", player.Name)
+		}
+		for j := 0; j < len(player.Bets); j++ {
+			if player.Bets[j] < 1 {
+				return fmt.Errorf("player %q bets must be positive, got %v", player.Name, player.Bets[j])
+			}
+		}
+		if err := rules.ValidateBets(player.Bets); err != nil {
+			return fmt.Errorf("player %q: %v", player.Name, err)
+		}
+		masterHands += len(player.Bets)
+		playerStrategy, err := strategy.CreatePlayerStrategy(player.Strategy, rules)
+		if err != nil {
+			return err
+		}
+		if player.Betting != "" {
+			if _, err := player.countingStrategy(playerStrategy, rules); err != nil {
+				return err
+			}
+		} else if player.Wonging != "" {
+			return fmt.Errorf("player %q needs a betting to wong, the count comes with it", player.Name)
+		}
+		for name, amount := range player.SideBets {
+			if _, err := sidebets.CreateSideBet(name); err != nil {
+				return err
+			}
+			if amount < 1 {
+				return fmt.Errorf("player %q side bets must be positive, got %v on %v", player.Name, amount, name)
+			}
+		}
+	}
+	// seven spots at a table
+	if masterHands > 7 {
+		return fmt.Errorf("a table has seven betting spots, the players asked for %v", masterHands)
+	}
+
+	return nil
+}
+
+type JobResults struct {
+	RoundsPlayed int                                     `json:"rounds_played"`
+	Players      map[string]*game.BlackJackPlayerResults `json:"players"`
+	SideBets     map[string]*game.SideBetResults         `json:"side_bets,omitempty"`
+	Stats        game.BlackJackStats                     `json:"stats"`
+	// the share of the shoe dealt before the cut card came out: where the
+	// rules put it, and how deep the shoes were actually dealt on average
+	CutCardPenetration float64 `json:"cut_card_penetration"`
+	Penetration        float64 `json:"penetration"`
+	// by the table clock, shuffles included
+	RoundsPerHour float64 `json:"rounds_per_hour"`
+	// by player name, the players betting by the cards they have seen
+	Betting map[string]*counting.BettingResults `json:"betting,omitempty"`
+}
+
+type Job struct {
+	ID      string
+	Request *JobRequest
+	// submission order
+	seq int
+
+	// updated by the worker once per round, read by whoever polls
+	roundsPlayed atomic.Int64
+
+	mutex      sync.Mutex
+	status     JobStatus
+	results    *JobResults
+	created    time.Time
+	started    time.Time
+	finished   time.Time
+	ctx        context.Context
+	cancelFunc context.CancelFunc
+	// JOB_FAILED: what went wrong
+	err error
+}
+
+type JobProgress struct {
+	ID           string     `json:"id"`
+	Status       JobStatus  `json:"status"`
+	Rounds       int        `json:"rounds"`
+	RoundsPlayed int        `json:"rounds_played"`
+	Percent      float64    `json:"percent"`
+	Created      time.Time  `json:"created"`
+	Started      *time.Time `json:"started,omitempty"`
+	Finished     *time.Time `json:"finished,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+func (self *Job) Progress() JobProgress {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	roundsPlayed := int(self.roundsPlayed.Load())
+	var progress JobProgress = JobProgress{
+		ID:           self.ID,
+		Status:       self.status,
+		Rounds:       self.Request.Rounds,
+		RoundsPlayed: roundsPlayed,
+		Percent:      100.0 * float64(roundsPlayed) / float64(self.Request.Rounds),
+		Created:      self.created,
+	}
+	if !self.started.IsZero() {
+		started := self.started
+		progress.Started = &started
+	}
+	if !self.finished.IsZero() {
+		finished := self.finished
+		progress.Finished = &finished
+	}
+	if self.err != nil {
+		progress.Error = self.err.Error()
+	}
+	return progress
+}
+
+// Results() returns nil until the job has completed or has been cancelled,
+// a cancelled job returns the results of the rounds played so far, a failed
+// job has none.
+func (self *Job) Results() *JobResults {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.results
+}
+
+func (self *Job) Status() JobStatus {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.status
+}
+
+// Err() is why the job failed, nil unless it did.
+func (self *Job) Err() error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.err
+}
+
+func (self *Job) isDone() bool {
+	return self.status == JOB_COMPLETED || self.status == JOB_CANCELLED || self.status == JOB_FAILED
+}
+
+// Cancel() returns false when the job was already done.
+func (self *Job) Cancel() bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if self.isDone() {
+		return false
+	}
+	self.cancelFunc()
+	if self.status == JOB_QUEUED {
+		// a worker will skip it when it comes off the queue
+		self.status = JOB_CANCELLED
+		self.finished = time.Now()
+		self.results = &JobResults{
+			RoundsPlayed: 0,
+			Players:      map[string]*game.BlackJackPlayerResults{},
+			Stats:        game.CreateBlackJackStats(),
+		}
+	}
+	return true
+}
+
+// createBlackJack() returns the players betting by the cards too, by name
+func (self *Job) createBlackJack() (*game.BlackJack, map[string]*counting.CountingStrategy) {
+	var request *JobRequest = self.Request
+	var blackjack *game.BlackJack = game.CreateBlackJackWithRules(request.Rules, request.Seed)
+	blackjack.Verbose = false
+
+	var players []*game.Player = []*game.Player{}
+	var counters map[string]*counting.CountingStrategy = map[string]*counting.CountingStrategy{}
+	for i := 0; i < len(request.Players); i++ {
+		var config PlayerConfig = request.Players[i]
+		var player *game.Player = game.CreatePlayer(config.Name)
+		player.Bets = config.Bets
+		// already validated
+		player.Strategy, _ = strategy.CreatePlayerStrategy(config.Strategy, request.Rules)
+		if config.Betting != "" {
+			countingStrategy, _ := config.countingStrategy(player.Strategy, request.Rules)
+			player.Strategy = countingStrategy
+			blackjack.AddListener(countingStrategy)
+			counters[config.Name] = countingStrategy
+		}
+		var names []string = make([]string, 0, len(config.SideBets))
+		for name := range config.SideBets {
+			names = append(names, name)
+		}
+		// settled in the same order every time
+		sort.Strings(names)
+		for j := 0; j < len(names); j++ {
+			sideBet, _ := sidebets.CreateSideBet(names[j])
+			player.SideBets = append(player.SideBets, game.SideBetWager{SideBet: sideBet, Amount: config.SideBets[names[j]]})
+		}
+		players = append(players, player)
+	}
+	blackjack.SetPlayersForGame(players)
+
+	return blackjack, counters
+}
+
+func (self *Job) run() {
+	self.mutex.Lock()
+	if self.status != JOB_QUEUED {
+		// cancelled while waiting in the queue
+		self.mutex.Unlock()
+		return
+	}
+	self.status = JOB_RUNNING
+	self.started = time.Now()
+	self.mutex.Unlock()
+	// a panic fails the job, not the worker and the server with it
+	defer func() {
+		if r := recover(); r != nil {
+			self.fail(fmt.Errorf("job panicked: %v", r))
+		}
+	}()
+
+	blackjack, counters := self.createBlackJack()
+
+	status := JOB_COMPLETED
+	for i := 0; i < self.Request.Rounds; i++ {
+		if self.ctx.Err() != nil {
+			status = JOB_CANCELLED
+			break
+		}
+		blackjack.PlayGame()
+		self.roundsPlayed.Add(1)
+	}
+
+	self.mutex.Lock()
+	self.status = status
+	self.finished = time.Now()
+	self.results = &JobResults{
+		RoundsPlayed:       int(self.roundsPlayed.Load()),
+		Players:            blackjack.Results,
+		SideBets:           blackjack.SideBetResults,
+		Stats:              blackjack.Stats,
+		CutCardPenetration: self.Request.Rules.Penetration(),
+		Penetration:        blackjack.Penetration(),
+		RoundsPerHour:      blackjack.RoundsPerHour(),
+	}
+	if len(counters) > 0 {
+		self.results.Betting = map[string]*counting.BettingResults{}
+		for name, countingStrategy := range counters {
+			self.results.Betting[name] = countingStrategy.Results
+		}
+	}
+	self.mutex.Unlock()
+	// release the context's resources
+	self.cancelFunc()
+}
+
+// fail() ends the job without results, the rounds played so far are lost.
+func (self *Job) fail(err error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.status = JOB_FAILED
+	self.err = err
+	self.finished = time.Now()
+	self.cancelFunc()
+}
+
+//
+// JobManager
+//
+
+// JobManager runs the submitted jobs concurrently on a fixed pool of workers.
+type JobManager struct {
+	// the finished jobs kept, so that a long running server does not keep
+	// every job's results forever, FINISHED_JOBS_KEPT by default
+	Retention int
+
+	mutex  sync.Mutex
+	jobs   map[string]*Job
+	nextID int
+	queue  chan *Job
+	wg     sync.WaitGroup
+	closed bool
+}
+
+var ErrQueueFull = errors.New("job queue is full")
+var ErrShutdown = errors.New("job manager is shut down")
+var ErrJobNotFinished = errors.New("job has not finished")
+var ErrJobNotFound = errors.New("no such job")
+
+func CreateJobManager(workers int, queueSize int) *JobManager {
+	if workers < 1 {
+		workers = 1
+	}
+	var manager JobManager = JobManager{
+		Retention: FINISHED_JOBS_KEPT,
+		jobs:      make(map[string]*Job),
+		nextID:    1,
+		queue:     make(chan *Job, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		manager.wg.Add(1)
+		go manager.worker()
+	}
+	return &manager
+}
+
+func (self *JobManager) worker() {
+	defer self.wg.Done()
+	for job := range self.queue {
+		job.run()
+		self.mutex.Lock()
+		self.forgetFinishedJobs()
+		self.mutex.Unlock()
+	}
+}
+
+// forgetFinishedJobs() drops the finished jobs beyond the Retention, the
+// ones that finished first, the caller holds the mutex.
+func (self *JobManager) forgetFinishedJobs() {
+	var finished []JobProgress = []JobProgress{}
+	for _, job := range self.jobs {
+		if progress := job.Progress(); progress.Finished != nil {
+			finished = append(finished, progress)
+		}
+	}
+	if len(finished) <= self.Retention {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].Finished.Before(*finished[j].Finished)
+	})
+	for i := 0; i < len(finished)-self.Retention; i++ {
+		delete(self.jobs, finished[i].ID)
+	}
+}
+
+// Delete() forgets a finished job and its results, a job still queued or
+// running has to be cancelled first.
+func (self *JobManager) Delete(id string) error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	job, ok := self.jobs[id]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrJobNotFound, id)
+	}
+	if status := job.Status(); status != JOB_COMPLETED && status != JOB_CANCELLED && status != JOB_FAILED {
+		return fmt.Errorf("%w: job is still %v", ErrJobNotFinished, status)
+	}
+	delete(self.jobs, id)
+	return nil
+}
+
+func (self *JobManager) Submit(request *JobRequest) (*Job, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if self.closed {
+		return nil, ErrShutdown
+	}
+
+	// the job plays by its own copy of the rules, cut card placed
+	var jobRequest JobRequest = *request
+	jobRequest.Rules = request.tableRules()
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	var job *Job = &Job{
+		ID:         fmt.Sprintf("job-%v", self.nextID),
+		Request:    &jobRequest,
+		seq:        self.nextID,
+		status:     JOB_QUEUED,
+		created:    time.Now(),
+		ctx:        ctx,
+		cancelFunc: cancelFunc,
+	}
+
+	select {
+	case self.queue <- job:
+	default:
+		cancelFunc()
+		return nil, ErrQueueFull
+	}
+
+	self.nextID++
+	self.jobs[job.ID] = job
+	return job, nil
+}
+
+func (self *JobManager) Get(id string) (*Job, bool) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	job, ok := self.jobs[id]
+	return job, ok
+}
+
+// List() returns the jobs in the order they were submitted.
+func (self *JobManager) List() []*Job {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	var jobs []*Job = make([]*Job, 0, len(self.jobs))
+	for _, job := range self.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].seq < jobs[j].seq
+	})
+	return jobs
+}
+
+// Shutdown() cancels every job that has not finished and waits for the workers.
+func (self *JobManager) Shutdown() {
+	self.mutex.Lock()
+	if self.closed {
+		self.mutex.Unlock()
+		return
+	}
+	self.closed = true
+	close(self.queue)
+	for _, job := range self.jobs {
+		job.Cancel()
+	}
+	self.mutex.Unlock()
+
+	self.wg.Wait()
+}
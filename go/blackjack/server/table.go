@@ -0,0 +1,523 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
+)
+
+// A table is a live game of blackjack, as opposed to a simulation job.
+// Clients sit down in a seat, bet and are asked for their decisions,
+// while PlayGame() deals and plays the dealer's hand.  Bots play basic
+// strategy in the seats nobody is sitting in.
+
+type TableConfig struct {
+	Seats  int
+	MinBet int
+	MaxBet int
+	// a seat may play more than one hand, ie place more than one bet
+	MaxBetsPerSeat int
+	BettingTime    time.Duration
+	// a seat that has not decided in time plays basic strategy for that decision
+	DecisionTime time.Duration
+	FillWithBots bool
+	Rules        *house_rules.HouseRules
+	Seed         int64
+}
+
+func CreateTableConfig() *TableConfig {
+	var config TableConfig = TableConfig{
+		Seats:          5,
+		MinBet:         2,
+		MaxBet:         100,
+		MaxBetsPerSeat: 2,
+		BettingTime:    15 * time.Second,
+		DecisionTime:   30 * time.Second,
+		FillWithBots:   true,
+		Rules:          house_rules.CreateHouseRules(),
+		Seed:           time.Now().UnixNano(),
+	}
+	return &config
+}
+
+// server => client message types
+const (
+	MSG_WELCOME          string = "welcome"
+	MSG_BETTING_OPEN     string = "betting-open"
+	MSG_BETTING_CLOSED   string = "betting-closed"
+	MSG_EVENT            string = "event"
+	MSG_DECISION_REQUEST string = "decision-request"
+	MSG_ROUND_OVER       string = "round-over"
+	MSG_ERROR            string = "error"
+)
+
+// client => server message types
+const (
+	MSG_BET      string = "bet"
+	MSG_DECISION string = "decision"
+	MSG_LEAVE    string = "leave"
+)
+
+type SeatInfo struct {
+	Seat int    `json:"seat"`
+	Name string `json:"name"`
+	Bot  bool   `json:"bot"`
+}
+
+type ServerMessage struct {
+	Type string `json:"type"`
+	// seats are numbered from 1
+	Seat         int                       `json:"seat,omitempty"`
+	Seats        []SeatInfo                `json:"seats,omitempty"`
+	MinBet       int                       `json:"min_bet,omitempty"`
+	MaxBet       int                       `json:"max_bet,omitempty"`
+	Event        *game.GameEvent           `json:"event,omitempty"`
+	Hand         []cards.Card              `json:"hand,omitempty"`
+	DealerUpCard *cards.Card               `json:"dealer_up_card,omitempty"`
+	Options      []strategy.PlayerDecision `json:"options,omitempty"`
+	Message      string                    `json:"message,omitempty"`
+	// round-over: how the seat did in the round, and since sitting down
+	Results *game.BlackJackPlayerResults `json:"results,omitempty"`
+	Totals  *game.BlackJackPlayerResults `json:"totals,omitempty"`
+}
+
+type ClientMessage struct {
+	Type     string                  `json:"type"`
+	Bets     []int                   `json:"bets,omitempty"`
+	Decision strategy.PlayerDecision `json:"decision,omitempty"`
+}
+
+// SeatClient is the connection to whoever is sitting in a seat.
+// Send() must not block the table.
+type SeatClient interface {
+	Send(message ServerMessage)
+	Close()
+}
+
+type Seat struct {
+	Index     int
+	Name      string
+	client    SeatClient
+	player    *game.Player
+	bets      []int
+	decisions chan strategy.PlayerDecision
+	// closed when the client leaves
+	left chan struct{}
+}
+
+type Table struct {
+	ID     string
+	Config *TableConfig
+
+	mutex       sync.Mutex
+	seats       []*Seat
+	bots        []*game.Player
+	bettingOpen bool
+	blackjack   *game.BlackJack
+	// a client sat down or placed a bet
+	wake chan struct{}
+}
+
+func CreateTable(id string, config *TableConfig) *Table {
+	var blackjack *game.BlackJack = game.CreateBlackJackWithRules(config.Rules, config.Seed)
+	blackjack.Verbose = false
+
+	var table Table = Table{
+		ID:        id,
+		Config:    config,
+		seats:     make([]*Seat, config.Seats),
+		bots:      make([]*game.Player, config.Seats),
+		blackjack: blackjack,
+		wake:      make(chan struct{}, 1),
+	}
+	for i := 0; i < config.Seats; i++ {
+		var bot *game.Player = game.CreatePlayer(fmt.Sprintf("Bot %v", i+1))
+		bot.Strategy = strategy.CreateBasicStrategy(config.Rules)
+		bot.Bets = config.Rules.StartingBets(config.MinBet)
+		table.bots[i] = bot
+	}
+	blackjack.AddListener(&table)
+	return &table
+}
+
+func (self *Table) notify() {
+	select {
+	case self.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (self *Table) seatInfo() []SeatInfo {
+	var infos []SeatInfo = []SeatInfo{}
+	for i := 0; i < len(self.seats); i++ {
+		if self.seats[i] != nil {
+			infos = append(infos, SeatInfo{Seat: i + 1, Name: self.seats[i].Name, Bot: false})
+		} else if self.Config.FillWithBots {
+			infos = append(infos, SeatInfo{Seat: i + 1, Name: self.bots[i].Name, Bot: true})
+		}
+	}
+	return infos
+}
+
+func (self *Table) Seats() []SeatInfo {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.seatInfo()
+}
+
+func (self *Table) broadcast(message ServerMessage) {
+	for i := 0; i < len(self.seats); i++ {
+		if self.seats[i] != nil {
+			self.seats[i].client.Send(message)
+		}
+	}
+}
+
+// Join() seats a client, seatNumber 0 => the first empty seat.
+func (self *Table) Join(name string, seatNumber int, client SeatClient) (*Seat, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("a name is required to sit down")
+	}
+	if strings.HasPrefix(name, "Bot ") {
+		return nil, errors.New("names starting with \"Bot \" are reserved")
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for i := 0; i < len(self.seats); i++ {
+		if self.seats[i] != nil && self.seats[i].Name == name {
+			return nil, fmt.Errorf("%q is already sitting at the table", name)
+		}
+	}
+
+	index := -1
+	if seatNumber == 0 {
+		for i := 0; i < len(self.seats); i++ {
+			if self.seats[i] == nil {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, errors.New("the table is full")
+		}
+	} else {
+		if seatNumber < 1 || seatNumber > len(self.seats) {
+			return nil, fmt.Errorf("seat %v does not exist", seatNumber)
+		}
+		if self.seats[seatNumber-1] != nil {
+			return nil, fmt.Errorf("seat %v is taken", seatNumber)
+		}
+		index = seatNumber - 1
+	}
+
+	var seat *Seat = &Seat{
+		Index:     index,
+		Name:      name,
+		client:    client,
+		decisions: make(chan strategy.PlayerDecision, 1),
+		left:      make(chan struct{}),
+	}
+	seat.player = game.CreatePlayer(name)
+	seat.player.Strategy = &RemoteStrategy{
+		Seat:         seat,
+		Rules:        self.Config.Rules,
+		DecisionTime: self.Config.DecisionTime,
+	}
+	self.seats[index] = seat
+
+	client.Send(ServerMessage{
+		Type:   MSG_WELCOME,
+		Seat:   index + 1,
+		Seats:  self.seatInfo(),
+		MinBet: self.Config.MinBet,
+		MaxBet: self.Config.MaxBet,
+	})
+	if self.bettingOpen {
+		client.Send(ServerMessage{Type: MSG_BETTING_OPEN, MinBet: self.Config.MinBet, MaxBet: self.Config.MaxBet})
+	}
+	self.notify()
+
+	return seat, nil
+}
+
+func (self *Table) Leave(seat *Seat) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if self.seats[seat.Index] != seat {
+		return
+	}
+	self.seats[seat.Index] = nil
+	close(seat.left)
+	seat.client.Close()
+}
+
+func (self *Table) validateBets(bets []int) error {
+	if len(bets) < 1 || len(bets) > self.Config.MaxBetsPerSeat {
+		return fmt.Errorf("place between 1 and %v bets", self.Config.MaxBetsPerSeat)
+	}
+	for i := 0; i < len(bets); i++ {
+		if bets[i] < self.Config.MinBet || bets[i] > self.Config.MaxBet {
+			return fmt.Errorf("bets must be between $%v and $%v", self.Config.MinBet, self.Config.MaxBet)
+		}
+	}
+	return self.Config.Rules.ValidateBets(bets)
+}
+
+// Receive() handles a message from the client sitting in the seat.
+func (self *Table) Receive(seat *Seat, message ClientMessage) {
+	switch message.Type {
+	case MSG_BET:
+		self.mutex.Lock()
+		if !self.bettingOpen {
+			seat.client.Send(ServerMessage{Type: MSG_ERROR, Message: "betting is closed"})
+		} else if err := self.validateBets(message.Bets); err != nil {
+			seat.client.Send(ServerMessage{Type: MSG_ERROR, Message: err.Error()})
+		} else {
+			seat.bets = slices.Clone(message.Bets)
+			self.notify()
+		}
+		self.mutex.Unlock()
+
+	case MSG_DECISION:
+		// only the latest decision counts
+		select {
+		case <-seat.decisions:
+		default:
+		}
+		seat.decisions <- message.Decision
+
+	case MSG_LEAVE:
+		self.Leave(seat)
+
+	default:
+		seat.client.Send(ServerMessage{Type: MSG_ERROR, Message: fmt.Sprintf("unknown message type %q", message.Type)})
+	}
+}
+
+func (self *Table) hasClients() bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	for i := 0; i < len(self.seats); i++ {
+		if self.seats[i] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *Table) allClientsHaveBet() bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	for i := 0; i < len(self.seats); i++ {
+		if self.seats[i] != nil && len(self.seats[i].bets) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// takeBets() returns the players for the next game, in seat order,
+// or no players when none of the clients placed a bet.
+func (self *Table) takeBets(ctx context.Context) []*game.Player {
+	self.mutex.Lock()
+	self.bettingOpen = true
+	for i := 0; i < len(self.seats); i++ {
+		if self.seats[i] != nil {
+			self.seats[i].bets = nil
+		}
+	}
+	self.broadcast(ServerMessage{Type: MSG_BETTING_OPEN, MinBet: self.Config.MinBet, MaxBet: self.Config.MaxBet})
+	self.mutex.Unlock()
+
+	timer := time.NewTimer(self.Config.BettingTime)
+	defer timer.Stop()
+	for waiting := true; waiting; {
+		select {
+		case <-ctx.Done():
+			waiting = false
+		case <-timer.C:
+			waiting = false
+		case <-self.wake:
+			waiting = !self.allClientsHaveBet()
+		}
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.bettingOpen = false
+	self.broadcast(ServerMessage{Type: MSG_BETTING_CLOSED})
+
+	var players []*game.Player = []*game.Player{}
+	clientBets := false
+	for i := 0; i < len(self.seats); i++ {
+		var seat *Seat = self.seats[i]
+		if seat != nil {
+			if len(seat.bets) > 0 {
+				// a client without a bet sits this game out
+				seat.player.Bets = seat.bets
+				players = append(players, seat.player)
+				clientBets = true
+			}
+		} else if self.Config.FillWithBots {
+			players = append(players, self.bots[i])
+		}
+	}
+	if !clientBets {
+		return []*game.Player{}
+	}
+	return players
+}
+
+// OnGameEvent() shows every client what happens at the table, except what
+// only one seat saw, eg CARDS_SEEN, which goes to that seat alone.
+func (self *Table) OnGameEvent(event game.GameEvent) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	var message ServerMessage = ServerMessage{Type: MSG_EVENT, Event: &event}
+	if event.Type == game.CARDS_SEEN {
+		for i := 0; i < len(self.seats); i++ {
+			if self.seats[i] != nil && self.seats[i].Name == event.PlayerName {
+				self.seats[i].client.Send(message)
+			}
+		}
+		return
+	}
+	self.broadcast(message)
+}
+
+// PlayRound() takes the bets and plays one game, returns false when
+// nobody bet and so no game was played.
+func (self *Table) PlayRound(ctx context.Context) bool {
+	var players []*game.Player = self.takeBets(ctx)
+	if len(players) == 0 || ctx.Err() != nil {
+		return false
+	}
+
+	self.blackjack.SetPlayersForGame(players)
+	var before map[string]game.BlackJackPlayerResults = map[string]game.BlackJackPlayerResults{}
+	for name, results := range self.blackjack.Results {
+		before[name] = *results
+	}
+	self.blackjack.PlayGame()
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	for i := 0; i < len(self.seats); i++ {
+		var seat *Seat = self.seats[i]
+		if seat != nil {
+			results, ok := self.blackjack.Results[seat.Name]
+			if ok {
+				var totals game.BlackJackPlayerResults = *results
+				var round game.BlackJackPlayerResults = totals.Since(before[seat.Name])
+				seat.client.Send(ServerMessage{Type: MSG_ROUND_OVER, Seat: i + 1, Results: &round, Totals: &totals})
+			}
+		}
+	}
+	return true
+}
+
+// Run() plays games for as long as anyone is sitting at the table.
+func (self *Table) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		if !self.hasClients() {
+			select {
+			case <-ctx.Done():
+				return
+			case <-self.wake:
+			}
+			continue
+		}
+		self.PlayRound(ctx)
+	}
+}
+
+//
+// RemoteStrategy
+//
+
+// RemoteStrategy asks the client sitting in the seat for each decision.
+type RemoteStrategy struct {
+	Seat         *Seat
+	Rules        *house_rules.HouseRules
+	DecisionTime time.Duration
+}
+
+func (self *RemoteStrategy) Name() string {
+	return "remote"
+}
+
+func (self *RemoteStrategy) DeterminePlay(
+	dealerTopCard cards.Card,
+	playerHand strategy.PlayerHandInterface,
+	legalActions []strategy.PlayerDecision,
+) strategy.PlayerDecision {
+	var options []strategy.PlayerDecision = legalActions
+	if self.Rules.PontoonTerms {
+		// stick, twist and buy at a Pontoon table
+		options = []strategy.PlayerDecision{}
+		for i := 0; i < len(legalActions); i++ {
+			options = append(options, strategy.PontoonTerm(legalActions[i]))
+		}
+	}
+
+	// a stale decision from an earlier prompt does not count
+	select {
+	case <-self.Seat.decisions:
+	default:
+	}
+
+	var hand []cards.Card = []cards.Card{}
+	for i := 0; i < playerHand.NumCards(); i++ {
+		hand = append(hand, playerHand.GetCard(i))
+	}
+	self.Seat.client.Send(ServerMessage{
+		Type:         MSG_DECISION_REQUEST,
+		Seat:         self.Seat.Index + 1,
+		Hand:         hand,
+		DealerUpCard: &dealerTopCard,
+		Options:      options,
+	})
+
+	timer := time.NewTimer(self.DecisionTime)
+	defer timer.Stop()
+	for {
+		select {
+		case decision := <-self.Seat.decisions:
+			if slices.Contains(options, decision) {
+				if self.Rules.PontoonTerms {
+					return strategy.FromPontoonTerm(decision)
+				}
+				return decision
+			}
+			self.Seat.client.Send(ServerMessage{
+				Type:    MSG_ERROR,
+				Message: fmt.Sprintf("%q is not one of the options %v", decision, options),
+			})
+		case <-timer.C:
+			return self.fallback(dealerTopCard, playerHand, legalActions)
+		case <-self.Seat.left:
+			return self.fallback(dealerTopCard, playerHand, legalActions)
+		}
+	}
+}
+
+// a client that is gone, or is taking too long, plays basic strategy
+func (self *RemoteStrategy) fallback(
+	dealerTopCard cards.Card,
+	playerHand strategy.PlayerHandInterface,
+	legalActions []strategy.PlayerDecision,
+) strategy.PlayerDecision {
+	return strategy.CreateBasicStrategy(self.Rules).DeterminePlay(dealerTopCard, playerHand, legalActions)
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Table endpoints:
+//     GET /tables                                 tables and who is sitting at them
+//     GET /tables/{id}/ws?name=Jack[&seat=3]      sit down, then play over the web socket
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// the table server is meant for local play
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type TableInfo struct {
+	ID     string     `json:"id"`
+	Seats  []SeatInfo `json:"seats"`
+	MinBet int        `json:"min_bet"`
+	MaxBet int        `json:"max_bet"`
+}
+
+func (self *Server) AddTable(table *Table) {
+	self.tablesMutex.Lock()
+	defer self.tablesMutex.Unlock()
+	self.tables[table.ID] = table
+}
+
+// RunTables() runs every table until the context is done.
+func (self *Server) RunTables(ctx context.Context) {
+	self.tablesMutex.Lock()
+	for _, table := range self.tables {
+		go table.Run(ctx)
+	}
+	self.tablesMutex.Unlock()
+}
+
+func (self *Server) listTables(w http.ResponseWriter, r *http.Request) {
+	self.tablesMutex.Lock()
+	var infos []TableInfo = []TableInfo{}
+	for _, table := range self.tables {
+		infos = append(infos, TableInfo{
+			ID:     table.ID,
+			Seats:  table.Seats(),
+			MinBet: table.Config.MinBet,
+			MaxBet: table.Config.MaxBet,
+		})
+	}
+	self.tablesMutex.Unlock()
+	writeJSON(w, http.StatusOK, infos)
+}
+
+func (self *Server) joinTable(w http.ResponseWriter, r *http.Request) {
+	self.tablesMutex.Lock()
+	table, ok := self.tables[r.PathValue("id")]
+	self.tablesMutex.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("no such table"))
+		return
+	}
+
+	seatNumber := 0
+	if seat := r.URL.Query().Get("seat"); seat != "" {
+		number, err := strconv.Atoi(seat)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.New("seat must be a number"))
+			return
+		}
+		seatNumber = number
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already replied
+		return
+	}
+
+	var client *webSocketClient = createWebSocketClient(conn)
+	seat, err := table.Join(r.URL.Query().Get("name"), seatNumber, client)
+	if err != nil {
+		client.Send(ServerMessage{Type: MSG_ERROR, Message: err.Error()})
+		client.Close()
+		return
+	}
+
+	for {
+		var message ClientMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			break
+		}
+		table.Receive(seat, message)
+	}
+	table.Leave(seat)
+}
+
+//
+// webSocketClient
+//
+
+// webSocketClient queues the messages for the seat, so that a slow
+// client never holds up the table.
+type webSocketClient struct {
+	conn     *websocket.Conn
+	mutex    sync.Mutex
+	outgoing chan ServerMessage
+	closed   bool
+}
+
+const WEB_SOCKET_QUEUE_SIZE int = 256
+
+func createWebSocketClient(conn *websocket.Conn) *webSocketClient {
+	var client *webSocketClient = &webSocketClient{
+		conn:     conn,
+		outgoing: make(chan ServerMessage, WEB_SOCKET_QUEUE_SIZE),
+	}
+	go client.writer()
+	return client
+}
+
+func (self *webSocketClient) writer() {
+	for message := range self.outgoing {
+		self.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		if err := self.conn.WriteJSON(message); err != nil {
+			break
+		}
+	}
+	self.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(time.Second),
+	)
+	self.conn.Close()
+}
+
+func (self *webSocketClient) Send(message ServerMessage) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if self.closed {
+		return
+	}
+	select {
+	case self.outgoing <- message:
+	default:
+		// the client is not keeping up, hang up on it
+		self.closed = true
+		close(self.outgoing)
+	}
+}
+
+func (self *webSocketClient) Close() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if !self.closed {
+		self.closed = true
+		close(self.outgoing)
+	}
+}
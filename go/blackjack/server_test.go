@@ -0,0 +1,454 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/counting"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/server"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+const jobRequestBody string = `{
+	"rules": {"decks_in_shoe": 2, "dealer_hits_soft_on": 16},
+	"players": [
+		{"name": "Jack", "strategy": "basic", "bets": [2]},
+		{"name": "Jill", "strategy": "mimic-dealer", "bets": [2, 2]}
+	],
+	"rounds": 500,
+	"seed": 7
+}`
+
+func submitJob(t *testing.T, handler http.Handler, body string) (int, server.JobProgress) {
+	request := httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(body))
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+
+	var progress server.JobProgress
+	if response.Code == http.StatusAccepted {
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &progress), "submit should return the job progress")
+	}
+	return response.Code, progress
+}
+
+func waitForJob(t *testing.T, jobs *server.JobManager, id string) *server.Job {
+	job, ok := jobs.Get(id)
+	assert.True(t, ok, "submitted job %v should be found", id)
+	deadline := time.Now().Add(30 * time.Second)
+	for job.Results() == nil && job.Err() == nil && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	return job
+}
+
+func TestServerJobLifecycle(t *testing.T) {
+	var jobs *server.JobManager = server.CreateJobManager(2, 10)
+	defer jobs.Shutdown()
+	var handler http.Handler = server.CreateServer(jobs)
+
+	code, progress := submitJob(t, handler, jobRequestBody)
+	assert.Equal(t, http.StatusAccepted, code, "valid job should be accepted")
+	assert.Equal(t, 500, progress.Rounds, "job should run the rounds asked for")
+
+	var job *server.Job = waitForJob(t, jobs, progress.ID)
+	assert.Equal(t, server.JOB_COMPLETED, job.Status(), "job should complete")
+
+	request := httptest.NewRequest(http.MethodGet, "/jobs/"+progress.ID+"/results", nil)
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code, "results of a completed job should be available")
+
+	var results server.JobResults
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &results), "results should be JSON")
+	assert.Equal(t, 500, results.RoundsPlayed, "every round should be played")
+	assert.Equal(t, 2, len(results.Players), "both players should have results")
+	assert.GreaterOrEqual(t, results.Players["Jill"].HandsPlayed, 1000, "Jill plays two hands a round")
+	assert.Equal(t, 0.75, results.CutCardPenetration, "the default cut card")
+	assert.Greater(t, results.Stats.ShoesShuffled, 0)
+	assert.Greater(t, results.Penetration, 0.75, "the round the cut card comes out in is finished")
+	assert.Greater(t, results.RoundsPerHour, 0.0)
+
+	// the same seed should replay the same games
+	_, progress2 := submitJob(t, handler, jobRequestBody)
+	var job2 *server.Job = waitForJob(t, jobs, progress2.ID)
+	assert.Equal(t, *results.Players["Jack"], *job2.Results().Players["Jack"], "same seed, same results")
+}
+
+func TestServerBettingJob(t *testing.T) {
+	var jobs *server.JobManager = server.CreateJobManager(1, 10)
+	defer jobs.Shutdown()
+	var handler http.Handler = server.CreateServer(jobs)
+
+	code, progress := submitJob(t, handler, `{
+		"rules": {"shuffler": "zone"},
+		"players": [
+			{"name": "Jack", "bets": [5], "betting": "hi-lo"},
+			{"name": "Jill", "bets": [5], "betting": "shuffle-tracking"},
+			{"name": "Joe", "bets": [5]}
+		],
+		"rounds": 500,
+		"seed": 7
+	}`)
+	assert.Equal(t, http.StatusAccepted, code, "betting job should be accepted")
+	var results *server.JobResults = waitForJob(t, jobs, progress.ID).Results()
+	assert.Equal(t, 2, len(results.Betting), "Joe bets the same every round")
+	var jack *counting.BettingResults = results.Betting["Jack"]
+	assert.Equal(t, 500, jack.Rounds)
+	assert.Equal(t, jack.ComparedWagered, jack.HiLoWagered, "Jack bets plain Hi-Lo")
+	assert.Equal(t, 0.0, jack.EdgeGained)
+	assert.Equal(t, results.Players["Jack"].Proceeds, jack.Proceeds, "no insurance or side bets")
+	var jill *counting.BettingResults = results.Betting["Jill"]
+	assert.Greater(t, jill.RoundsBetHigher+jill.RoundsBetLower, 0, "Jill follows the slugs")
+	assert.InDelta(t, jill.ComparedEdge()-jill.HiLoEdge, jill.EdgeGained, 1e-9)
+}
+
+func TestServerWongingJob(t *testing.T) {
+	var jobs *server.JobManager = server.CreateJobManager(1, 10)
+	defer jobs.Shutdown()
+	var handler http.Handler = server.CreateServer(jobs)
+
+	wongingJob := func(noMidShoeEntry bool) *server.JobResults {
+		code, progress := submitJob(t, handler, fmt.Sprintf(`{
+			"rules": {"no_mid_shoe_entry": %v},
+			"players": [
+				{"name": "Jack", "bets": [5], "betting": "hi-lo"},
+				{"name": "Jill", "bets": [5], "betting": "hi-lo", "wonging": "wong-in", "wong_in": 1, "wong_out": 0},
+				{"name": "Joe", "bets": [5], "betting": "hi-lo", "wonging": "wong-out"}
+			],
+			"rounds": 1000,
+			"seed": 7
+		}`, noMidShoeEntry))
+		assert.Equal(t, http.StatusAccepted, code, "wonging job should be accepted")
+		return waitForJob(t, jobs, progress.ID).Results()
+	}
+
+	var results *server.JobResults = wongingJob(false)
+	assert.Equal(t, counting.PLAY_ALL, results.Betting["Jack"].Wonging)
+	assert.Equal(t, 0, results.Players["Jack"].RoundsSatOut)
+	assert.Equal(t, 1000, results.Betting["Jack"].Rounds)
+	for _, name := range []string{"Jill", "Joe"} {
+		assert.Greater(t, results.Players[name].RoundsSatOut, 0, "%v sits out the bad counts", name)
+		assert.Equal(t, results.RoundsPlayed, results.Betting[name].Rounds+results.Players[name].RoundsSatOut, "%v plays or watches every round", name)
+	}
+	assert.Greater(t, results.Players["Jill"].RoundsSatOut, results.Players["Joe"].RoundsSatOut, "Jill waits for the count to go up, Joe for it to go down")
+
+	results = wongingJob(true)
+	assert.Equal(t, 0, results.Betting["Jill"].Rounds, "the count is never up at the start of the shoe")
+	assert.Greater(t, results.Betting["Joe"].Rounds, 0, "Joe starts every shoe")
+	assert.Greater(t, results.Players["Joe"].RoundsSatOut, 0, "once Joe leaves he waits for the shuffle")
+}
+
+func TestServerHoleCardJob(t *testing.T) {
+	var jobs *server.JobManager = server.CreateJobManager(1, 10)
+	defer jobs.Shutdown()
+	var handler http.Handler = server.CreateServer(jobs)
+
+	// the dealer flashes every hole card to first base, where Jack sits
+	code, progress := submitJob(t, handler, `{
+		"rules": {"hole_card_flash": 1},
+		"players": [
+			{"name": "Jack", "strategy": "exposed-hole-card", "bets": [5]},
+			{"name": "Jill", "strategy": "exposed-hole-card", "bets": [5]}
+		],
+		"rounds": 4000,
+		"seed": 7
+	}`)
+	assert.Equal(t, http.StatusAccepted, code, "hole card job should be accepted")
+	var results *server.JobResults = waitForJob(t, jobs, progress.ID).Results()
+	var jack *game.BlackJackPlayerResults = results.Players["Jack"]
+	var jill *game.BlackJackPlayerResults = results.Players["Jill"]
+	assert.Greater(t, jack.Proceeds, money.Money(0), "the hole card beats the house")
+	assert.Greater(t, jack.Proceeds, jill.Proceeds, "Jill never sees it and plays basic strategy")
+}
+
+func TestServerRejectsBadJobs(t *testing.T) {
+	var jobs *server.JobManager = server.CreateJobManager(1, 10)
+	defer jobs.Shutdown()
+	var handler http.Handler = server.CreateServer(jobs)
+
+	badBodies := []string{
+		`not json`,
+		`{"players": [{"name": "Jack", "bets": [2]}], "rounds": 0}`,
+		`{"players": [], "rounds": 10}`,
+		`{"players": [{"name": "Jack", "strategy": "card-counting", "bets": [2]}], "rounds": 10}`,
+		`{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10, "rules": {"decks_in_shoe": 9}}`,
+		`{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10, "bogus": true}`,
+		`{"players": [{"name": "Jack", "bets": [2], "side_bets": {"keno": 1}}], "rounds": 10}`,
+		`{"players": [{"name": "Jack", "bets": [2], "side_bets": {"perfect-pairs": 0}}], "rounds": 10}`,
+		`{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10, "penetration": 0.95}`,
+		`{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10, "rules": {"cut_card_spread": -1}}`,
+		`{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10, "rules": {"shuffler": "dealer-choice"}}`,
+		`{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10, "rules": {"shuffler": "csm", "shuffle_procedure": [{"action": "riffle"}]}}`,
+		`{"players": [{"name": "Jack", "bets": [2], "betting": "martingale"}], "rounds": 10}`,
+		`{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10, "rules": {"burn_cards": 11}}`,
+		`{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10, "rules": {"hole_card_flash": 1.5}}`,
+		`{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10, "rules": {"dealer_tell": -0.1}}`,
+		`{"players": [{"name": "Jack", "bets": [2], "wonging": "wong-in"}], "rounds": 10}`,
+		`{"players": [{"name": "Jack", "bets": [2], "betting": "hi-lo", "wonging": "wong-sideways"}], "rounds": 10}`,
+		`{"players": [{"name": "Jack", "bets": [2], "betting": "hi-lo", "wonging": "wong-in", "wong_in": -1, "wong_out": 1}], "rounds": 10}`,
+	}
+	for i := 0; i < len(badBodies); i++ {
+		code, _ := submitJob(t, handler, badBodies[i])
+		assert.Equal(t, http.StatusBadRequest, code, "job %v should be rejected", badBodies[i])
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/jobs/job-42", nil)
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusNotFound, response.Code, "unknown job should not be found")
+}
+
+func TestServerCancelJob(t *testing.T) {
+	var jobs *server.JobManager = server.CreateJobManager(1, 10)
+	defer jobs.Shutdown()
+	var handler http.Handler = server.CreateServer(jobs)
+
+	body := `{"players": [{"name": "Jack", "bets": [2]}], "rounds": 100000000}`
+	code, progress := submitJob(t, handler, body)
+	assert.Equal(t, http.StatusAccepted, code, "valid job should be accepted")
+
+	request := httptest.NewRequest(http.MethodDelete, "/jobs/"+progress.ID, nil)
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusAccepted, response.Code, "running job should be cancelled")
+
+	var job *server.Job = waitForJob(t, jobs, progress.ID)
+	assert.Equal(t, server.JOB_CANCELLED, job.Status(), "job should be cancelled")
+	assert.Less(t, job.Results().RoundsPlayed, 100000000, "cancelled job should stop early")
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodDelete, "/jobs/"+progress.ID, nil))
+	assert.Equal(t, http.StatusConflict, response.Code, "finished job can not be cancelled")
+}
+
+func TestServerForgetsFinishedJobs(t *testing.T) {
+	var jobs *server.JobManager = server.CreateJobManager(1, 10)
+	defer jobs.Shutdown()
+	jobs.Retention = 2
+	var handler http.Handler = server.CreateServer(jobs)
+	deleteResults := func(id string) int {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, httptest.NewRequest(http.MethodDelete, "/jobs/"+id+"/results", nil))
+		return response.Code
+	}
+
+	_, running := submitJob(t, handler, `{"players": [{"name": "Jack", "bets": [2]}], "rounds": 100000000}`)
+	assert.Equal(t, http.StatusConflict, deleteResults(running.ID), "cancel it first")
+	job, _ := jobs.Get(running.ID)
+	job.Cancel()
+	waitForJob(t, jobs, running.ID)
+	assert.Equal(t, http.StatusNoContent, deleteResults(running.ID))
+	_, ok := jobs.Get(running.ID)
+	assert.False(t, ok, "forgotten")
+	assert.Equal(t, http.StatusNotFound, deleteResults(running.ID))
+
+	var ids []string = []string{}
+	for i := 0; i < 3; i++ {
+		_, progress := submitJob(t, handler, `{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10}`)
+		waitForJob(t, jobs, progress.ID)
+		ids = append(ids, progress.ID)
+	}
+	// the worker forgets the oldest once it is done with the job
+	deadline := time.Now().Add(10 * time.Second)
+	for len(jobs.List()) > 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	_, ok = jobs.Get(ids[0])
+	assert.False(t, ok, "only the last two finished jobs are kept")
+	_, ok = jobs.Get(ids[2])
+	assert.True(t, ok)
+
+	assert.ErrorIs(t, jobs.Delete("job-42"), server.ErrJobNotFound)
+	assert.Equal(t, http.StatusNotFound, deleteResults("job-42"))
+}
+
+func TestServerValidatesACopyOfTheRules(t *testing.T) {
+	var jobs *server.JobManager = server.CreateJobManager(1, 10)
+	defer jobs.Shutdown()
+
+	var request *server.JobRequest = server.CreateJobRequest()
+	request.Players = []server.PlayerConfig{{Name: "Jack", Bets: []int{2}}}
+	request.Rounds = 10
+	request.Penetration = 0.5
+	assert.NoError(t, request.Validate())
+	assert.Equal(t, 0, request.Rules.ForceReshuffle, "validating leaves the rules alone")
+
+	job, err := jobs.Submit(request)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, request.Rules.ForceReshuffle, "so does submitting")
+	assert.Equal(t, request.Rules.ForceReshuffleAt(0.5), job.Request.Rules.ForceReshuffle, "the job plays by its own copy")
+}
+
+func TestServerFailedJob(t *testing.T) {
+	var jobs *server.JobManager = server.CreateJobManager(1, 10)
+	defer jobs.Shutdown()
+	var handler http.Handler = server.CreateServer(jobs)
+
+	// the worker is busy with the first job while the second is broken
+	_, running := submitJob(t, handler, `{"players": [{"name": "Jack", "bets": [2]}], "rounds": 100000000}`)
+	_, broken := submitJob(t, handler, `{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10}`)
+	job, _ := jobs.Get(broken.ID)
+	job.Request.Rules = nil
+	runningJob, _ := jobs.Get(running.ID)
+	runningJob.Cancel()
+
+	waitForJob(t, jobs, broken.ID)
+	assert.Equal(t, server.JOB_FAILED, job.Status())
+	assert.Error(t, job.Err())
+	assert.Nil(t, job.Results())
+	assert.NotEmpty(t, job.Progress().Error, "the client is told why")
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/jobs/"+broken.ID+"/results", nil))
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+
+	// the worker lives on
+	_, next := submitJob(t, handler, `{"players": [{"name": "Jack", "bets": [2]}], "rounds": 10}`)
+	assert.Equal(t, server.JOB_COMPLETED, waitForJob(t, jobs, next.ID).Status())
+
+	assert.NoError(t, jobs.Delete(broken.ID), "a failed job is finished")
+}
+
+//
+// Tables
+//
+
+type fakeSeatClient struct {
+	messages chan server.ServerMessage
+}
+
+func (self *fakeSeatClient) Send(message server.ServerMessage) {
+	self.messages <- message
+}
+
+func (self *fakeSeatClient) Close() {}
+
+func createTestTableConfig() *server.TableConfig {
+	var config *server.TableConfig = server.CreateTableConfig()
+	config.Seats = 3
+	config.BettingTime = 2 * time.Second
+	config.DecisionTime = 2 * time.Second
+	config.Seed = 11
+	return config
+}
+
+func TestTableJoin(t *testing.T) {
+	var table *server.Table = server.CreateTable("table-1", createTestTableConfig())
+	var client *fakeSeatClient = &fakeSeatClient{messages: make(chan server.ServerMessage, 100)}
+
+	_, err := table.Join("", 0, client)
+	assert.Error(t, err, "a name is required")
+	_, err = table.Join("Bot 2", 0, client)
+	assert.Error(t, err, "bot names are reserved")
+	_, err = table.Join("Jack", 4, client)
+	assert.Error(t, err, "seat 4 does not exist at a three seat table")
+
+	seat, err := table.Join("Jack", 2, client)
+	assert.NoError(t, err, "Jack should sit down")
+	assert.Equal(t, 1, seat.Index, "Jack should sit in the second seat")
+	welcome := <-client.messages
+	assert.Equal(t, server.MSG_WELCOME, welcome.Type, "Jack should be welcomed")
+	assert.Equal(t, 3, len(welcome.Seats), "bots should fill the other seats")
+
+	_, err = table.Join("Jill", 2, client)
+	assert.Error(t, err, "seat 2 is taken")
+	_, err = table.Join("Jack", 0, client)
+	assert.Error(t, err, "Jack is already at the table")
+
+	table.Leave(seat)
+	_, err = table.Join("Jill", 2, client)
+	assert.NoError(t, err, "seat 2 is free again")
+}
+
+func TestTableEvents(t *testing.T) {
+	var table *server.Table = server.CreateTable("table-1", createTestTableConfig())
+	var jack *fakeSeatClient = &fakeSeatClient{messages: make(chan server.ServerMessage, 100)}
+	var jill *fakeSeatClient = &fakeSeatClient{messages: make(chan server.ServerMessage, 100)}
+	_, err := table.Join("Jack", 1, jack)
+	assert.NoError(t, err)
+	_, err = table.Join("Jill", 2, jill)
+	assert.NoError(t, err)
+	<-jack.messages
+	<-jill.messages
+	for len(jack.messages) > 0 {
+		<-jack.messages
+	}
+
+	table.OnGameEvent(game.GameEvent{Type: game.ROUND_STARTED})
+	assert.Equal(t, game.ROUND_STARTED, (<-jack.messages).Event.Type, "everyone sees the round start")
+	assert.Equal(t, game.ROUND_STARTED, (<-jill.messages).Event.Type)
+
+	table.OnGameEvent(game.GameEvent{Type: game.CARDS_SEEN, PlayerName: "Jill", Cards: []cards.Card{{Suite: cards.CLUBS, Rank: cards.TEN}}})
+	seen := <-jill.messages
+	assert.Equal(t, game.CARDS_SEEN, seen.Event.Type, "only Jill's seat gets Jill's cards")
+	assert.Equal(t, 0, len(jack.messages), "Jack's seat does not")
+}
+
+func TestTableWebSocketRound(t *testing.T) {
+	var jobs *server.JobManager = server.CreateJobManager(1, 1)
+	defer jobs.Shutdown()
+	var httpServer *server.Server = server.CreateServer(jobs)
+	httpServer.AddTable(server.CreateTable("table-1", createTestTableConfig()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	httpServer.RunTables(ctx)
+
+	testServer := httptest.NewServer(httpServer)
+	defer testServer.Close()
+
+	url := "ws" + strings.TrimPrefix(testServer.URL, "http") + "/tables/table-1/ws?name=Jack"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err, "should connect to the table")
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(20 * time.Second))
+
+	var eventTypes map[game.GameEventType]int = map[game.GameEventType]int{}
+	var roundOver *server.ServerMessage
+	for roundOver == nil {
+		var message server.ServerMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			assert.NoError(t, err, "table should keep talking until the round is over")
+			return
+		}
+		switch message.Type {
+		case server.MSG_BETTING_OPEN:
+			conn.WriteJSON(server.ClientMessage{Type: server.MSG_BET, Bets: []int{5}})
+		case server.MSG_DECISION_REQUEST:
+			assert.Contains(t, message.Options, strategy.STAND, "standing is always an option")
+			conn.WriteJSON(server.ClientMessage{Type: server.MSG_DECISION, Decision: strategy.STAND})
+		case server.MSG_EVENT:
+			eventTypes[message.Event.Type]++
+			if message.Event.Type == game.HOLE_CARD_DEALT {
+				assert.Nil(t, message.Event.Card, "the hole card is dealt face down")
+			}
+		case server.MSG_ROUND_OVER:
+			roundOver = &message
+		case server.MSG_ERROR:
+			assert.Fail(t, "unexpected error from the table", message.Message)
+		}
+	}
+
+	assert.Equal(t, 1, eventTypes[game.ROUND_STARTED], "one round should start")
+	assert.Equal(t, 1, eventTypes[game.HOLE_CARD_DEALT], "the dealer gets one hole card")
+	assert.Equal(t, 1, eventTypes[game.HOLE_CARD_REVEALED], "the hole card gets revealed")
+	assert.GreaterOrEqual(t, eventTypes[game.CARD_DEALT], 7, "Jack, two bots and the dealer get cards")
+	assert.GreaterOrEqual(t, eventTypes[game.HAND_SETTLED], 3, "Jack's and the bots' hands get settled")
+	assert.Equal(t, 1, roundOver.Results.HandsPlayed, "Jack played one hand")
+	assert.Equal(t, *roundOver.Results, *roundOver.Totals, "the first round is all there is so far")
+}
@@ -0,0 +1,50 @@
+package sidebets
+
+import (
+	"fmt"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// Buster Blackjack pays when the dealer busts, the more cards in the
+// dealer's bust the more it pays.  It is settled once the dealer is done.
+
+// eg "bust-5-cards", eight or more cards are all "bust-8-cards"
+func BustHand(numCards int) string {
+	return fmt.Sprintf("bust-%v-cards", min(numCards, 8))
+}
+
+type Buster struct {
+	Pays PayTable
+}
+
+func CreateBuster() *Buster {
+	var buster Buster = Buster{
+		Pays: PayTable{
+			BustHand(3): money.PaysToOne(1),
+			BustHand(4): money.PaysToOne(2),
+			BustHand(5): money.PaysToOne(8),
+			BustHand(6): money.PaysToOne(40),
+			BustHand(7): money.PaysToOne(100),
+			BustHand(8): money.PaysToOne(250),
+		},
+	}
+	return &buster
+}
+
+func (self *Buster) Name() string {
+	return BUSTER
+}
+
+func (self *Buster) PayTable() PayTable {
+	return self.Pays
+}
+
+func (self *Buster) Evaluate(deal *Deal) string {
+	hardTotal, _ := house_rules.HandTotals(cardValues(deal.DealerCards))
+	if hardTotal <= 21 {
+		return ""
+	}
+	return BustHand(len(deal.DealerCards))
+}
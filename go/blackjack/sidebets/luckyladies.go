@@ -0,0 +1,77 @@
+package sidebets
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// Lucky Ladies pays when the player's first two cards make 20, the most for
+// a pair of Queens of hearts, and more still when the dealer has a natural.
+const (
+	QUEEN_OF_HEARTS_PAIR_VS_NATURAL string = "queen-of-hearts-pair-vs-dealer-natural"
+	QUEEN_OF_HEARTS_PAIR            string = "queen-of-hearts-pair"
+	MATCHED_20                      string = "matched-20" // same rank and suit
+	SUITED_20                       string = "suited-20"
+	ANY_20                          string = "any-20"
+)
+
+type LuckyLadies struct {
+	Pays PayTable
+}
+
+func CreateLuckyLadies() *LuckyLadies {
+	var luckyLadies LuckyLadies = LuckyLadies{
+		Pays: PayTable{
+			QUEEN_OF_HEARTS_PAIR_VS_NATURAL: money.PaysToOne(1000),
+			QUEEN_OF_HEARTS_PAIR:            money.PaysToOne(200),
+			MATCHED_20:                      money.PaysToOne(25),
+			SUITED_20:                       money.PaysToOne(10),
+			ANY_20:                          money.PaysToOne(4),
+		},
+	}
+	return &luckyLadies
+}
+
+func (self *LuckyLadies) Name() string {
+	return LUCKY_LADIES
+}
+
+func (self *LuckyLadies) PayTable() PayTable {
+	return self.Pays
+}
+
+func (self *LuckyLadies) Evaluate(deal *Deal) string {
+	card1 := deal.PlayerCards[0]
+	card2 := deal.PlayerCards[1]
+	_, softTotal := house_rules.HandTotals([]int{cards.CardRankValue[card1.Rank], cards.CardRankValue[card2.Rank]})
+	if softTotal != 20 {
+		return ""
+	}
+
+	var queenOfHearts cards.Card = cards.Card{Suite: cards.HEARTS, Rank: cards.QUEEN}
+	if card1 == queenOfHearts && card2 == queenOfHearts {
+		if isNatural(deal.DealerCards) {
+			return QUEEN_OF_HEARTS_PAIR_VS_NATURAL
+		}
+		return QUEEN_OF_HEARTS_PAIR
+	} else if card1 == card2 {
+		return MATCHED_20
+	} else if card1.Suite == card2.Suite {
+		return SUITED_20
+	}
+	return ANY_20
+}
+
+func cardValues(hand []cards.Card) []int {
+	var values []int = make([]int, len(hand))
+	for i := 0; i < len(hand); i++ {
+		values[i] = cards.CardRankValue[hand[i].Rank]
+	}
+	return values
+}
+
+func isNatural(hand []cards.Card) bool {
+	_, softTotal := house_rules.HandTotals(cardValues(hand))
+	return len(hand) == 2 && softTotal == 21
+}
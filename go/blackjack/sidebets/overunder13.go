@@ -0,0 +1,51 @@
+package sidebets
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
+// Over/Under 13 is a bet on the player's first two cards totalling more, or
+// less, than 13, an Ace counting as 1.  Exactly 13 loses either way.
+const (
+	OVER  string = "over"
+	UNDER string = "under"
+)
+
+type OverUnder13 struct {
+	// true => the over bet, false => the under bet
+	Over bool
+	Pays PayTable
+}
+
+func CreateOverUnder13(over bool) *OverUnder13 {
+	var overUnder OverUnder13 = OverUnder13{
+		Over: over,
+		Pays: PayTable{UNDER: money.EVEN_MONEY},
+	}
+	if over {
+		overUnder.Pays = PayTable{OVER: money.EVEN_MONEY}
+	}
+	return &overUnder
+}
+
+func (self *OverUnder13) Name() string {
+	if self.Over {
+		return OVER_13
+	}
+	return UNDER_13
+}
+
+func (self *OverUnder13) PayTable() PayTable {
+	return self.Pays
+}
+
+func (self *OverUnder13) Evaluate(deal *Deal) string {
+	var values []int = cardValues(deal.PlayerCards)
+	total := values[0] + values[1]
+	if self.Over && total > 13 {
+		return OVER
+	} else if !self.Over && total < 13 {
+		return UNDER
+	}
+	return ""
+}
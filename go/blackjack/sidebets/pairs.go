@@ -0,0 +1,99 @@
+package sidebets
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
+//
+// Perfect Pairs
+//
+
+// Perfect Pairs pays when the player's first two cards are a pair of the same rank.
+const (
+	PERFECT_PAIR string = "perfect-pair" // same suit
+	COLORED_PAIR string = "colored-pair" // same color, eg hearts and diamonds
+	MIXED_PAIR   string = "mixed-pair"
+)
+
+type PerfectPairs struct {
+	Pays PayTable
+}
+
+func CreatePerfectPairs() *PerfectPairs {
+	var perfectPairs PerfectPairs = PerfectPairs{
+		Pays: PayTable{
+			PERFECT_PAIR: money.PaysToOne(25),
+			COLORED_PAIR: money.PaysToOne(12),
+			MIXED_PAIR:   money.PaysToOne(6),
+		},
+	}
+	return &perfectPairs
+}
+
+func (self *PerfectPairs) Name() string {
+	return PERFECT_PAIRS
+}
+
+func (self *PerfectPairs) PayTable() PayTable {
+	return self.Pays
+}
+
+func (self *PerfectPairs) Evaluate(deal *Deal) string {
+	card1 := deal.PlayerCards[0]
+	card2 := deal.PlayerCards[1]
+	if card1.Rank != card2.Rank {
+		return ""
+	}
+	if card1.Suite == card2.Suite {
+		return PERFECT_PAIR
+	} else if isRed(card1) == isRed(card2) {
+		return COLORED_PAIR
+	}
+	return MIXED_PAIR
+}
+
+//
+// Royal Match
+//
+
+// Royal Match pays when the player's first two cards are suited,
+// and more for a suited King and Queen.
+const (
+	ROYAL_MATCH_HAND string = "royal-match"
+	SUITED           string = "suited"
+)
+
+type RoyalMatch struct {
+	Pays PayTable
+}
+
+func CreateRoyalMatch() *RoyalMatch {
+	var royalMatch RoyalMatch = RoyalMatch{
+		Pays: PayTable{
+			ROYAL_MATCH_HAND: money.PaysToOne(25),
+			SUITED:           {Win: 5, Bet: 2}, // 5 to 2
+		},
+	}
+	return &royalMatch
+}
+
+func (self *RoyalMatch) Name() string {
+	return ROYAL_MATCH
+}
+
+func (self *RoyalMatch) PayTable() PayTable {
+	return self.Pays
+}
+
+func (self *RoyalMatch) Evaluate(deal *Deal) string {
+	card1 := deal.PlayerCards[0]
+	card2 := deal.PlayerCards[1]
+	if card1.Suite != card2.Suite {
+		return ""
+	}
+	if (card1.Rank == cards.KING && card2.Rank == cards.QUEEN) || (card1.Rank == cards.QUEEN && card2.Rank == cards.KING) {
+		return ROYAL_MATCH_HAND
+	}
+	return SUITED
+}
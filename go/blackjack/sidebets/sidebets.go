@@ -0,0 +1,84 @@
+package sidebets
+
+import (
+	"fmt"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
+// A side bet is a bet of its own, placed next to a hand's bet and settled
+// on the cards alone, whatever the hand goes on to do.  Most are settled on
+// the player's first two cards, some on the dealer's up card as well, and
+// a few on the dealer's hand once the dealer is done, eg Buster Blackjack.
+
+// Deal is what a side bet is settled on.
+type Deal struct {
+	// the player's first two cards, as dealt
+	PlayerCards []cards.Card
+	// the dealer's hand when the dealer is done, the up card first
+	DealerCards []cards.Card
+}
+
+func (self *Deal) DealerUpCard() cards.Card {
+	return self.DealerCards[0]
+}
+
+// PayTable maps the hands a side bet pays on to what they pay, eg 25:1.
+type PayTable map[string]money.Payout
+
+type SideBet interface {
+	Name() string
+	PayTable() PayTable
+	// Evaluate() is the best hand in the pay table the deal makes,
+	// "" => the side bet is lost.
+	Evaluate(deal *Deal) string
+}
+
+// Payout() is what the side bet pays, the zero payout => it is lost.
+func Payout(sideBet SideBet, deal *Deal) money.Payout {
+	hand := sideBet.Evaluate(deal)
+	if hand == "" {
+		return money.Payout{}
+	}
+	return sideBet.PayTable()[hand]
+}
+
+const (
+	PERFECT_PAIRS         string = "perfect-pairs"
+	TWENTY_ONE_PLUS_THREE string = "21+3"
+	LUCKY_LADIES          string = "lucky-ladies"
+	ROYAL_MATCH           string = "royal-match"
+	BUSTER                string = "buster-blackjack"
+	OVER_13               string = "over-13"
+	UNDER_13              string = "under-13"
+)
+
+func SideBetNames() []string {
+	return []string{PERFECT_PAIRS, TWENTY_ONE_PLUS_THREE, LUCKY_LADIES, ROYAL_MATCH, BUSTER, OVER_13, UNDER_13}
+}
+
+// CreateSideBet() makes the side bet with its usual pay table.
+func CreateSideBet(name string) (SideBet, error) {
+	switch name {
+	case PERFECT_PAIRS:
+		return CreatePerfectPairs(), nil
+	case TWENTY_ONE_PLUS_THREE:
+		return CreateTwentyOnePlusThree(), nil
+	case LUCKY_LADIES:
+		return CreateLuckyLadies(), nil
+	case ROYAL_MATCH:
+		return CreateRoyalMatch(), nil
+	case BUSTER:
+		return CreateBuster(), nil
+	case OVER_13:
+		return CreateOverUnder13(true), nil
+	case UNDER_13:
+		return CreateOverUnder13(false), nil
+	}
+	return nil, fmt.Errorf("unknown side bet %q", name)
+}
+
+func isRed(card cards.Card) bool {
+	return card.Suite == cards.HEARTS || card.Suite == cards.DIAMONDS
+}
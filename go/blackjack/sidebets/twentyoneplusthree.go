@@ -0,0 +1,69 @@
+package sidebets
+
+import (
+	"slices"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+)
+
+// 21+3 makes a three card poker hand of the player's first two cards and
+// the dealer's up card.
+const (
+	SUITED_TRIPS    string = "suited-trips"
+	STRAIGHT_FLUSH  string = "straight-flush"
+	THREE_OF_A_KIND string = "three-of-a-kind"
+	STRAIGHT        string = "straight"
+	FLUSH           string = "flush"
+)
+
+type TwentyOnePlusThree struct {
+	Pays PayTable
+}
+
+func CreateTwentyOnePlusThree() *TwentyOnePlusThree {
+	var twentyOnePlusThree TwentyOnePlusThree = TwentyOnePlusThree{
+		Pays: PayTable{
+			SUITED_TRIPS:    money.PaysToOne(100),
+			STRAIGHT_FLUSH:  money.PaysToOne(40),
+			THREE_OF_A_KIND: money.PaysToOne(30),
+			STRAIGHT:        money.PaysToOne(10),
+			FLUSH:           money.PaysToOne(5),
+		},
+	}
+	return &twentyOnePlusThree
+}
+
+func (self *TwentyOnePlusThree) Name() string {
+	return TWENTY_ONE_PLUS_THREE
+}
+
+func (self *TwentyOnePlusThree) PayTable() PayTable {
+	return self.Pays
+}
+
+func (self *TwentyOnePlusThree) Evaluate(deal *Deal) string {
+	var hand []cards.Card = []cards.Card{deal.PlayerCards[0], deal.PlayerCards[1], deal.DealerUpCard()}
+	flush := hand[0].Suite == hand[1].Suite && hand[1].Suite == hand[2].Suite
+	trips := hand[0].Rank == hand[1].Rank && hand[1].Rank == hand[2].Rank
+
+	var ranks []int = []int{int(hand[0].Rank), int(hand[1].Rank), int(hand[2].Rank)}
+	slices.Sort(ranks)
+	// an Ace is high or low, Q-K-A and A-2-3 are both straights
+	straight := (ranks[0]+1 == ranks[1] && ranks[1]+1 == ranks[2]) ||
+		slices.Equal(ranks, []int{int(cards.ACE), int(cards.QUEEN), int(cards.KING)})
+
+	switch {
+	case trips && flush:
+		return SUITED_TRIPS
+	case straight && flush:
+		return STRAIGHT_FLUSH
+	case trips:
+		return THREE_OF_A_KIND
+	case straight:
+		return STRAIGHT
+	case flush:
+		return FLUSH
+	}
+	return ""
+}
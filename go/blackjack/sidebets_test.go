@@ -0,0 +1,154 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/counting"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/money"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/sidebets"
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func card(rank cards.CardRank, suite cards.CardSuite) cards.Card {
+	return cards.Card{Suite: suite, Rank: rank}
+}
+
+// createDeal() makes a deal of the player's two cards against the dealer's up card
+func createDeal(card1 cards.Card, card2 cards.Card, dealerCards ...cards.Card) *sidebets.Deal {
+	var deal sidebets.Deal = sidebets.Deal{
+		PlayerCards: []cards.Card{card1, card2},
+		DealerCards: dealerCards,
+	}
+	return &deal
+}
+
+func TestCreateSideBet(t *testing.T) {
+	for _, name := range sidebets.SideBetNames() {
+		sideBet, err := sidebets.CreateSideBet(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, name, sideBet.Name())
+		assert.NotEmpty(t, sideBet.PayTable(), name)
+	}
+	_, err := sidebets.CreateSideBet("insurance")
+	assert.Error(t, err)
+}
+
+func TestPerfectPairs(t *testing.T) {
+	var sideBet sidebets.SideBet = sidebets.CreatePerfectPairs()
+	dealerUpCard := card(cards.TEN, cards.CLUBS)
+	assert.Equal(t, sidebets.PERFECT_PAIR, sideBet.Evaluate(createDeal(card(cards.EIGHT, cards.HEARTS), card(cards.EIGHT, cards.HEARTS), dealerUpCard)))
+	assert.Equal(t, sidebets.COLORED_PAIR, sideBet.Evaluate(createDeal(card(cards.EIGHT, cards.HEARTS), card(cards.EIGHT, cards.DIAMONDS), dealerUpCard)))
+	assert.Equal(t, sidebets.MIXED_PAIR, sideBet.Evaluate(createDeal(card(cards.EIGHT, cards.HEARTS), card(cards.EIGHT, cards.SPADES), dealerUpCard)))
+	assert.Equal(t, "", sideBet.Evaluate(createDeal(card(cards.KING, cards.HEARTS), card(cards.QUEEN, cards.HEARTS), dealerUpCard)), "tens of different ranks are no pair")
+	assert.Equal(t, money.PaysToOne(25), sidebets.Payout(sideBet, createDeal(card(cards.ACE, cards.SPADES), card(cards.ACE, cards.SPADES), dealerUpCard)))
+	assert.Equal(t, money.Payout{}, sidebets.Payout(sideBet, createDeal(card(cards.ACE, cards.SPADES), card(cards.TWO, cards.SPADES), dealerUpCard)))
+}
+
+func TestTwentyOnePlusThree(t *testing.T) {
+	var sideBet sidebets.SideBet = sidebets.CreateTwentyOnePlusThree()
+	cases := []struct {
+		name     string
+		deal     *sidebets.Deal
+		expected string
+	}{
+		{"suited trips", createDeal(card(cards.SEVEN, cards.CLUBS), card(cards.SEVEN, cards.CLUBS), card(cards.SEVEN, cards.CLUBS)), sidebets.SUITED_TRIPS},
+		{"straight flush", createDeal(card(cards.NINE, cards.HEARTS), card(cards.JACK, cards.HEARTS), card(cards.TEN, cards.HEARTS)), sidebets.STRAIGHT_FLUSH},
+		{"three of a kind", createDeal(card(cards.SEVEN, cards.CLUBS), card(cards.SEVEN, cards.HEARTS), card(cards.SEVEN, cards.CLUBS)), sidebets.THREE_OF_A_KIND},
+		{"A-2-3", createDeal(card(cards.ACE, cards.CLUBS), card(cards.THREE, cards.HEARTS), card(cards.TWO, cards.CLUBS)), sidebets.STRAIGHT},
+		{"Q-K-A", createDeal(card(cards.KING, cards.CLUBS), card(cards.ACE, cards.HEARTS), card(cards.QUEEN, cards.CLUBS)), sidebets.STRAIGHT},
+		{"K-A-2", createDeal(card(cards.KING, cards.CLUBS), card(cards.ACE, cards.HEARTS), card(cards.TWO, cards.CLUBS)), ""},
+		{"flush", createDeal(card(cards.TWO, cards.SPADES), card(cards.NINE, cards.SPADES), card(cards.KING, cards.SPADES)), sidebets.FLUSH},
+		{"nothing", createDeal(card(cards.TWO, cards.SPADES), card(cards.NINE, cards.HEARTS), card(cards.KING, cards.SPADES)), ""},
+	}
+	for i := 0; i < len(cases); i++ {
+		assert.Equal(t, cases[i].expected, sideBet.Evaluate(cases[i].deal), cases[i].name)
+	}
+}
+
+func TestLuckyLadies(t *testing.T) {
+	var sideBet sidebets.SideBet = sidebets.CreateLuckyLadies()
+	queenOfHearts := card(cards.QUEEN, cards.HEARTS)
+	dealerUpCard := card(cards.SIX, cards.CLUBS)
+	assert.Equal(t, sidebets.QUEEN_OF_HEARTS_PAIR_VS_NATURAL, sideBet.Evaluate(createDeal(queenOfHearts, queenOfHearts, card(cards.ACE, cards.CLUBS), card(cards.KING, cards.CLUBS))))
+	assert.Equal(t, sidebets.QUEEN_OF_HEARTS_PAIR, sideBet.Evaluate(createDeal(queenOfHearts, queenOfHearts, dealerUpCard, card(cards.KING, cards.CLUBS))))
+	assert.Equal(t, sidebets.MATCHED_20, sideBet.Evaluate(createDeal(card(cards.KING, cards.SPADES), card(cards.KING, cards.SPADES), dealerUpCard)))
+	assert.Equal(t, sidebets.SUITED_20, sideBet.Evaluate(createDeal(card(cards.KING, cards.SPADES), card(cards.TEN, cards.SPADES), dealerUpCard)))
+	assert.Equal(t, sidebets.ANY_20, sideBet.Evaluate(createDeal(card(cards.ACE, cards.SPADES), card(cards.NINE, cards.HEARTS), dealerUpCard)), "soft 20")
+	assert.Equal(t, "", sideBet.Evaluate(createDeal(card(cards.ACE, cards.SPADES), card(cards.KING, cards.SPADES), dealerUpCard)), "a natural is not 20")
+}
+
+func TestRoyalMatch(t *testing.T) {
+	var sideBet sidebets.SideBet = sidebets.CreateRoyalMatch()
+	dealerUpCard := card(cards.SIX, cards.CLUBS)
+	assert.Equal(t, sidebets.ROYAL_MATCH_HAND, sideBet.Evaluate(createDeal(card(cards.QUEEN, cards.DIAMONDS), card(cards.KING, cards.DIAMONDS), dealerUpCard)))
+	assert.Equal(t, sidebets.SUITED, sideBet.Evaluate(createDeal(card(cards.TWO, cards.DIAMONDS), card(cards.KING, cards.DIAMONDS), dealerUpCard)))
+	assert.Equal(t, "", sideBet.Evaluate(createDeal(card(cards.QUEEN, cards.HEARTS), card(cards.KING, cards.DIAMONDS), dealerUpCard)))
+}
+
+func TestBuster(t *testing.T) {
+	var sideBet sidebets.SideBet = sidebets.CreateBuster()
+	playerCard := card(cards.TEN, cards.CLUBS)
+	assert.Equal(t, "", sideBet.Evaluate(createDeal(playerCard, playerCard, card(cards.TEN, cards.CLUBS), card(cards.SEVEN, cards.CLUBS))), "the dealer stood")
+	assert.Equal(t, "bust-3-cards", sideBet.Evaluate(createDeal(playerCard, playerCard, card(cards.TEN, cards.CLUBS), card(cards.SIX, cards.CLUBS), card(cards.NINE, cards.CLUBS))))
+	var dealerCards []cards.Card = []cards.Card{}
+	for i := 0; i < 8; i++ {
+		dealerCards = append(dealerCards, card(cards.THREE, cards.CLUBS))
+	}
+	assert.Equal(t, "bust-8-cards", sideBet.Evaluate(createDeal(playerCard, playerCard, dealerCards...)))
+	dealerCards = append(dealerCards, card(cards.THREE, cards.CLUBS))
+	assert.Equal(t, sidebets.BustHand(8), sideBet.Evaluate(createDeal(playerCard, playerCard, dealerCards...)), "nine cards pay like eight")
+}
+
+func TestOverUnder13(t *testing.T) {
+	var over sidebets.SideBet = sidebets.CreateOverUnder13(true)
+	var under sidebets.SideBet = sidebets.CreateOverUnder13(false)
+	dealerUpCard := card(cards.SIX, cards.CLUBS)
+	var deal *sidebets.Deal = createDeal(card(cards.THREE, cards.CLUBS), card(cards.KING, cards.CLUBS), dealerUpCard)
+	assert.Equal(t, "", over.Evaluate(deal), "13 loses")
+	assert.Equal(t, "", under.Evaluate(deal), "13 loses")
+	deal = createDeal(card(cards.FOUR, cards.CLUBS), card(cards.KING, cards.CLUBS), dealerUpCard)
+	assert.Equal(t, sidebets.OVER, over.Evaluate(deal))
+	assert.Equal(t, "", under.Evaluate(deal))
+	deal = createDeal(card(cards.ACE, cards.CLUBS), card(cards.ACE, cards.CLUBS), dealerUpCard)
+	assert.Equal(t, sidebets.UNDER, under.Evaluate(deal), "an Ace is 1")
+	assert.Equal(t, money.EVEN_MONEY, sidebets.Payout(under, deal), "even money")
+}
+
+func TestRoundSideBets(t *testing.T) {
+	var blackjack *game.BlackJack = createQuietBlackJack("John")
+	var counter *counting.SideBetCounter = counting.CreateSideBetCounter(blackjack.Rules.DecksInShoe, blackjack.Rules.CardsPerDeck())
+	blackjack.AddListener(counter)
+	var player *game.Player = blackjack.Players[0]
+	player.SideBets = []game.SideBetWager{
+		{SideBet: sidebets.CreatePerfectPairs(), Amount: 1},
+		{SideBet: sidebets.CreateOverUnder13(false), Amount: 3},
+	}
+	// John: 8, 8   Dealer: 10, 7, all clubs
+	stackShoe(blackjack, []cards.CardRank{cards.EIGHT, cards.TEN, cards.EIGHT, cards.SEVEN})
+	player.Bankroll = money.Dollars(100)
+
+	var round *game.Round = blackjack.StartRound()
+	for !round.IsOver() {
+		var action game.RoundAction = round.DefaultAction()
+		if round.Phase == game.PHASE_PLAYER_TURNS {
+			action = game.PlayAction(strategy.STAND)
+		}
+		assert.NoError(t, round.Step(action))
+	}
+
+	var results *game.BlackJackPlayerResults = blackjack.Results["John"]
+	assert.Equal(t, money.Dollars(25-3), results.SideBetProceeds, "the perfect pair wins 25, 16 loses the under bet")
+	assert.Equal(t, money.Dollars(-2+25-3), results.Proceeds, "16 loses to 17")
+	assert.Equal(t, money.Dollars(100)+results.Proceeds, player.Bankroll, "the side bets come off the bankroll and are paid back to it")
+
+	assert.Equal(t, 1, blackjack.SideBetResults[sidebets.PERFECT_PAIRS].Wins)
+	assert.Equal(t, 1, blackjack.SideBetResults[sidebets.PERFECT_PAIRS].Hands[sidebets.PERFECT_PAIR])
+	assert.Equal(t, 3, blackjack.SideBetResults[sidebets.UNDER_13].Wagered)
+	assert.Equal(t, money.Dollars(-3), blackjack.SideBetResults[sidebets.UNDER_13].Proceeds)
+	assert.Equal(t, float64(25), counter.PlayerEdge(sidebets.PERFECT_PAIRS, 0), "made at a true count of 0")
+	assert.Equal(t, float64(-1), counter.PlayerEdge(sidebets.UNDER_13, 0))
+}
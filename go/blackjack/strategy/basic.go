@@ -1,8 +1,6 @@
 package strategy
 
 import (
-	"fmt"
-
 	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
 	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
 )
@@ -14,28 +12,24 @@ type PlayerHandInterface interface {
 	NumCards() int
 	IsFromSplit() bool
 	GetCard(cardIndex int) cards.Card
+	GetBet() int
+	NumDoubleDowns() int
 }
 
 func convertToPlayerDecision(
 	decision Decision,
-	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
 ) PlayerDecision {
 	// Decision sometimes return Xy, which translates to do X if allowed else do y.
-	// Determine the X or the y here.
-
-	isFirstDecision := playerHand.NumCards() == 2
-	isFirstPostSplitDecision := isFirstDecision && playerHand.IsFromSplit()
+	// Determine the X or the y here, LegalActions() knows what is allowed.
 
 	var playerDecision PlayerDecision
 
-	hardCount := playerHand.HardCount()
-	softCount := playerHand.SoftCount()
-
 	if decision == Decision(S) {
-		return PlayerDecision(STAND)
+		playerDecision = PlayerDecision(STAND)
 
 	} else if decision == Decision(H) {
-		return PlayerDecision(HIT)
+		playerDecision = PlayerDecision(HIT)
 
 	} else if decision == Decision(Dh) || decision == Decision(Ds) {
 		// may be only allow to down on hand totals [9, 10, 11] or some such
@@ -49,35 +43,8 @@ func convertToPlayerDecision(
 			nondoubleDownDecision = PlayerDecision(STAND)
 		}
 
-		var canDoubleDown bool
-		if isFirstDecision {
-			if isFirstPostSplitDecision {
-				if house_rules.DOUBLE_DOWN_AFTER_SPLIT {
-					canDoubleDown = true
-				} else {
-					canDoubleDown = false
-				}
-			} else {
-				canDoubleDown = true
-			}
-		} else {
-			canDoubleDown = false
-		}
-
-		if canDoubleDown {
-			var doubleDown bool
-			if house_rules.CanDoubleDown(hardCount) {
-				doubleDown = true
-			} else if house_rules.CanDoubleDown(softCount) {
-				doubleDown = true
-			} else {
-				doubleDown = false
-			}
-			if doubleDown {
-				playerDecision = PlayerDecision(DOUBLE)
-			} else {
-				playerDecision = nondoubleDownDecision
-			}
+		if IsLegalAction(DOUBLE, legalActions) {
+			playerDecision = PlayerDecision(DOUBLE)
 		} else {
 			playerDecision = nondoubleDownDecision
 		}
@@ -101,46 +68,77 @@ func convertToPlayerDecision(
 			panic("convertToPlayerDecision() ran into a little trouble in town.")
 		}
 
-		surrenderCanBePlayed := isFirstDecision && !isFirstPostSplitDecision && house_rules.SURRENDER_ALLOWED
-		if surrenderCanBePlayed {
+		if IsLegalAction(SURRENDER, legalActions) {
 			playerDecision = PlayerDecision(SURRENDER)
 		} else {
 			playerDecision = nonsurrenderDecision
 		}
 	}
 
+	if !IsLegalAction(playerDecision, legalActions) && IsLegalAction(STAND, legalActions) {
+		// eg hitting split aces is not allowed, yet doubling down on them is
+		playerDecision = PlayerDecision(STAND)
+	} else if !IsLegalAction(playerDecision, legalActions) && IsLegalAction(HIT, legalActions) {
+		// eg Pontoon, the hand has to take a card below the house's minimum
+		playerDecision = PlayerDecision(HIT)
+	}
+
 	return playerDecision
 }
 
+// BasicStrategy plays the basic strategy tables for a given set of house rules.
+type BasicStrategy struct {
+	Rules *house_rules.HouseRules
+}
+
+func CreateBasicStrategy(rules *house_rules.HouseRules) *BasicStrategy {
+	var basicStrategy BasicStrategy = BasicStrategy{
+		Rules: rules,
+	}
+	return &basicStrategy
+}
+
+func (self *BasicStrategy) Name() string {
+	return BASIC_STRATEGY
+}
+
+// not exported
+var _DEFAULT_BASIC_STRATEGY *BasicStrategy = CreateBasicStrategy(house_rules.CreateHouseRules())
+
+// DetermineBasicStrategyPlay() plays basic strategy against the default house rules,
+// for a player who can always afford to double down or split.
 func DetermineBasicStrategyPlay(
 	dealerTopCard cards.Card,
 	playerHand PlayerHandInterface,
 	handAllowsMoreSplits bool,
 ) PlayerDecision {
-	isFirstDecision := playerHand.NumCards() == 2
-	// isFirstPostSplitDecision := isFirstDecision && playerHand.FromSplit
+	var legalActions []PlayerDecision = LegalActions(
+		playerHand,
+		splitAllowance{allowsMoreSplits: handAllowsMoreSplits},
+		_DEFAULT_BASIC_STRATEGY.Rules,
+		UNLIMITED_BANKROLL,
+	)
+	return _DEFAULT_BASIC_STRATEGY.DeterminePlay(dealerTopCard, playerHand, legalActions)
+}
 
-	playerCard1 := playerHand.GetCard(0)
-	playerCard2 := playerHand.GetCard(1)
+func (self *BasicStrategy) DeterminePlay(
+	dealerTopCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	if len(legalActions) == 1 {
+		// eg standing on split aces
+		return legalActions[0]
+	}
 
 	var decision Decision
 	var playerDecision PlayerDecision
 
-	var gotPairs bool
-	if isFirstDecision {
-		if house_rules.SPLIT_ON_VALUE_MATCH {
-			gotPairs = cards.CardRankValue[playerCard1.Rank] == cards.CardRankValue[playerCard2.Rank]
-		} else {
-			gotPairs = playerCard1.Rank == playerCard2.Rank
-		}
-	} else {
-		gotPairs = false
-	}
-
-	if gotPairs && handAllowsMoreSplits {
-		// Determine if the pairs can be split.
+	if IsLegalAction(SPLIT, legalActions) {
+		// Determine if the pairs should be split.
 		// Note all of the non-split decisions that are ignored below
 		// will not contradict the hard/soft total decision.
+		playerCard1 := playerHand.GetCard(0)
 		var pairRank cards.CardRank
 		if cards.CardRankValue[playerCard1.Rank] == 10 {
 			pairRank = cards.CardRank(10)
@@ -149,7 +147,7 @@ func DetermineBasicStrategyPlay(
 		}
 
 		decision = GetPairSplitDecision(pairRank, dealerTopCard.Rank)
-		playerDecision = convertToPlayerDecision(decision, playerHand)
+		playerDecision = convertToPlayerDecision(decision, legalActions)
 		if playerDecision == PlayerDecision(SPLIT) {
 			return PlayerDecision(SPLIT)
 		}
@@ -161,15 +159,9 @@ func DetermineBasicStrategyPlay(
 
 	if useSoftTotal {
 		decision = GetSoftTotalDecision(softCount, dealerTopCard.Rank)
-		playerDecision = convertToPlayerDecision(decision, playerHand)
-		return playerDecision
-
 	} else {
 		decision = GetHardTotalDecision(hardCount, dealerTopCard.Rank)
-		playerDecision = convertToPlayerDecision(decision, playerHand)
-		return playerDecision
 	}
-
-	fmt.Println("DetermineBasicStrategyPlay() ran into a little trouble in town.")
-	panic("DetermineBasicStrategyPlay() ran into a little trouble in town.")
+	playerDecision = convertToPlayerDecision(decision, legalActions)
+	return playerDecision
 }
@@ -41,3 +41,35 @@ func IsValidPlayerDecision(player_decision PlayerDecision) bool {
 	}
 	return false
 }
+
+// Pontoon has words of its own for the decisions, see HouseRules.PontoonTerms
+const (
+	STICK PlayerDecision = "stick"
+	TWIST PlayerDecision = "twist"
+	BUY   PlayerDecision = "buy"
+)
+
+var pontoon_terms map[PlayerDecision]PlayerDecision = map[PlayerDecision]PlayerDecision{
+	STAND:  STICK,
+	HIT:    TWIST,
+	DOUBLE: BUY,
+}
+
+// PontoonTerm() is the decision as Pontoon calls it, split and surrender keep their names.
+func PontoonTerm(decision PlayerDecision) PlayerDecision {
+	term, ok := pontoon_terms[decision]
+	if ok {
+		return term
+	}
+	return decision
+}
+
+// FromPontoonTerm() undoes PontoonTerm(), any other decision is returned as is.
+func FromPontoonTerm(term PlayerDecision) PlayerDecision {
+	for decision, pontoonTerm := range pontoon_terms {
+		if pontoonTerm == term {
+			return decision
+		}
+	}
+	return term
+}
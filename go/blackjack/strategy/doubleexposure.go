@@ -0,0 +1,334 @@
+package strategy
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// Double Exposure strategy, eight decks, dealer hits soft 17, dealer wins ties,
+// doubling down on hard 9 thru 11 only.  Both dealer cards are face up, so
+// the tables are indexed by the dealer's total rather than the top card:
+// a hard total 4 thru 20 or a soft total 12 thru 20.  A dealer natural has
+// already been settled.  Stand on a stiff against a dealer stiff, and hit
+// until the dealer's total is beaten when the dealer stands on it.
+// The tables were worked out with expectedValues, for an infinite deck.
+
+var double_exposure_hard_total_vs_hard_decision = [22][22]Decision{
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 1  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 2  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 3  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 4  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 5  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 6  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 7  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 8  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 9  x  dealer hard total
+	{NO, NO, NO, NO,  H, Dh, Dh,  H,  H,  H,  H,  H, Dh, Dh, Dh, Dh, Dh,  H,  H,  H,  H, NO},
+	// 10  x  dealer hard total
+	{NO, NO, NO, NO, Dh, Dh, Dh, Dh, Dh,  H,  H,  H, Dh, Dh, Dh, Dh, Dh,  H,  H,  H,  H, NO},
+	// 11  x  dealer hard total
+	{NO, NO, NO, NO, Dh, Dh, Dh, Dh, Dh, Dh,  H,  H, Dh, Dh, Dh, Dh, Dh,  H,  H,  H,  H, NO},
+	// 12  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  H,  H,  H,  H,  H,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 13  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  H,  H,  H,  H,  H,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 14  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  H,  H,  H,  H,  S,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 15  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  H,  H,  H,  S,  S,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 16  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  H,  S,  S,  S,  S,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 17  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 18  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  H,  H,  H, NO},
+	// 19  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  H,  H, NO},
+	// 20  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  H, NO},
+	// 21  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S, NO},
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+}
+
+var double_exposure_soft_total_vs_hard_decision = [22][22]Decision{
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 1  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 2  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 3  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 4  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 5  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 6  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 7  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 8  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 9  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 10  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 11  x  dealer hard total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 12  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 13  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 14  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 15  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 16  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 17  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 18  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H,  S,  S,  H,  H,  H,  H,  H,  H,  H,  S,  H,  S,  H,  H,  H, NO},
+	// 19  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  H,  H, NO},
+	// 20  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  H, NO},
+	// 21  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S, NO},
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+}
+
+var double_exposure_pairs_vs_hard_decision = [11][22]Decision{
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// player pair card: Ace  x  dealer hard total
+	{NO, NO, NO, NO, SP, SP, SP, SP, SP, SP, SP,  H, SP, SP, SP, SP, SP,  H,  H,  H,  H, NO},
+	// player pair card: 2  x  dealer hard total
+	{NO, NO, NO, NO, SP, SP, SP,  H,  H,  H,  H,  H, SP, SP, SP, SP, SP, SP,  H,  H,  H, NO},
+	// player pair card: 3  x  dealer hard total
+	{NO, NO, NO, NO, SP, SP, SP,  H,  H,  H,  H,  H, SP, SP, SP, SP, SP, SP,  H,  H,  H, NO},
+	// player pair card: 4  x  dealer hard total
+	{NO, NO, NO, NO,  H,  H, SP,  H,  H,  H,  H,  H, SP, SP, SP, SP, SP,  H,  H,  H,  H, NO},
+	// player pair card: 5  x  dealer hard total
+	{NO, NO, NO, NO, Dh, Dh, Dh, Dh, Dh,  H,  H,  H, Dh, Dh, Dh, Dh, Dh,  H,  H,  H,  H, NO},
+	// player pair card: 6  x  dealer hard total
+	{NO, NO, NO, NO, SP, SP, SP,  H,  H,  H,  H,  H, SP, SP, SP, SP, SP, SP,  H,  H,  H, NO},
+	// player pair card: 7  x  dealer hard total
+	{NO, NO, NO, NO, SP, SP, SP,  H,  H,  H,  H,  S, SP, SP, SP, SP, SP, SP,  H,  H,  H, NO},
+	// player pair card: 8  x  dealer hard total
+	{NO, NO, NO, NO, SP, SP, SP, SP, SP,  S,  S,  S, SP, SP, SP, SP, SP, SP,  H,  H,  H, NO},
+	// player pair card: 9  x  dealer hard total
+	{NO, NO, NO, NO, SP, SP, SP,  S, SP,  S,  S,  S, SP, SP, SP, SP, SP,  S, SP,  H,  H, NO},
+	// player pair card: 10  x  dealer hard total
+	{NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  S, SP, SP, SP, SP,  S,  S,  S,  H, NO},
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+}
+
+var double_exposure_hard_total_vs_soft_decision = [22][22]Decision{
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 1  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 2  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 3  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 4  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 5  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 6  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 7  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 8  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 9  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 10  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H, Dh, Dh, Dh,  H,  H,  H,  H, NO},
+	// 11  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H, Dh, Dh, Dh, Dh,  H,  H,  H,  H, NO},
+	// 12  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 13  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 14  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 15  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 16  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 17  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// 18  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  H,  H,  H, NO},
+	// 19  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  H,  H, NO},
+	// 20  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  H, NO},
+	// 21  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  S, NO},
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+}
+
+var double_exposure_soft_total_vs_soft_decision = [22][22]Decision{
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 1  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 2  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 3  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 4  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 5  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 6  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 7  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 8  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 9  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 10  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 11  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 12  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 13  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 14  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 15  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 16  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 17  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// 18  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  S,  H,  H,  H, NO},
+	// 19  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  H,  H, NO},
+	// 20  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  H, NO},
+	// 21  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  S, NO},
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+}
+
+var double_exposure_pairs_vs_soft_decision = [11][22]Decision{
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// player pair card: Ace  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, SP, SP, SP, SP, SP, SP,  H,  H,  H, NO},
+	// player pair card: 2  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// player pair card: 3  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// player pair card: 4  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  H,  H,  H,  H,  H,  H,  H, NO},
+	// player pair card: 5  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H, Dh, Dh, Dh,  H,  H,  H,  H, NO},
+	// player pair card: 6  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  H,  H,  S,  S,  S,  H,  H,  H,  H, NO},
+	// player pair card: 7  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  H,  H,  H,  H, NO},
+	// player pair card: 8  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S, SP, SP,  H,  H,  H, NO},
+	// player pair card: 9  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S, SP, SP,  S, SP,  H,  H, NO},
+	// player pair card: 10  x  dealer soft total
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO,  S,  S,  S,  S,  S,  S,  S,  S,  H, NO},
+	//0   1   2   3   4   5   6   7   8   9  10  11  12  13  14  15  16  17  18  19  20  21
+}
+
+func GetDoubleExposureDecision(playerHand PlayerHandInterface, dealerHardCount int, dealerSoftCount int) Decision {
+	dealerIsSoft := dealerHardCount < dealerSoftCount && dealerSoftCount <= 21
+	if hasSoftAce(playerHand) {
+		if dealerIsSoft {
+			return double_exposure_soft_total_vs_soft_decision[playerHand.SoftCount()][dealerSoftCount]
+		}
+		return double_exposure_soft_total_vs_hard_decision[playerHand.SoftCount()][dealerHardCount]
+	}
+	if dealerIsSoft {
+		return double_exposure_hard_total_vs_soft_decision[playerHand.HardCount()][dealerSoftCount]
+	}
+	return double_exposure_hard_total_vs_hard_decision[playerHand.HardCount()][dealerHardCount]
+}
+
+func GetDoubleExposurePairSplitDecision(pairValue int, dealerHardCount int, dealerSoftCount int) Decision {
+	if dealerHardCount < dealerSoftCount && dealerSoftCount <= 21 {
+		return double_exposure_pairs_vs_soft_decision[pairValue][dealerSoftCount]
+	}
+	return double_exposure_pairs_vs_hard_decision[pairValue][dealerHardCount]
+}
+
+// DoubleExposureStrategy plays the tables above when it is shown the dealer's
+// hole card, and basic strategy when it is not.
+type DoubleExposureStrategy struct {
+	Rules *house_rules.HouseRules
+}
+
+func CreateDoubleExposureStrategy(rules *house_rules.HouseRules) *DoubleExposureStrategy {
+	var doubleExposureStrategy DoubleExposureStrategy = DoubleExposureStrategy{
+		Rules: rules,
+	}
+	return &doubleExposureStrategy
+}
+
+func (self *DoubleExposureStrategy) Name() string {
+	return DOUBLE_EXPOSURE
+}
+
+func (self *DoubleExposureStrategy) DeterminePlay(
+	dealerTopCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	return CreateBasicStrategy(self.Rules).DeterminePlay(dealerTopCard, playerHand, legalActions)
+}
+
+func (self *DoubleExposureStrategy) DeterminePlayWithHoleCard(
+	dealerTopCard cards.Card,
+	dealerHoleCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	if len(legalActions) == 1 {
+		return legalActions[0]
+	}
+
+	dealerHardCount, dealerSoftCount := house_rules.HandTotals([]int{
+		cards.CardRankValue[dealerTopCard.Rank],
+		cards.CardRankValue[dealerHoleCard.Rank],
+	})
+
+	if IsLegalAction(SPLIT, legalActions) {
+		pairValue := cards.CardRankValue[playerHand.GetCard(0).Rank]
+		if GetDoubleExposurePairSplitDecision(pairValue, dealerHardCount, dealerSoftCount) == Decision(SP) {
+			return PlayerDecision(SPLIT)
+		}
+	}
+
+	var decision Decision = GetDoubleExposureDecision(playerHand, dealerHardCount, dealerSoftCount)
+	if decision == Decision(NO) {
+		// eg a dealer natural, which is settled before anyone plays
+		return PlayerDecision(STAND)
+	}
+	return convertToPlayerDecision(decision, legalActions)
+}
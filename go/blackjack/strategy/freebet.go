@@ -0,0 +1,45 @@
+package strategy
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// FreeBetStrategy takes every free double down, and every free split but 5s,
+// a free double down on 10 being worth more.  What is not free is played by
+// expected value, as in Blackjack Switch, which counts the dealer 22 push.
+type FreeBetStrategy struct {
+	Rules  *house_rules.HouseRules
+	played *BlackjackSwitchStrategy
+}
+
+func CreateFreeBetStrategy(rules *house_rules.HouseRules) *FreeBetStrategy {
+	var freeBetStrategy FreeBetStrategy = FreeBetStrategy{
+		Rules:  rules,
+		played: CreateBlackjackSwitchStrategy(rules),
+	}
+	return &freeBetStrategy
+}
+
+func (self *FreeBetStrategy) Name() string {
+	return FREE_BET
+}
+
+func (self *FreeBetStrategy) DeterminePlay(
+	dealerTopCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	if len(legalActions) == 1 {
+		return legalActions[0]
+	}
+
+	if IsLegalAction(SPLIT, legalActions) && IsFreeSplit(playerHand, self.Rules) &&
+		cards.CardRankValue[playerHand.GetCard(0).Rank] != 5 {
+		return PlayerDecision(SPLIT)
+	}
+	if IsLegalAction(DOUBLE, legalActions) && IsFreeDouble(playerHand, self.Rules) {
+		return PlayerDecision(DOUBLE)
+	}
+	return self.played.DeterminePlay(dealerTopCard, playerHand, legalActions)
+}
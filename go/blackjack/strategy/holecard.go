@@ -0,0 +1,79 @@
+package strategy
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// ExposedHoleCardStrategy is for a player who gets to know something of the
+// dealer's hole card under the usual rules: the whole card, flashed by a
+// careless dealer, or whether it is a ten, from a dealer's tell.  Every
+// decision is the one worth the most against what the dealer can have, see
+// expectedValues, an infinite deck.  Without a look at the hole card it
+// plays basic strategy, so the edge of the look is the difference.
+type ExposedHoleCardStrategy struct {
+	Rules *house_rules.HouseRules
+	// by the dealer's top card and hole card values, Ace => 1, worked out when first needed
+	values [11][11]*expectedValues
+	// by the dealer's top card value, then whether the hole card is a ten
+	tellValues [11][2]*expectedValues
+}
+
+func CreateExposedHoleCardStrategy(rules *house_rules.HouseRules) *ExposedHoleCardStrategy {
+	var exposedHoleCardStrategy ExposedHoleCardStrategy = ExposedHoleCardStrategy{
+		Rules: rules,
+	}
+	return &exposedHoleCardStrategy
+}
+
+func (self *ExposedHoleCardStrategy) Name() string {
+	return EXPOSED_HOLE_CARD
+}
+
+func (self *ExposedHoleCardStrategy) DeterminePlay(
+	dealerTopCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	return CreateBasicStrategy(self.Rules).DeterminePlay(dealerTopCard, playerHand, legalActions)
+}
+
+func (self *ExposedHoleCardStrategy) DeterminePlayWithHoleCard(
+	dealerTopCard cards.Card,
+	dealerHoleCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	if len(legalActions) == 1 {
+		return legalActions[0]
+	}
+	dealerValue := cards.CardRankValue[dealerTopCard.Rank]
+	holeValue := cards.CardRankValue[dealerHoleCard.Rank]
+	if self.values[dealerValue][holeValue] == nil {
+		self.values[dealerValue][holeValue] = createExpectedValues(self.Rules, []int{dealerValue, holeValue})
+	}
+	return self.values[dealerValue][holeValue].decideWithSplit(playerHand, legalActions)
+}
+
+// DeterminePlayWithTell() is for a dealer who has given away whether the hole card is a ten.
+func (self *ExposedHoleCardStrategy) DeterminePlayWithTell(
+	dealerTopCard cards.Card,
+	holeCardIsTen bool,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	if len(legalActions) == 1 {
+		return legalActions[0]
+	}
+	dealerValue := cards.CardRankValue[dealerTopCard.Rank]
+	isTen := 0
+	var holeValues []int = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if holeCardIsTen {
+		isTen = 1
+		holeValues = []int{10}
+	}
+	if self.tellValues[dealerValue][isTen] == nil {
+		self.tellValues[dealerValue][isTen] = createExpectedValuesWithHoleCards(self.Rules, dealerValue, holeValues)
+	}
+	return self.tellValues[dealerValue][isTen].decideWithSplit(playerHand, legalActions)
+}
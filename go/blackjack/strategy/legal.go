@@ -0,0 +1,180 @@
+package strategy
+
+import (
+	"math"
+	"slices"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// The one place that knows which decisions the house rules allow.
+// The engine rejects anything else, and the strategies pick from it.
+
+// a player who can always cover a double down or a split
+const UNLIMITED_BANKROLL int = math.MaxInt
+
+// interface for the same reason as PlayerHandInterface, ie no circular import
+type PlayerMasterHandInterface interface {
+	NumHands() int
+	HandsLimit() int
+}
+
+// splitAllowance stands in for a master hand when all that is known is
+// whether the hand may be split again.
+type splitAllowance struct {
+	allowsMoreSplits bool
+}
+
+func (self splitAllowance) NumHands() int {
+	return 1
+}
+
+func (self splitAllowance) HandsLimit() int {
+	if self.allowsMoreSplits {
+		return 2
+	}
+	return 1
+}
+
+func IsPair(playerHand PlayerHandInterface, rules *house_rules.HouseRules) bool {
+	if playerHand.NumCards() != 2 {
+		return false
+	}
+	card1 := playerHand.GetCard(0)
+	card2 := playerHand.GetCard(1)
+	if rules.SplitOnValueMatch {
+		return cards.CardRankValue[card1.Rank] == cards.CardRankValue[card2.Rank]
+	}
+	return card1.Rank == card2.Rank
+}
+
+// IsFreeDouble() is true when the house puts up the bet for doubling down,
+// as in Free Bet blackjack.
+func IsFreeDouble(playerHand PlayerHandInterface, rules *house_rules.HouseRules) bool {
+	return playerHand.NumDoubleDowns() == 0 &&
+		rules.FreeDouble(playerHand.NumCards(), playerHand.HardCount(), playerHand.SoftCount())
+}
+
+// IsFreeSplit() is true when the house puts up the bet for splitting the pair.
+func IsFreeSplit(playerHand PlayerHandInterface, rules *house_rules.HouseRules) bool {
+	return IsPair(playerHand, rules) && rules.FreeSplit(cards.CardRankValue[playerHand.GetCard(0).Rank])
+}
+
+// IsFiveCardTrick() is true for Pontoon's five card trick, five cards that have not bust.
+func IsFiveCardTrick(playerHand PlayerHandInterface, rules *house_rules.HouseRules) bool {
+	return rules.FiveCardTrick && playerHand.NumCards() >= 5 && playerHand.HardCount() <= 21
+}
+
+// IsCharlie() is true for a hand of the house's Charlie cards that has not bust.
+func IsCharlie(playerHand PlayerHandInterface, rules *house_rules.HouseRules) bool {
+	numCards := rules.CharlieCards()
+	return numCards > 0 && playerHand.NumCards() >= numCards && playerHand.HardCount() <= 21
+}
+
+// the hand is one of the hands from splitting aces
+func IsSplitAces(playerHand PlayerHandInterface) bool {
+	return playerHand.IsFromSplit() && playerHand.GetCard(0).Rank == cards.ACE
+}
+
+// LegalActions() returns the decisions allowed for the hand right now.
+// bankroll is the money the player has left to put on the table,
+// splitting needs another bet the size of the hand's bet, and so does doubling
+// down, unless the house lets the player double for less or the bet is free.
+// A hand that is bust has no decisions left, a five card trick or a Charlie can only stand.
+func LegalActions(
+	playerHand PlayerHandInterface,
+	masterHand PlayerMasterHandInterface,
+	rules *house_rules.HouseRules,
+	bankroll int,
+) []PlayerDecision {
+	if playerHand.NumCards() < 2 || playerHand.SoftCount() > 21 {
+		return []PlayerDecision{}
+	}
+
+	if IsFiveCardTrick(playerHand, rules) || IsCharlie(playerHand, rules) {
+		return []PlayerDecision{STAND}
+	}
+
+	if playerHand.NumDoubleDowns() > 0 {
+		return doubledDownActions(playerHand, rules, bankroll)
+	}
+
+	splitAces := IsSplitAces(playerHand)
+
+	var actions []PlayerDecision = []PlayerDecision{STAND}
+	if !splitAces || rules.HitSplitAces {
+		if playerHand.SoftCount() < rules.PlayerMinimumStand {
+			// below the house's minimum the hand has to take another card
+			actions = []PlayerDecision{}
+		}
+		// otherwise one card to each ace, unless doubling down or re-splitting
+		actions = append(actions, HIT)
+	}
+
+	isFirstDecision := playerHand.NumCards() == 2
+	canCoverBet := bankroll >= playerHand.GetBet()
+
+	canCoverDoubleDown := canCoverBet || (rules.DoubleForLess && bankroll > 0) || IsFreeDouble(playerHand, rules)
+	canDoubleDown := canCoverDoubleDown && (isFirstDecision || rules.DoubleDownAnyNumberOfCards)
+	if splitAces && !rules.DoubleDownAfterSplittingAces {
+		canDoubleDown = false
+	} else if !splitAces && playerHand.IsFromSplit() && !rules.DoubleDownAfterSplit {
+		canDoubleDown = false
+	}
+	if !rules.CanDoubleDownHand(playerHand.HardCount(), playerHand.SoftCount()) {
+		canDoubleDown = false
+	}
+	if canDoubleDown {
+		actions = append(actions, DOUBLE)
+	}
+
+	if !isFirstDecision {
+		return actions
+	}
+
+	canSplit := (canCoverBet || IsFreeSplit(playerHand, rules)) && IsPair(playerHand, rules) && masterHand.NumHands() < masterHand.HandsLimit()
+	if splitAces && !rules.ResplitAces {
+		canSplit = false
+	}
+	if canSplit {
+		actions = append(actions, SPLIT)
+	}
+
+	// surrender must be the first decision, before any splitting.
+	// an early surrender game still has late surrender for a hand that waited.
+	if rules.SurrenderAllowed() && !playerHand.IsFromSplit() {
+		actions = append(actions, SURRENDER)
+	}
+
+	return actions
+}
+
+func IsLegalAction(decision PlayerDecision, legalActions []PlayerDecision) bool {
+	return slices.Contains(legalActions, decision)
+}
+
+// A hand that was doubled down on gets no more cards by hitting, it can only
+// stand, re-double or be rescued, when the house rules allow.  Below the
+// house's minimum, eg a Pontoon buy under 15, it has to hit instead of standing.
+func doubledDownActions(
+	playerHand PlayerHandInterface,
+	rules *house_rules.HouseRules,
+	bankroll int,
+) []PlayerDecision {
+	var actions []PlayerDecision = []PlayerDecision{STAND}
+	if playerHand.SoftCount() < rules.PlayerMinimumStand {
+		actions = []PlayerDecision{HIT}
+	}
+	if playerHand.SoftCount() == 21 {
+		return actions
+	}
+	canCoverBet := bankroll >= playerHand.GetBet() || (rules.DoubleForLess && bankroll > 0)
+	if canCoverBet && playerHand.NumDoubleDowns() <= rules.Redoubles {
+		actions = append(actions, DOUBLE)
+	}
+	if rules.DoubleDownRescue {
+		actions = append(actions, SURRENDER)
+	}
+	return actions
+}
@@ -0,0 +1,143 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// PlayerStrategy is how a seat at the table makes its decisions.
+// Basic strategy is the work horse; the others are here to have something
+// to compare basic strategy against.
+//
+// legalActions comes from LegalActions(), a strategy must pick one of them.
+type PlayerStrategy interface {
+	Name() string
+	DeterminePlay(
+		dealerTopCard cards.Card,
+		playerHand PlayerHandInterface,
+		legalActions []PlayerDecision,
+	) PlayerDecision
+}
+
+const (
+	BASIC_STRATEGY   string = "basic"
+	MIMIC_DEALER     string = "mimic-dealer"
+	NEVER_BUST       string = "never-bust"
+	SPANISH_21       string = "spanish-21"
+	BLACKJACK_SWITCH string = "blackjack-switch"
+	FREE_BET         string = "free-bet"
+	DOUBLE_EXPOSURE  string = "double-exposure"
+	PONTOON          string = "pontoon"
+	// plays the dealer's hole card when it gets a look at it
+	EXPOSED_HOLE_CARD string = "exposed-hole-card"
+)
+
+func PlayerStrategyNames() []string {
+	return []string{BASIC_STRATEGY, MIMIC_DEALER, NEVER_BUST, SPANISH_21, BLACKJACK_SWITCH, FREE_BET, DOUBLE_EXPOSURE, PONTOON, EXPOSED_HOLE_CARD}
+}
+
+func CreatePlayerStrategy(name string, rules *house_rules.HouseRules) (PlayerStrategy, error) {
+	switch name {
+	case BASIC_STRATEGY, "":
+		return CreateBasicStrategy(rules), nil
+	case MIMIC_DEALER:
+		return CreateMimicDealerStrategy(rules), nil
+	case NEVER_BUST:
+		return &NeverBustStrategy{}, nil
+	case SPANISH_21:
+		return CreateSpanish21Strategy(rules), nil
+	case BLACKJACK_SWITCH:
+		return CreateBlackjackSwitchStrategy(rules), nil
+	case FREE_BET:
+		return CreateFreeBetStrategy(rules), nil
+	case DOUBLE_EXPOSURE:
+		return CreateDoubleExposureStrategy(rules), nil
+	case PONTOON:
+		return CreatePontoonStrategy(rules), nil
+	case EXPOSED_HOLE_CARD:
+		return CreateExposedHoleCardStrategy(rules), nil
+	}
+	return nil, fmt.Errorf("unknown player strategy %q", name)
+}
+
+//
+// MimicDealerStrategy
+//
+
+// Play the hand the way the dealer has to: hit until reaching the dealer's standing total.
+type MimicDealerStrategy struct {
+	Rules *house_rules.HouseRules
+}
+
+func CreateMimicDealerStrategy(rules *house_rules.HouseRules) *MimicDealerStrategy {
+	var mimicDealer MimicDealerStrategy = MimicDealerStrategy{
+		Rules: rules,
+	}
+	return &mimicDealer
+}
+
+func (self *MimicDealerStrategy) Name() string {
+	return MIMIC_DEALER
+}
+
+func (self *MimicDealerStrategy) DeterminePlay(
+	dealerTopCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	if !IsLegalAction(HIT, legalActions) {
+		return PlayerDecision(STAND)
+	}
+	if !IsLegalAction(STAND, legalActions) {
+		// eg Pontoon, below the house's minimum
+		return PlayerDecision(HIT)
+	}
+	var cardValues []int = make([]int, playerHand.NumCards())
+	for i := 0; i < playerHand.NumCards(); i++ {
+		cardValues[i] = cards.CardRankValue[playerHand.GetCard(i).Rank]
+	}
+	if self.Rules.DealerPolicy().Hits(cardValues) {
+		return PlayerDecision(HIT)
+	}
+	return PlayerDecision(STAND)
+}
+
+//
+// NeverBustStrategy
+//
+
+// Never take a card that could bust the hand, ie stand on any hard 12 or more.
+type NeverBustStrategy struct{}
+
+func (self *NeverBustStrategy) Name() string {
+	return NEVER_BUST
+}
+
+func (self *NeverBustStrategy) DeterminePlay(
+	dealerTopCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	if !IsLegalAction(HIT, legalActions) {
+		return PlayerDecision(STAND)
+	}
+	if !IsLegalAction(STAND, legalActions) {
+		// eg Pontoon, below the house's minimum
+		return PlayerDecision(HIT)
+	}
+	hardCount := playerHand.HardCount()
+	softCount := playerHand.SoftCount()
+	if hardCount < softCount && softCount <= 21 {
+		// a soft hand can always take another card
+		if softCount <= 17 {
+			return PlayerDecision(HIT)
+		}
+		return PlayerDecision(STAND)
+	}
+	if hardCount <= 11 {
+		return PlayerDecision(HIT)
+	}
+	return PlayerDecision(STAND)
+}
@@ -0,0 +1,46 @@
+package strategy
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// PontoonStrategy plays Pontoon by expected value, see expectedValues, which
+// counts the ties the dealer wins, the twisting to 15 and the five card trick
+// that makes a small hand of four cards worth twisting on.  A pair is split
+// when the two hands are worth more than the pair played as a total.
+type PontoonStrategy struct {
+	Rules  *house_rules.HouseRules
+	played *BlackjackSwitchStrategy
+}
+
+func CreatePontoonStrategy(rules *house_rules.HouseRules) *PontoonStrategy {
+	var pontoonStrategy PontoonStrategy = PontoonStrategy{
+		Rules:  rules,
+		played: CreateBlackjackSwitchStrategy(rules),
+	}
+	return &pontoonStrategy
+}
+
+func (self *PontoonStrategy) Name() string {
+	return PONTOON
+}
+
+func (self *PontoonStrategy) DeterminePlay(
+	dealerTopCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	if len(legalActions) == 1 {
+		return legalActions[0]
+	}
+
+	var values *expectedValues = self.played.handValues(dealerTopCard)
+	if IsLegalAction(SPLIT, legalActions) {
+		pairValue := cards.CardRankValue[playerHand.GetCard(0).Rank]
+		if values.split(pairValue) > values.twoCards(pairValue, pairValue, playerHand.IsFromSplit()) {
+			return PlayerDecision(SPLIT)
+		}
+	}
+	return values.decide(playerHand, legalActions)
+}
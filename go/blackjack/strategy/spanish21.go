@@ -0,0 +1,247 @@
+package strategy
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// Spanish 21 basic strategy, multi deck, dealer hits soft 17, doubling down
+// on any number of cards, late surrender, re-doubling and double down rescue.
+// Without the 10s the dealer busts less, so there is less standing on stiffs
+// and less doubling down than in basic strategy.
+// The tables are indexed by the dealer top card's rank, Ace => 1 ... King => 13.
+
+var spanish_21_hard_total_decision = [22][14]Decision{
+	//0   A   2   3   4   5   6   7   8   9  10   J   Q   K
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 1  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 2  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 3  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// 4  x  dealer top card
+	{NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H},
+	// 5  x  dealer top card
+	{NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H},
+	// 6  x  dealer top card
+	{NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H},
+	// 7  x  dealer top card
+	{NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H},
+	// 8  x  dealer top card
+	{NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H},
+	// 9  x  dealer top card
+	{NO,  H,  H,  H,  H,  H, Dh,  H,  H,  H,  H,  H,  H,  H},
+	// 10  x  dealer top card
+	{NO,  H, Dh, Dh, Dh, Dh, Dh, Dh,  H,  H,  H,  H,  H,  H},
+	// 11  x  dealer top card
+	{NO, Dh, Dh, Dh, Dh, Dh, Dh, Dh, Dh, Dh, Dh, Dh, Dh, Dh},
+	// 12  x  dealer top card
+	{NO,  H,  H,  H,  S,  S,  S,  H,  H,  H,  H,  H,  H,  H},
+	// 13  x  dealer top card
+	{NO,  H,  S,  S,  S,  S,  S,  H,  H,  H,  H,  H,  H,  H},
+	// 14  x  dealer top card
+	{NO,  H,  S,  S,  S,  S,  S,  H,  H,  H,  H,  H,  H,  H},
+	// 15  x  dealer top card
+	{NO,  H,  S,  S,  S,  S,  S,  H,  H,  H,  H,  H,  H,  H},
+	// 16  x  dealer top card
+	{NO,  H,  S,  S,  S,  S,  S,  H,  H,  H,  H,  H,  H,  H},
+	// 17  x  dealer top card
+	{NO, Us,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	// 18  x  dealer top card
+	{NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	// 19  x  dealer top card
+	{NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	// 20  x  dealer top card
+	{NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	// 21  x  dealer top card
+	{NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	//0   A   2   3   4   5   6   7   8   9  10   J   Q   K
+}
+
+// The bonus for a five card 21 and up is worth chasing: a stiff standing against
+// a 2 thru 6 in the table above is hit once it is made of this many cards.
+// zero => always stand
+var spanish_21_hit_stiff_with_cards = [22][14]int{
+	//0 A  2  3  4  5  6  7  8  9 10  J  Q  K
+	12: {0, 0, 0, 0, 4, 5, 5, 0, 0, 0, 0, 0, 0, 0},
+	13: {0, 0, 4, 5, 6, 6, 6, 0, 0, 0, 0, 0, 0, 0},
+	14: {0, 0, 4, 5, 6, 6, 6, 0, 0, 0, 0, 0, 0, 0},
+	15: {0, 0, 4, 5, 6, 6, 6, 0, 0, 0, 0, 0, 0, 0},
+	16: {0, 0, 5, 6, 6, 6, 6, 0, 0, 0, 0, 0, 0, 0},
+}
+
+var spanish_21_soft_total_decision = [22][14]Decision{
+	//0   A   2   3   4   5   6   7   8   9  10   J   Q   K
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 1  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 2  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 3  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 4  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 5  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 6  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 7  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 8  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 9  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 10  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 11  x  dealer top card
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// soft total: 12  x  dealer top card
+	{NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H},
+	// soft total: 13  x  dealer top card
+	{NO,  H,  H,  H,  H,  H, Dh,  H,  H,  H,  H,  H,  H,  H},
+	// soft total: 14  x  dealer top card
+	{NO,  H,  H,  H,  H, Dh, Dh,  H,  H,  H,  H,  H,  H,  H},
+	// soft total: 15  x  dealer top card
+	{NO,  H,  H,  H, Dh, Dh, Dh,  H,  H,  H,  H,  H,  H,  H},
+	// soft total: 16  x  dealer top card
+	{NO,  H,  H,  H, Dh, Dh, Dh,  H,  H,  H,  H,  H,  H,  H},
+	// soft total: 17  x  dealer top card
+	{NO,  H,  H,  H, Dh, Dh, Dh,  H,  H,  H,  H,  H,  H,  H},
+	// soft total: 18  x  dealer top card
+	{NO,  H,  S,  S, Ds, Ds, Ds,  S,  S,  H,  H,  H,  H,  H},
+	// soft total: 19  x  dealer top card
+	{NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	// soft total: 20  x  dealer top card
+	{NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	// soft total: 21  x  dealer top card
+	{NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	//0   A   2   3   4   5   6   7   8   9  10   J   Q   K
+}
+
+// Only the SP decisions are used, the others are played as hard or soft totals.
+var spanish_21_pairs_decision = [14][14]Decision{
+	//0   A   2   3   4   5   6   7   8   9  10   J   Q   K
+	{NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO, NO},
+	// player pair card: Ace  x  dealer top card
+	{NO, SP, SP, SP, SP, SP, SP, SP, SP, SP, SP, SP, SP, SP},
+	// player pair card: 2  x  dealer top card
+	{NO,  H, SP, SP, SP, SP, SP, SP,  H,  H,  H,  H,  H,  H},
+	// player pair card: 3  x  dealer top card
+	{NO,  H, SP, SP, SP, SP, SP, SP, SP,  H,  H,  H,  H,  H},
+	// player pair card: 4  x  dealer top card
+	{NO,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H,  H},
+	// player pair card: 5  x  dealer top card
+	{NO,  H, Dh, Dh, Dh, Dh, Dh, Dh,  H,  H,  H,  H,  H,  H},
+	// player pair card: 6  x  dealer top card
+	{NO,  H, SP, SP, SP, SP, SP,  H,  H,  H,  H,  H,  H,  H},
+	// player pair card: 7  x  dealer top card
+	{NO,  H, SP, SP, SP, SP, SP, SP,  H,  H,  H,  H,  H,  H},
+	// player pair card: 8  x  dealer top card
+	{NO, SP, SP, SP, SP, SP, SP, SP, SP, SP, SP, SP, SP, SP},
+	// player pair card: 9  x  dealer top card
+	{NO,  S,  S, SP, SP, SP, SP,  S, SP, SP,  S,  S,  S,  S},
+	// player pair card: 10  x  dealer top card
+	{NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	// player pair card: J  x  dealer top card
+	{NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	// player pair card: Q  x  dealer top card
+	{NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	// player pair card: K  x  dealer top card
+	{NO,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S,  S},
+	//0   A   2   3   4   5   6   7   8   9  10   J   Q   K
+}
+
+func GetSpanish21HardTotalDecision(playerHand PlayerHandInterface, dealerTopCard cards.CardRank) Decision {
+	hardCount := playerHand.HardCount()
+	var decision Decision = spanish_21_hard_total_decision[hardCount][dealerTopCard]
+	hitWithCards := spanish_21_hit_stiff_with_cards[hardCount][dealerTopCard]
+	if decision == Decision(S) && hitWithCards > 0 && playerHand.NumCards() >= hitWithCards {
+		decision = Decision(H)
+	}
+	return decision
+}
+
+func GetSpanish21SoftTotalDecision(softTotal int, dealerTopCard cards.CardRank) Decision {
+	return spanish_21_soft_total_decision[softTotal][dealerTopCard]
+}
+
+func GetSpanish21PairSplitDecision(playerPairRank cards.CardRank, dealerTopCard cards.CardRank) Decision {
+	return spanish_21_pairs_decision[playerPairRank][dealerTopCard]
+}
+
+// Spanish21Strategy plays the tables above, best with the Spanish 21 house rules.
+type Spanish21Strategy struct {
+	Rules *house_rules.HouseRules
+}
+
+func CreateSpanish21Strategy(rules *house_rules.HouseRules) *Spanish21Strategy {
+	var spanish21Strategy Spanish21Strategy = Spanish21Strategy{
+		Rules: rules,
+	}
+	return &spanish21Strategy
+}
+
+func (self *Spanish21Strategy) Name() string {
+	return SPANISH_21
+}
+
+func (self *Spanish21Strategy) decision(dealerTopCard cards.Card, playerHand PlayerHandInterface) Decision {
+	hardCount := playerHand.HardCount()
+	softCount := playerHand.SoftCount()
+	if hardCount < softCount && softCount <= 21 {
+		return GetSpanish21SoftTotalDecision(softCount, dealerTopCard.Rank)
+	}
+	return GetSpanish21HardTotalDecision(playerHand, dealerTopCard.Rank)
+}
+
+func (self *Spanish21Strategy) DeterminePlay(
+	dealerTopCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	if len(legalActions) == 1 {
+		return legalActions[0]
+	}
+	if playerHand.NumDoubleDowns() > 0 {
+		return self.playDoubledDown(dealerTopCard, playerHand, legalActions)
+	}
+
+	if IsLegalAction(SPLIT, legalActions) {
+		var pairRank cards.CardRank = playerHand.GetCard(0).Rank
+		if cards.CardRankValue[pairRank] == 10 {
+			pairRank = cards.CardRank(10)
+		}
+		if GetSpanish21PairSplitDecision(pairRank, dealerTopCard.Rank) == Decision(SP) {
+			return PlayerDecision(SPLIT)
+		}
+	}
+
+	return convertToPlayerDecision(self.decision(dealerTopCard, playerHand), legalActions)
+}
+
+// A doubled down hand can stand, re-double or be rescued.
+// Re-double where the tables double down, eg a doubled 6 that drew a 5.
+// Rescue a stiff against an 8 or better, standing on it loses more than
+// the original bet that the rescue gives up.
+func (self *Spanish21Strategy) playDoubledDown(
+	dealerTopCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	var decision Decision = self.decision(dealerTopCard, playerHand)
+	if (decision == Decision(Dh) || decision == Decision(Ds)) && IsLegalAction(DOUBLE, legalActions) {
+		return PlayerDecision(DOUBLE)
+	}
+
+	total := playerHand.HardCount()
+	if playerHand.SoftCount() <= 21 {
+		total = playerHand.SoftCount()
+	}
+	dealerValue := cards.CardRankValue[dealerTopCard.Rank]
+	strongDealer := dealerValue == 1 || dealerValue >= 8
+	if total < 17 && strongDealer && IsLegalAction(SURRENDER, legalActions) {
+		return PlayerDecision(SURRENDER)
+	}
+	return PlayerDecision(STAND)
+}
@@ -0,0 +1,49 @@
+package strategy
+
+import (
+	"slices"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+)
+
+// Early surrender basic strategy, multi deck, dealer stands on soft 17.
+// Before the dealer checks for a natural there is a lot more to give up on,
+// the hands below are surrendered early against the dealer's Ace or 10.
+// Pairs are played by their hard total, eg 8-8 is surrendered as a hard 16
+// and 3-3 against an Ace as a hard 6.  Soft hands are never surrendered.
+//
+// Late surrender is in the hard total table, as Uh and Us.
+
+// dealer top card value (Ace => 1) => player hard totals
+var early_surrender_hard_totals = map[int][]int{
+	1:  {5, 6, 7, 12, 13, 14, 15, 16, 17},
+	10: {14, 15, 16},
+}
+
+func GetEarlySurrenderDecision(
+	playerHand PlayerHandInterface,
+	dealerTopCard cards.CardRank,
+) bool {
+	if playerHand.NumCards() != 2 {
+		return false
+	}
+	hardCount := playerHand.HardCount()
+	softCount := playerHand.SoftCount()
+	if hardCount < softCount && softCount <= 21 {
+		return false
+	}
+	totals, ok := early_surrender_hard_totals[cards.CardRankValue[dealerTopCard]]
+	return ok && slices.Contains(totals, hardCount)
+}
+
+// SurrenderEarly() is called before the dealer checks for a natural,
+// only when the house rules allow early surrender against the top card.
+func (self *BasicStrategy) SurrenderEarly(
+	dealerTopCard cards.Card,
+	playerHand PlayerHandInterface,
+) bool {
+	if playerHand.IsFromSplit() {
+		return false
+	}
+	return GetEarlySurrenderDecision(playerHand, dealerTopCard.Rank)
+}
@@ -0,0 +1,79 @@
+package strategy
+
+import (
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// Blackjack Switch needs its own strategy twice over: whether to switch the
+// second cards of the two hands, and how to play a hand when a dealer 22
+// pushes, which makes standing on a stiff worth less than in blackjack.
+//
+// Both come from the expected value of a hand, see expectedValues, given
+// there is no dealer natural under the top card.  The two hands are
+// switched when the switched hands are worth more.  Pairs are valued as
+// their totals and split as in basic strategy.
+
+type BlackjackSwitchStrategy struct {
+	Rules *house_rules.HouseRules
+	// indexed by the dealer top card's value, Ace => 1, worked out when first needed
+	values [11]*expectedValues
+}
+
+func CreateBlackjackSwitchStrategy(rules *house_rules.HouseRules) *BlackjackSwitchStrategy {
+	var switchStrategy BlackjackSwitchStrategy = BlackjackSwitchStrategy{
+		Rules: rules,
+	}
+	return &switchStrategy
+}
+
+func (self *BlackjackSwitchStrategy) Name() string {
+	return BLACKJACK_SWITCH
+}
+
+func (self *BlackjackSwitchStrategy) handValues(dealerTopCard cards.Card) *expectedValues {
+	dealerValue := cards.CardRankValue[dealerTopCard.Rank]
+	if self.values[dealerValue] == nil {
+		self.values[dealerValue] = createExpectedValues(self.Rules, []int{dealerValue})
+	}
+	return self.values[dealerValue]
+}
+
+// SwitchCards() switches when the switched hands are worth more than the hands as dealt.
+func (self *BlackjackSwitchStrategy) SwitchCards(
+	dealerTopCard cards.Card,
+	firstHand PlayerHandInterface,
+	secondHand PlayerHandInterface,
+) bool {
+	var values *expectedValues = self.handValues(dealerTopCard)
+	first1 := cards.CardRankValue[firstHand.GetCard(0).Rank]
+	first2 := cards.CardRankValue[firstHand.GetCard(1).Rank]
+	second1 := cards.CardRankValue[secondHand.GetCard(0).Rank]
+	second2 := cards.CardRankValue[secondHand.GetCard(1).Rank]
+
+	asDealt := values.twoCards(first1, first2, false) + values.twoCards(second1, second2, false)
+	switched := values.twoCards(first1, second2, false) + values.twoCards(second1, first2, false)
+	return switched > asDealt
+}
+
+func (self *BlackjackSwitchStrategy) DeterminePlay(
+	dealerTopCard cards.Card,
+	playerHand PlayerHandInterface,
+	legalActions []PlayerDecision,
+) PlayerDecision {
+	if len(legalActions) == 1 {
+		return legalActions[0]
+	}
+
+	if IsLegalAction(SPLIT, legalActions) {
+		var pairRank cards.CardRank = playerHand.GetCard(0).Rank
+		if cards.CardRankValue[pairRank] == 10 {
+			pairRank = cards.CardRank(10)
+		}
+		if GetPairSplitDecision(pairRank, dealerTopCard.Rank) == Decision(SP) {
+			return PlayerDecision(SPLIT)
+		}
+	}
+
+	return self.handValues(dealerTopCard).decide(playerHand, legalActions)
+}
@@ -0,0 +1,278 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
+)
+
+// expectedValues works out what a hand is worth against the dealer, per unit
+// bet, for an infinite deck with the house rules: the odds of the dealer's
+// final counts, then the best of standing, hitting and doubling down for every
+// player total.  Blackjack Switch, Free Bet and Pontoon are played by it, and
+// the Double Exposure tables were made with it.
+
+// the dealer's final count: 17 thru 21, 22, or more, below 17 only when
+// the dealer policy stands on it
+const max_dealer_count int = 31
+
+type dealerCounts [max_dealer_count + 1]float64
+
+// the number of cards matters up to Pontoon's five card trick, or a seven card Charlie
+const max_hand_cards int = 7
+
+type expectedValues struct {
+	rules  *house_rules.HouseRules
+	dealer dealerCounts
+	// indexed by hard total, whether an Ace still counts as 11, then the number of cards
+	played     [22][2][max_hand_cards + 1]float64
+	playedDone [22][2][max_hand_cards + 1]bool
+}
+
+// createExpectedValues() is for the dealer cards the players can see, Ace => 1:
+// the top card, when the dealer has checked for a natural, or both cards.
+func createExpectedValues(rules *house_rules.HouseRules, dealerCardValues []int) *expectedValues {
+	var values expectedValues = expectedValues{
+		rules: rules,
+	}
+	if len(dealerCardValues) == 1 {
+		values.playDealer(dealerCardValues[0])
+	} else {
+		values.drawDealer(rules.DealerPolicy(), dealerCardValues, 1.0)
+	}
+	return &values
+}
+
+// createExpectedValuesWithHoleCards() is for a hole card the players know
+// something about, eg from a dealer's tell: its value is one of holeValues,
+// Ace => 1, and it does not make a natural, the dealer has checked for one.
+func createExpectedValuesWithHoleCards(rules *house_rules.HouseRules, dealerValue int, holeValues []int) *expectedValues {
+	var values expectedValues = expectedValues{
+		rules: rules,
+	}
+	var policy house_rules.DealerPolicy = rules.DealerPolicy()
+	isNatural := func(holeValue int) bool {
+		return dealerValue+holeValue == 11 && (dealerValue == 1 || holeValue == 1)
+	}
+	total := 0.0
+	for i := 0; i < len(holeValues); i++ {
+		if !isNatural(holeValues[i]) {
+			total += values.cardOdds(holeValues[i])
+		}
+	}
+	for i := 0; i < len(holeValues); i++ {
+		if !isNatural(holeValues[i]) {
+			values.drawDealer(policy, []int{dealerValue, holeValues[i]}, values.cardOdds(holeValues[i])/total)
+		}
+	}
+	return &values
+}
+
+// the chance of drawing a card of each value, Ace => 1
+func (self *expectedValues) cardOdds(value int) float64 {
+	cardsPerDeck := float64(self.rules.CardsPerDeck())
+	if value == 10 {
+		return (cardsPerDeck - 36) / cardsPerDeck
+	}
+	return 4 / cardsPerDeck
+}
+
+// playDealer() adds up the dealer's final counts, the hole card can not
+// make a natural since the dealer has already checked for one.
+func (self *expectedValues) playDealer(dealerValue int) {
+	var policy house_rules.DealerPolicy = self.rules.DealerPolicy()
+	noNatural := 1.0
+	for holeValue := 1; holeValue <= 10; holeValue++ {
+		if dealerValue+holeValue == 11 && (dealerValue == 1 || holeValue == 1) {
+			noNatural -= self.cardOdds(holeValue)
+		}
+	}
+	for holeValue := 1; holeValue <= 10; holeValue++ {
+		if dealerValue+holeValue == 11 && (dealerValue == 1 || holeValue == 1) {
+			continue
+		}
+		self.drawDealer(policy, []int{dealerValue, holeValue}, self.cardOdds(holeValue)/noNatural)
+	}
+}
+
+func (self *expectedValues) drawDealer(policy house_rules.DealerPolicy, cardValues []int, odds float64) {
+	hardTotal, softTotal := house_rules.HandTotals(cardValues)
+	if hardTotal > 21 || !policy.Hits(cardValues) {
+		count := softTotal
+		if hardTotal > 21 {
+			count = hardTotal
+		}
+		self.dealer[min(count, max_dealer_count)] += odds
+		return
+	}
+	for value := 1; value <= 10; value++ {
+		self.drawDealer(policy, append(cardValues, value), odds*self.cardOdds(value))
+	}
+}
+
+func handCount(hardTotal int, softAce bool) int {
+	if softAce && hardTotal+10 <= 21 {
+		return hardTotal + 10
+	}
+	return hardTotal
+}
+
+func hasSoftAce(playerHand PlayerHandInterface) bool {
+	return playerHand.SoftCount() > playerHand.HardCount() && playerHand.SoftCount() <= 21
+}
+
+// stand() is what standing on the count is worth
+func (self *expectedValues) stand(count int) float64 {
+	if count == 21 && self.rules.Player21AlwaysWins {
+		return 1
+	}
+	value := 0.0
+	for dealerCount := 0; dealerCount <= max_dealer_count; dealerCount++ {
+		odds := self.dealer[dealerCount]
+		if dealerCount == 22 && self.rules.Dealer22Pushes {
+			continue
+		} else if dealerCount > 21 || dealerCount < count {
+			value += odds
+		} else if dealerCount > count || self.rules.DealerWinsTies {
+			value -= odds
+		}
+	}
+	return value
+}
+
+// autoWin() is what a hand of numCards that has not bust wins whatever the
+// dealer has, a five card trick or a Charlie, 0 => it has to beat the dealer
+func (self *expectedValues) autoWin(numCards int) float64 {
+	if self.rules.FiveCardTrick && numCards >= 5 {
+		return self.rules.FiveCardTrickPayout.Ratio()
+	}
+	return self.rules.CharliePayout(numCards).Ratio()
+}
+
+// final() is what the hand is worth when it takes no more cards, a five card trick wins
+func (self *expectedValues) final(hardTotal int, softAce bool, numCards int) float64 {
+	if payout := self.autoWin(numCards); payout > 0 {
+		return payout
+	}
+	return self.stand(handCount(hardTotal, softAce))
+}
+
+// standOn() is final() for a hand that chooses to stand, which it can not
+// do below the house's minimum, a doubled down hand has no choice.
+func (self *expectedValues) standOn(hardTotal int, softAce bool, numCards int) float64 {
+	if handCount(hardTotal, softAce) < self.rules.PlayerMinimumStand && self.autoWin(numCards) == 0 {
+		return math.Inf(-1)
+	}
+	return self.final(hardTotal, softAce, numCards)
+}
+
+// hit() is what taking one more card is worth, then playing on at its best
+func (self *expectedValues) hit(hardTotal int, softAce bool, numCards int) float64 {
+	value := 0.0
+	for card := 1; card <= 10; card++ {
+		if hardTotal+card > 21 {
+			value -= self.cardOdds(card)
+		} else {
+			value += self.cardOdds(card) * self.play(hardTotal+card, softAce || card == 1, numCards+1)
+		}
+	}
+	return value
+}
+
+// doubleDown() takes one card for twice the bet, and hits on below the
+// house's minimum, eg a Pontoon buy under 15
+func (self *expectedValues) doubleDown(hardTotal int, softAce bool, numCards int) float64 {
+	value := 0.0
+	for card := 1; card <= 10; card++ {
+		if hardTotal+card > 21 {
+			value -= 2 * self.cardOdds(card)
+		} else if handCount(hardTotal+card, softAce || card == 1) < self.rules.PlayerMinimumStand {
+			value += 2 * self.cardOdds(card) * self.play(hardTotal+card, softAce || card == 1, numCards+1)
+		} else {
+			value += 2 * self.cardOdds(card) * self.final(hardTotal+card, softAce || card == 1, numCards+1)
+		}
+	}
+	return value
+}
+
+// play() is the better of standing and hitting, a five card trick or a Charlie is done
+func (self *expectedValues) play(hardTotal int, softAce bool, numCards int) float64 {
+	numCards = min(numCards, max_hand_cards)
+	if self.autoWin(numCards) > 0 {
+		return self.final(hardTotal, softAce, numCards)
+	}
+	ace := 0
+	if softAce {
+		ace = 1
+	}
+	if !self.playedDone[hardTotal][ace][numCards] {
+		self.played[hardTotal][ace][numCards] = max(self.standOn(hardTotal, softAce, numCards), self.hit(hardTotal, softAce, numCards))
+		self.playedDone[hardTotal][ace][numCards] = true
+	}
+	return self.played[hardTotal][ace][numCards]
+}
+
+func (self *expectedValues) canDoubleDown(hardTotal int, softAce bool) bool {
+	return self.rules.CanDoubleDownHand(hardTotal, handCount(hardTotal, softAce))
+}
+
+// twoCards() is what a two card hand is worth, from its card values
+func (self *expectedValues) twoCards(value1 int, value2 int, fromSplit bool) float64 {
+	hardTotal := value1 + value2
+	softAce := value1 == 1 || value2 == 1
+	if handCount(hardTotal, softAce) == 21 && !fromSplit {
+		return self.rules.NaturalBlackjackPayout.Ratio()
+	}
+	if fromSplit && value1 == 1 && !self.rules.HitSplitAces {
+		return self.stand(handCount(hardTotal, softAce))
+	}
+	value := self.play(hardTotal, softAce, 2)
+	if self.canDoubleDown(hardTotal, softAce) && (!fromSplit || self.rules.DoubleDownAfterSplit) {
+		value = max(value, self.doubleDown(hardTotal, softAce, 2))
+	}
+	return value
+}
+
+// split() is what splitting a pair of this value is worth, two hands
+// of one card each, without re-splitting
+func (self *expectedValues) split(pairValue int) float64 {
+	value := 0.0
+	for card := 1; card <= 10; card++ {
+		value += self.cardOdds(card) * self.twoCards(pairValue, card, true)
+	}
+	return 2 * value
+}
+
+// decideWithSplit() is decide() for a hand that can split too, a pair is
+// split when the two hands are worth more than the pair's total
+func (self *expectedValues) decideWithSplit(playerHand PlayerHandInterface, legalActions []PlayerDecision) PlayerDecision {
+	if IsLegalAction(SPLIT, legalActions) {
+		pairValue := cards.CardRankValue[playerHand.GetCard(0).Rank]
+		if self.split(pairValue) > self.twoCards(pairValue, pairValue, false) {
+			return PlayerDecision(SPLIT)
+		}
+	}
+	return self.decide(playerHand, legalActions)
+}
+
+// decide() picks the legal decision worth the most, other than splitting
+func (self *expectedValues) decide(playerHand PlayerHandInterface, legalActions []PlayerDecision) PlayerDecision {
+	hardTotal := playerHand.HardCount()
+	softAce := hasSoftAce(playerHand)
+	numCards := playerHand.NumCards()
+	stand := self.standOn(hardTotal, softAce, numCards)
+	hit := self.hit(hardTotal, softAce, numCards)
+	best := max(stand, hit)
+
+	if IsLegalAction(DOUBLE, legalActions) && self.doubleDown(hardTotal, softAce, numCards) > best {
+		return PlayerDecision(DOUBLE)
+	}
+	if IsLegalAction(SURRENDER, legalActions) && best < -0.5 {
+		return PlayerDecision(SURRENDER)
+	}
+	if hit > stand && IsLegalAction(HIT, legalActions) {
+		return PlayerDecision(HIT)
+	}
+	return PlayerDecision(STAND)
+}
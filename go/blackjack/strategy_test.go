@@ -5,6 +5,7 @@ import (
 
 	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/cards"
 	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/game"
+	house_rules "github.com/bnwest/GoBlackjackSimulation/go/blackjack/rules"
 	"github.com/bnwest/GoBlackjackSimulation/go/blackjack/strategy"
 
 	"github.com/stretchr/testify/assert"
@@ -215,3 +216,496 @@ func TestPlayerHandInterface(t *testing.T) {
 	assert.Equal(t, 12, playerHandInterface.SoftCount(), "PlayerHandInterface SoftCount() failed")
 	assert.Equal(t, playerCard1, playerHandInterface.GetCard(0), "PlayerHandInterface GetCard() failed")
 }
+
+func createLegalActionsHand(fromSplit bool, bet int, ranks ...cards.CardRank) *game.PlayerHand {
+	var playerHand *game.PlayerHand = game.CreatePlayerHand(fromSplit, bet)
+	for i := 0; i < len(ranks); i++ {
+		playerHand.AddCard(cards.Card{Suite: cards.SPADES, Rank: ranks[i]})
+	}
+	return playerHand
+}
+
+func createLegalActionsMasterHand(numHands int) *game.PlayerMasterHand {
+	var masterHand *game.PlayerMasterHand = game.CreatePlayerMasterHand()
+	for i := 0; i < numHands; i++ {
+		masterHand.AddStartHand(2)
+	}
+	return masterHand
+}
+
+func TestLegalActions(t *testing.T) {
+	const (
+		S  = strategy.STAND
+		H  = strategy.HIT
+		D  = strategy.DOUBLE
+		SP = strategy.SPLIT
+		U  = strategy.SURRENDER
+	)
+	const bet int = 10
+
+	testCases := []struct {
+		name      string
+		rules     func(rules *house_rules.HouseRules)
+		fromSplit bool
+		ranks     []cards.CardRank
+		numHands  int
+		bankroll  int
+		expected  []strategy.PlayerDecision
+	}{
+		{"pair of eights", nil, false, []cards.CardRank{cards.EIGHT, cards.EIGHT}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, SP, U}},
+		{"eleven doubles", nil, false, []cards.CardRank{cards.FIVE, cards.SIX}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, U}},
+		{"king ten is not a pair", func(rules *house_rules.HouseRules) { rules.SplitOnValueMatch = false }, false, []cards.CardRank{cards.KING, cards.TEN}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, U}},
+		{"king ten is a pair on value", func(rules *house_rules.HouseRules) { rules.SplitOnValueMatch = true }, false, []cards.CardRank{cards.KING, cards.TEN}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, SP, U}},
+		{"early surrender has late surrender too", func(rules *house_rules.HouseRules) { rules.Surrender = house_rules.SURRENDER_EARLY }, false, []cards.CardRank{cards.KING, cards.SIX}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, U}},
+		{"no surrender", func(rules *house_rules.HouseRules) { rules.Surrender = house_rules.SURRENDER_NONE }, false, []cards.CardRank{cards.KING, cards.SIX}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D}},
+		{"soft hand doubles", nil, false, []cards.CardRank{cards.ACE, cards.SIX}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, U}},
+		{"fresh aces", nil, false, []cards.CardRank{cards.ACE, cards.ACE}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, SP, U}},
+		{"split aces stand", nil, true, []cards.CardRank{cards.ACE, cards.NINE}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S}},
+		{"split aces play on", func(rules *house_rules.HouseRules) { rules.HitSplitAces = true }, true, []cards.CardRank{cards.ACE, cards.FIVE}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H}},
+		{"split aces double", func(rules *house_rules.HouseRules) { rules.DoubleDownAfterSplittingAces = true }, true, []cards.CardRank{cards.ACE, cards.FIVE}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, D}},
+		{"split aces double without DAS", func(rules *house_rules.HouseRules) {
+			rules.DoubleDownAfterSplittingAces = true
+			rules.DoubleDownAfterSplit = false
+		}, true, []cards.CardRank{cards.ACE, cards.FIVE}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, D}},
+		{"split aces no resplit", nil, true, []cards.CardRank{cards.ACE, cards.ACE}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S}},
+		{"split aces resplit", func(rules *house_rules.HouseRules) { rules.ResplitAces = true }, true, []cards.CardRank{cards.ACE, cards.ACE}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, SP}},
+		{"split aces all three", func(rules *house_rules.HouseRules) {
+			rules.ResplitAces = true
+			rules.HitSplitAces = true
+			rules.DoubleDownAfterSplittingAces = true
+		}, true, []cards.CardRank{cards.ACE, cards.ACE}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, SP}},
+		{"hit split aces three cards", func(rules *house_rules.HouseRules) { rules.HitSplitAces = true }, true, []cards.CardRank{cards.ACE, cards.TWO, cards.THREE}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H}},
+		{"resplit aces at the limit", func(rules *house_rules.HouseRules) { rules.ResplitAces = true }, true, []cards.CardRank{cards.ACE, cards.ACE}, house_rules.SPLITS_PER_HAND + 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S}},
+		{"DAS does not double split aces", func(rules *house_rules.HouseRules) { rules.DoubleDownAfterSplit = true }, true, []cards.CardRank{cards.ACE, cards.SIX}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S}},
+		{"split eights with DAS", func(rules *house_rules.HouseRules) { rules.DoubleDownAfterSplit = true }, true, []cards.CardRank{cards.EIGHT, cards.THREE}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D}},
+		{"split eights without DAS", func(rules *house_rules.HouseRules) { rules.DoubleDownAfterSplit = false }, true, []cards.CardRank{cards.EIGHT, cards.THREE}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H}},
+		{"split eights again", nil, true, []cards.CardRank{cards.EIGHT, cards.EIGHT}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, SP}},
+		{"master hand at its limit", nil, true, []cards.CardRank{cards.EIGHT, cards.EIGHT}, house_rules.SPLITS_PER_HAND + 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D}},
+		{"reno doubles 9", func(rules *house_rules.HouseRules) { rules.DoubleDownOn = house_rules.DOUBLE_DOWN_9_TO_11 }, false, []cards.CardRank{cards.FIVE, cards.FOUR}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, U}},
+		{"reno does not double 12", func(rules *house_rules.HouseRules) { rules.DoubleDownOn = house_rules.DOUBLE_DOWN_9_TO_11 }, false, []cards.CardRank{cards.FIVE, cards.SEVEN}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, U}},
+		{"reno doubles soft 19 as a 9", func(rules *house_rules.HouseRules) { rules.DoubleDownOn = house_rules.DOUBLE_DOWN_9_TO_11 }, false, []cards.CardRank{cards.ACE, cards.EIGHT}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, U}},
+		{"reno hard only", func(rules *house_rules.HouseRules) {
+			rules.DoubleDownOn = house_rules.DOUBLE_DOWN_9_TO_11
+			rules.DoubleDownSoftHands = false
+		}, false, []cards.CardRank{cards.ACE, cards.EIGHT}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, U}},
+		{"european does not double 9", func(rules *house_rules.HouseRules) { rules.DoubleDownOn = house_rules.DOUBLE_DOWN_10_TO_11 }, false, []cards.CardRank{cards.FIVE, cards.FOUR}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, U}},
+		{"european doubles 10", func(rules *house_rules.HouseRules) { rules.DoubleDownOn = house_rules.DOUBLE_DOWN_10_TO_11 }, false, []cards.CardRank{cards.SIX, cards.FOUR}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D, U}},
+		{"no soft doubles", func(rules *house_rules.HouseRules) { rules.DoubleDownSoftHands = false }, false, []cards.CardRank{cards.ACE, cards.SIX}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, U}},
+		{"double after hit", func(rules *house_rules.HouseRules) { rules.DoubleDownAnyNumberOfCards = true }, false, []cards.CardRank{cards.TWO, cards.THREE, cards.FOUR}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H, D}},
+		{"double after hit reno", func(rules *house_rules.HouseRules) {
+			rules.DoubleDownAnyNumberOfCards = true
+			rules.DoubleDownOn = house_rules.DOUBLE_DOWN_9_TO_11
+		}, false, []cards.CardRank{cards.TWO, cards.THREE, cards.SEVEN}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H}},
+		{"double after hit broke", func(rules *house_rules.HouseRules) { rules.DoubleDownAnyNumberOfCards = true }, false, []cards.CardRank{cards.TWO, cards.THREE, cards.FOUR}, 1, bet - 1, []strategy.PlayerDecision{S, H}},
+		{"three cards", nil, false, []cards.CardRank{cards.TWO, cards.THREE, cards.FOUR}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H}},
+		{"bust", nil, false, []cards.CardRank{cards.KING, cards.SIX, cards.NINE}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{}},
+		{"one card", nil, true, []cards.CardRank{cards.EIGHT}, 2, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{}},
+		{"broke", nil, false, []cards.CardRank{cards.FIVE, cards.FIVE}, 1, 0, []strategy.PlayerDecision{S, H, U}},
+		{"just enough", nil, false, []cards.CardRank{cards.FIVE, cards.FIVE}, 1, bet, []strategy.PlayerDecision{S, H, D, SP, U}},
+		{"one short", nil, false, []cards.CardRank{cards.FIVE, cards.FIVE}, 1, bet - 1, []strategy.PlayerDecision{S, H, U}},
+		{"double for less", func(rules *house_rules.HouseRules) { rules.DoubleForLess = true }, false, []cards.CardRank{cards.FIVE, cards.FIVE}, 1, 1, []strategy.PlayerDecision{S, H, D, U}},
+		{"five card charlie", func(rules *house_rules.HouseRules) { rules.BonusHands = []house_rules.BonusHand{house_rules.CreateCharlie(5)} }, false, []cards.CardRank{cards.TWO, cards.THREE, cards.TWO, cards.FOUR, cards.THREE}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S}},
+		{"six card charlie", func(rules *house_rules.HouseRules) { rules.BonusHands = []house_rules.BonusHand{house_rules.CreateCharlie(6)} }, false, []cards.CardRank{cards.TWO, cards.THREE, cards.TWO, cards.FOUR, cards.THREE}, 1, strategy.UNLIMITED_BANKROLL, []strategy.PlayerDecision{S, H}},
+		{"double for less broke", func(rules *house_rules.HouseRules) { rules.DoubleForLess = true }, false, []cards.CardRank{cards.FIVE, cards.FIVE}, 1, 0, []strategy.PlayerDecision{S, H, U}},
+	}
+
+	for i := 0; i < len(testCases); i++ {
+		testCase := testCases[i]
+		var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+		rules.Surrender = house_rules.SURRENDER_LATE
+		if testCase.rules != nil {
+			testCase.rules(rules)
+		}
+		var playerHand *game.PlayerHand = createLegalActionsHand(testCase.fromSplit, bet, testCase.ranks...)
+		var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(testCase.numHands)
+
+		var legalActions []strategy.PlayerDecision = strategy.LegalActions(playerHand, masterHand, rules, testCase.bankroll)
+		assert.Equal(t, testCase.expected, legalActions, "LegalActions() for %v", testCase.name)
+	}
+}
+
+func TestStrategiesPickLegalActions(t *testing.T) {
+	// every strategy, every rule combination, every two card hand against every dealer card
+	var dealerTopCard cards.Card
+	doubleDownOn := []house_rules.DoubleDownRule{
+		house_rules.DOUBLE_DOWN_ANY_TWO, house_rules.DOUBLE_DOWN_9_TO_11, house_rules.DOUBLE_DOWN_10_TO_11,
+	}
+	for combo := 0; combo < 256; combo++ {
+		var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+		rules.DoubleDownAfterSplit = combo&1 != 0
+		rules.HitSplitAces = combo&2 != 0
+		rules.Surrender = house_rules.SURRENDER_NONE
+		if combo&4 != 0 {
+			rules.Surrender = house_rules.SURRENDER_LATE
+		}
+		rules.SplitOnValueMatch = combo&8 != 0
+		rules.ResplitAces = combo&16 != 0
+		rules.DoubleDownAfterSplittingAces = combo&32 != 0
+		rules.DoubleDownSoftHands = combo&64 != 0
+		rules.DoubleDownAnyNumberOfCards = combo&128 != 0
+		rules.DoubleDownOn = doubleDownOn[combo%3]
+
+		for _, name := range strategy.PlayerStrategyNames() {
+			playerStrategy, err := strategy.CreatePlayerStrategy(name, rules)
+			assert.NoError(t, err, "strategy %v exists", name)
+
+			for i := cards.ACE; i <= cards.KING; i++ {
+				dealerTopCard = cards.Card{Suite: cards.HEARTS, Rank: i}
+				for j := cards.ACE; j <= cards.KING; j++ {
+					for k := cards.ACE; k <= cards.KING; k++ {
+						for _, fromSplit := range []bool{false, true} {
+							var playerHand *game.PlayerHand = createLegalActionsHand(fromSplit, 2, j, k)
+							var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(2)
+							var legalActions []strategy.PlayerDecision = strategy.LegalActions(
+								playerHand, masterHand, rules, strategy.UNLIMITED_BANKROLL,
+							)
+							var decision strategy.PlayerDecision = playerStrategy.DeterminePlay(dealerTopCard, playerHand, legalActions)
+							if !strategy.IsLegalAction(decision, legalActions) {
+								assert.Fail(t, "illegal decision", "%v played %v with %v, %v vs %v, legal %v", name, decision, j, k, i, legalActions)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGetEarlySurrenderDecision(t *testing.T) {
+	testCases := []struct {
+		card1    cards.CardRank
+		card2    cards.CardRank
+		dealer   cards.CardRank
+		expected bool
+	}{
+		{cards.TEN, cards.SIX, cards.ACE, true},
+		{cards.TEN, cards.SEVEN, cards.ACE, true},
+		{cards.EIGHT, cards.EIGHT, cards.ACE, true},
+		{cards.THREE, cards.THREE, cards.ACE, true},
+		{cards.TWO, cards.FOUR, cards.ACE, true},
+		{cards.TEN, cards.EIGHT, cards.ACE, false},
+		{cards.SIX, cards.FIVE, cards.ACE, false},
+		{cards.ACE, cards.SIX, cards.ACE, false},
+		{cards.ACE, cards.ACE, cards.ACE, false},
+		{cards.TEN, cards.SIX, cards.KING, true},
+		{cards.SEVEN, cards.SEVEN, cards.TEN, true},
+		{cards.TEN, cards.THREE, cards.TEN, false},
+		{cards.TEN, cards.SEVEN, cards.TEN, false},
+		{cards.TEN, cards.SIX, cards.NINE, false},
+	}
+	for i := 0; i < len(testCases); i++ {
+		testCase := testCases[i]
+		var playerHand *game.PlayerHand = createLegalActionsHand(false, 2, testCase.card1, testCase.card2)
+		assert.Equal(
+			t,
+			testCase.expected,
+			strategy.GetEarlySurrenderDecision(playerHand, testCase.dealer),
+			"early surrender %v, %v against %v", testCase.card1, testCase.card2, testCase.dealer,
+		)
+	}
+}
+
+func createDoubledDownHand(doubleDowns int, ranks ...cards.CardRank) *game.PlayerHand {
+	var playerHand *game.PlayerHand = createLegalActionsHand(false, 2, ranks...)
+	for i := 0; i < doubleDowns; i++ {
+		playerHand.DoubleDownBet += playerHand.Bet
+		playerHand.Bet *= 2
+	}
+	playerHand.DoubleDowns = doubleDowns
+	return playerHand
+}
+
+func TestLegalActionsAfterDoubleDown(t *testing.T) {
+	const (
+		S = strategy.STAND
+		D = strategy.DOUBLE
+		U = strategy.SURRENDER
+	)
+	var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(1)
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	var hand *game.PlayerHand = createDoubledDownHand(1, cards.FIVE, cards.SIX, cards.TWO)
+	assert.Equal(t, []strategy.PlayerDecision{S}, strategy.LegalActions(hand, masterHand, rules, strategy.UNLIMITED_BANKROLL), "the common game stands after doubling down")
+
+	rules.Redoubles = 1
+	rules.DoubleDownRescue = true
+	assert.Equal(t, []strategy.PlayerDecision{S, D, U}, strategy.LegalActions(hand, masterHand, rules, strategy.UNLIMITED_BANKROLL), "re-double or rescue")
+	assert.Equal(t, []strategy.PlayerDecision{S, U}, strategy.LegalActions(hand, masterHand, rules, hand.Bet-1), "cannot cover the re-double")
+
+	hand = createDoubledDownHand(2, cards.FIVE, cards.SIX, cards.TWO, cards.TWO)
+	assert.Equal(t, []strategy.PlayerDecision{S, U}, strategy.LegalActions(hand, masterHand, rules, strategy.UNLIMITED_BANKROLL), "no re-doubles left")
+
+	hand = createDoubledDownHand(1, cards.FIVE, cards.SIX, cards.KING)
+	assert.Equal(t, []strategy.PlayerDecision{S}, strategy.LegalActions(hand, masterHand, rules, strategy.UNLIMITED_BANKROLL), "nothing to do with 21")
+}
+
+func TestSpanish21Strategy(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateSpanish21Rules()
+	var spanish21 *strategy.Spanish21Strategy = strategy.CreateSpanish21Strategy(rules)
+	var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(1)
+	play := func(dealerRank cards.CardRank, playerHand *game.PlayerHand) strategy.PlayerDecision {
+		var legalActions []strategy.PlayerDecision = strategy.LegalActions(playerHand, masterHand, rules, strategy.UNLIMITED_BANKROLL)
+		return spanish21.DeterminePlay(cards.Card{Suite: cards.HEARTS, Rank: dealerRank}, playerHand, legalActions)
+	}
+
+	assert.Equal(t, strategy.STAND, play(cards.SIX, createLegalActionsHand(false, 2, cards.KING, cards.SIX)), "stand 16 vs 6")
+	assert.Equal(t, strategy.HIT, play(cards.TWO, createLegalActionsHand(false, 2, cards.TWO, cards.THREE, cards.FOUR, cards.TWO, cards.FIVE)), "hit a five card 16 vs 2")
+	assert.Equal(t, strategy.STAND, play(cards.TWO, createLegalActionsHand(false, 2, cards.SIX, cards.FOUR, cards.SIX)), "stand on a three card 16 vs 2")
+	assert.Equal(t, strategy.HIT, play(cards.EIGHT, createLegalActionsHand(false, 2, cards.SIX, cards.FOUR)), "no doubling 10 vs 8")
+	assert.Equal(t, strategy.DOUBLE, play(cards.ACE, createLegalActionsHand(false, 2, cards.SIX, cards.FIVE)), "double 11 vs Ace")
+	assert.Equal(t, strategy.HIT, play(cards.NINE, createLegalActionsHand(false, 2, cards.ACE, cards.SEVEN)), "hit soft 18 vs 9")
+	assert.Equal(t, strategy.SURRENDER, play(cards.ACE, createLegalActionsHand(false, 2, cards.KING, cards.SEVEN)), "surrender 17 vs Ace")
+	assert.Equal(t, strategy.SPLIT, play(cards.EIGHT, createLegalActionsHand(false, 2, cards.THREE, cards.THREE)), "split 3s vs 8")
+	assert.Equal(t, strategy.STAND, play(cards.SEVEN, createLegalActionsHand(false, 2, cards.NINE, cards.NINE)), "stand 9s vs 7")
+
+	assert.Equal(t, strategy.DOUBLE, play(cards.SIX, createDoubledDownHand(1, cards.THREE, cards.THREE, cards.FIVE)), "re-double a doubled 11")
+	assert.Equal(t, strategy.SURRENDER, play(cards.KING, createDoubledDownHand(1, cards.SIX, cards.FIVE, cards.TWO)), "rescue a doubled 13 vs King")
+	assert.Equal(t, strategy.STAND, play(cards.SIX, createDoubledDownHand(1, cards.SIX, cards.FIVE, cards.TWO)), "stand a doubled 13 vs 6")
+	assert.Equal(t, strategy.STAND, play(cards.KING, createDoubledDownHand(1, cards.SIX, cards.FIVE, cards.SEVEN)), "stand a doubled 18 vs King")
+}
+
+func TestStrategiesPlayDoubledDownHands(t *testing.T) {
+	// every strategy, every three card hand that was doubled down on, against every dealer card
+	var rules *house_rules.HouseRules = house_rules.CreateSpanish21Rules()
+	var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(1)
+	for _, name := range strategy.PlayerStrategyNames() {
+		playerStrategy, err := strategy.CreatePlayerStrategy(name, rules)
+		assert.NoError(t, err, "strategy %v exists", name)
+		for i := cards.ACE; i <= cards.KING; i++ {
+			var dealerTopCard cards.Card = cards.Card{Suite: cards.HEARTS, Rank: i}
+			for j := cards.ACE; j <= cards.KING; j++ {
+				for k := cards.ACE; k <= cards.KING; k++ {
+					for l := cards.ACE; l <= cards.KING; l++ {
+						var playerHand *game.PlayerHand = createDoubledDownHand(1, j, k, l)
+						var legalActions []strategy.PlayerDecision = strategy.LegalActions(
+							playerHand, masterHand, rules, strategy.UNLIMITED_BANKROLL,
+						)
+						if len(legalActions) == 0 {
+							// bust
+							continue
+						}
+						var decision strategy.PlayerDecision = playerStrategy.DeterminePlay(dealerTopCard, playerHand, legalActions)
+						if !strategy.IsLegalAction(decision, legalActions) {
+							assert.Fail(t, "illegal decision", "%v played %v with doubled %v, %v, %v vs %v, legal %v", name, decision, j, k, l, i, legalActions)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBlackjackSwitchStrategy(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateBlackjackSwitchRules()
+	var switchStrategy *strategy.BlackjackSwitchStrategy = strategy.CreateBlackjackSwitchStrategy(rules)
+	var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(1)
+	dealer := func(dealerRank cards.CardRank) cards.Card {
+		return cards.Card{Suite: cards.HEARTS, Rank: dealerRank}
+	}
+	play := func(dealerRank cards.CardRank, playerHand *game.PlayerHand) strategy.PlayerDecision {
+		var legalActions []strategy.PlayerDecision = strategy.LegalActions(playerHand, masterHand, rules, strategy.UNLIMITED_BANKROLL)
+		return switchStrategy.DeterminePlay(dealer(dealerRank), playerHand, legalActions)
+	}
+
+	// a dealer 22 push makes standing on a stiff worth less
+	assert.Equal(t, strategy.HIT, play(cards.FOUR, createLegalActionsHand(false, 2, cards.KING, cards.TWO)), "hit 12 vs 4")
+	assert.Equal(t, strategy.STAND, play(cards.SIX, createLegalActionsHand(false, 2, cards.KING, cards.TWO)), "stand 12 vs 6")
+	assert.Equal(t, strategy.HIT, play(cards.TEN, createLegalActionsHand(false, 2, cards.KING, cards.SIX)), "hit 16 vs 10")
+	assert.Equal(t, strategy.DOUBLE, play(cards.SIX, createLegalActionsHand(false, 2, cards.SIX, cards.FIVE)), "double 11 vs 6")
+	assert.Equal(t, strategy.HIT, play(cards.TEN, createLegalActionsHand(false, 2, cards.SIX, cards.FIVE)), "no doubling 11 vs 10")
+	assert.Equal(t, strategy.STAND, play(cards.TEN, createLegalActionsHand(false, 2, cards.KING, cards.QUEEN)), "stand 20")
+	assert.Equal(t, strategy.SPLIT, play(cards.SIX, createLegalActionsHand(false, 2, cards.EIGHT, cards.EIGHT)), "split 8s")
+
+	switchCards := func(dealerRank cards.CardRank, first []cards.CardRank, second []cards.CardRank) bool {
+		return switchStrategy.SwitchCards(
+			dealer(dealerRank),
+			createLegalActionsHand(false, 2, first...),
+			createLegalActionsHand(false, 2, second...),
+		)
+	}
+	assert.True(t, switchCards(cards.SIX, []cards.CardRank{cards.KING, cards.SIX}, []cards.CardRank{cards.FIVE, cards.KING}), "K-6 5-K => K-K 5-6")
+	assert.False(t, switchCards(cards.SIX, []cards.CardRank{cards.KING, cards.KING}, []cards.CardRank{cards.FIVE, cards.SIX}), "keep K-K 5-6")
+	assert.True(t, switchCards(cards.TEN, []cards.CardRank{cards.ACE, cards.SIX}, []cards.CardRank{cards.NINE, cards.KING}), "A-6 9-K => A-K 9-6")
+	assert.False(t, switchCards(cards.TEN, []cards.CardRank{cards.ACE, cards.KING}, []cards.CardRank{cards.NINE, cards.SIX}), "keep the natural")
+	assert.False(t, switchCards(cards.TEN, []cards.CardRank{cards.NINE, cards.TWO}, []cards.CardRank{cards.EIGHT, cards.TWO}), "the same hands either way")
+}
+
+func TestLegalActionsFreeBet(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateFreeBetRules()
+	var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(1)
+	legal := func(bankroll int, ranks ...cards.CardRank) []strategy.PlayerDecision {
+		return strategy.LegalActions(createLegalActionsHand(false, 2, ranks...), masterHand, rules, bankroll)
+	}
+
+	assert.Contains(t, legal(0, cards.SIX, cards.FIVE), strategy.DOUBLE, "a free double down needs no bankroll")
+	assert.NotContains(t, legal(0, cards.SIX, cards.TWO), strategy.DOUBLE, "8 is not free")
+	assert.NotContains(t, legal(0, cards.ACE, cards.EIGHT), strategy.DOUBLE, "soft 19 is not free")
+	assert.Contains(t, legal(0, cards.EIGHT, cards.EIGHT), strategy.SPLIT, "a free split needs no bankroll")
+	assert.NotContains(t, legal(0, cards.KING, cards.KING), strategy.SPLIT, "10s are not free")
+	assert.Contains(t, legal(2, cards.KING, cards.KING), strategy.SPLIT, "10s can be paid for")
+
+	var freeSplitHand *game.PlayerHand = createLegalActionsHand(true, 0, cards.SIX, cards.TWO)
+	freeSplitHand.FreeBet = 2
+	assert.Equal(t, 2, freeSplitHand.GetBet(), "the free bet counts towards the hand's bet")
+	assert.NotContains(t,
+		strategy.LegalActions(freeSplitHand, createLegalActionsMasterHand(2), rules, 1),
+		strategy.DOUBLE, "paying to double down a free split hand takes the whole bet")
+}
+
+func TestFreeBetStrategy(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateFreeBetRules()
+	var freeBet *strategy.FreeBetStrategy = strategy.CreateFreeBetStrategy(rules)
+	var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(1)
+	play := func(dealerRank cards.CardRank, ranks ...cards.CardRank) strategy.PlayerDecision {
+		var playerHand *game.PlayerHand = createLegalActionsHand(false, 2, ranks...)
+		var legalActions []strategy.PlayerDecision = strategy.LegalActions(playerHand, masterHand, rules, 0)
+		return freeBet.DeterminePlay(cards.Card{Suite: cards.HEARTS, Rank: dealerRank}, playerHand, legalActions)
+	}
+
+	assert.Equal(t, strategy.DOUBLE, play(cards.ACE, cards.FIVE, cards.FOUR), "a free double on 9 vs Ace")
+	assert.Equal(t, strategy.DOUBLE, play(cards.TEN, cards.FIVE, cards.FIVE), "double 5s rather than split them")
+	assert.Equal(t, strategy.SPLIT, play(cards.TEN, cards.FOUR, cards.FOUR), "a free split of 4s vs 10")
+	assert.Equal(t, strategy.SPLIT, play(cards.SIX, cards.ACE, cards.ACE), "a free split of Aces")
+	assert.Equal(t, strategy.STAND, play(cards.SIX, cards.KING, cards.KING), "stand on 10s")
+	assert.Equal(t, strategy.HIT, play(cards.FOUR, cards.KING, cards.TWO), "hit 12 vs 4, a dealer 22 pushes")
+}
+
+func TestDoubleExposureStrategy(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateDoubleExposureRules()
+	var doubleExposure *strategy.DoubleExposureStrategy = strategy.CreateDoubleExposureStrategy(rules)
+	var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(1)
+	play := func(dealerTop cards.CardRank, dealerHole cards.CardRank, ranks ...cards.CardRank) strategy.PlayerDecision {
+		var playerHand *game.PlayerHand = createLegalActionsHand(false, 2, ranks...)
+		var legalActions []strategy.PlayerDecision = strategy.LegalActions(playerHand, masterHand, rules, strategy.UNLIMITED_BANKROLL)
+		return doubleExposure.DeterminePlayWithHoleCard(
+			cards.Card{Suite: cards.HEARTS, Rank: dealerTop},
+			cards.Card{Suite: cards.CLUBS, Rank: dealerHole},
+			playerHand,
+			legalActions,
+		)
+	}
+
+	assert.Equal(t, strategy.STAND, play(cards.TEN, cards.SIX, cards.TEN, cards.SIX), "stand 16 vs a dealer 16")
+	assert.Equal(t, strategy.HIT, play(cards.TEN, cards.NINE, cards.TEN, cards.EIGHT), "hit 18 vs a dealer 19")
+	assert.Equal(t, strategy.HIT, play(cards.KING, cards.QUEEN, cards.TEN, cards.KING), "hit 20 vs a dealer 20, the dealer wins ties")
+	assert.Equal(t, strategy.STAND, play(cards.KING, cards.NINE, cards.TEN, cards.KING), "stand 20 vs a dealer 19")
+	assert.Equal(t, strategy.DOUBLE, play(cards.SIX, cards.SIX, cards.SIX, cards.FIVE), "double 11 vs a dealer 12")
+	assert.Equal(t, strategy.SPLIT, play(cards.SEVEN, cards.SIX, cards.TEN, cards.TEN), "split 10s vs a dealer 13")
+	assert.Equal(t, strategy.HIT, play(cards.TEN, cards.NINE, cards.ACE, cards.SEVEN), "hit soft 18 vs a dealer 19")
+	assert.Equal(t, strategy.HIT, play(cards.ACE, cards.SIX, cards.TEN, cards.SEVEN), "hit 17 vs a dealer soft 17, which the dealer hits")
+
+	var legalActions []strategy.PlayerDecision = []strategy.PlayerDecision{strategy.STAND, strategy.HIT}
+	assert.Equal(t, strategy.HIT,
+		doubleExposure.DeterminePlay(cards.Card{Suite: cards.HEARTS, Rank: cards.TEN}, createLegalActionsHand(false, 2, cards.TEN, cards.SIX), legalActions),
+		"basic strategy without the hole card")
+}
+
+func TestLegalActionsPontoon(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreatePontoonRules()
+	var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(1)
+	legal := func(ranks ...cards.CardRank) []strategy.PlayerDecision {
+		return strategy.LegalActions(createLegalActionsHand(false, 2, ranks...), masterHand, rules, strategy.UNLIMITED_BANKROLL)
+	}
+
+	assert.Equal(t, []strategy.PlayerDecision{strategy.HIT, strategy.DOUBLE}, legal(cards.TEN, cards.FOUR), "twist or buy on 14")
+	assert.Equal(t, []strategy.PlayerDecision{strategy.STAND, strategy.HIT, strategy.DOUBLE}, legal(cards.TEN, cards.FIVE), "stick on 15")
+	assert.Equal(t, []strategy.PlayerDecision{strategy.STAND, strategy.HIT, strategy.DOUBLE}, legal(cards.ACE, cards.FOUR), "soft 15 can stick")
+	assert.Equal(t, []strategy.PlayerDecision{strategy.HIT, strategy.DOUBLE}, legal(cards.TWO, cards.THREE, cards.FOUR), "buy on three cards")
+	assert.Equal(t, []strategy.PlayerDecision{strategy.STAND}, legal(cards.TWO, cards.THREE, cards.FOUR, cards.TWO, cards.ACE), "a five card trick is done")
+	assert.Equal(t, []strategy.PlayerDecision{}, legal(cards.TEN, cards.FOUR, cards.TWO, cards.THREE, cards.KING), "bust")
+
+	// a bought hand twists on up to 15, then stands wherever it ends up
+	assert.Equal(t, []strategy.PlayerDecision{strategy.HIT}, strategy.LegalActions(createDoubledDownHand(1, cards.TWO, cards.THREE, cards.FOUR), masterHand, rules, strategy.UNLIMITED_BANKROLL), "no sticking on a bought 9")
+	assert.Equal(t, []strategy.PlayerDecision{strategy.STAND}, strategy.LegalActions(createDoubledDownHand(1, cards.TWO, cards.THREE, cards.TEN), masterHand, rules, strategy.UNLIMITED_BANKROLL))
+
+	assert.True(t, strategy.IsFiveCardTrick(createLegalActionsHand(false, 2, cards.TWO, cards.THREE, cards.FOUR, cards.TWO, cards.ACE), rules))
+	assert.False(t, strategy.IsFiveCardTrick(createLegalActionsHand(false, 2, cards.TWO, cards.THREE, cards.FOUR, cards.TWO, cards.ACE), house_rules.CreateHouseRules()), "no tricks in blackjack")
+}
+
+func TestPontoonTerms(t *testing.T) {
+	assert.Equal(t, strategy.STICK, strategy.PontoonTerm(strategy.STAND))
+	assert.Equal(t, strategy.TWIST, strategy.PontoonTerm(strategy.HIT))
+	assert.Equal(t, strategy.BUY, strategy.PontoonTerm(strategy.DOUBLE))
+	assert.Equal(t, strategy.SPLIT, strategy.PontoonTerm(strategy.SPLIT))
+	for _, decision := range []strategy.PlayerDecision{strategy.STAND, strategy.HIT, strategy.DOUBLE, strategy.SPLIT, strategy.SURRENDER} {
+		assert.Equal(t, decision, strategy.FromPontoonTerm(strategy.PontoonTerm(decision)), "round trip %v", decision)
+		assert.Equal(t, decision, strategy.FromPontoonTerm(decision), "not a Pontoon word %v", decision)
+	}
+}
+
+func TestPontoonStrategy(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreatePontoonRules()
+	var pontoon *strategy.PontoonStrategy = strategy.CreatePontoonStrategy(rules)
+	var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(1)
+	play := func(dealerTop cards.CardRank, ranks ...cards.CardRank) strategy.PlayerDecision {
+		var playerHand *game.PlayerHand = createLegalActionsHand(false, 2, ranks...)
+		var legalActions []strategy.PlayerDecision = strategy.LegalActions(playerHand, masterHand, rules, strategy.UNLIMITED_BANKROLL)
+		return pontoon.DeterminePlay(cards.Card{Suite: cards.HEARTS, Rank: dealerTop}, playerHand, legalActions)
+	}
+
+	assert.Equal(t, strategy.HIT, play(cards.SIX, cards.TEN, cards.TWO), "12 has to twist")
+	assert.Equal(t, strategy.STAND, play(cards.SIX, cards.TEN, cards.FIVE), "stick on 15 vs 6")
+	assert.Equal(t, strategy.STAND, play(cards.TEN, cards.TEN, cards.KING), "stick on 20")
+	assert.Equal(t, strategy.DOUBLE, play(cards.SIX, cards.SIX, cards.FIVE), "buy on 11 vs 6")
+	assert.Equal(t, strategy.SPLIT, play(cards.SIX, cards.EIGHT, cards.EIGHT), "split 8s")
+	assert.Equal(t, strategy.STAND, play(cards.SEVEN, cards.TWO, cards.THREE, cards.FOUR, cards.TWO, cards.ACE), "a five card trick")
+}
+
+func TestExposedHoleCardStrategy(t *testing.T) {
+	var rules *house_rules.HouseRules = house_rules.CreateHouseRules()
+	var exposed *strategy.ExposedHoleCardStrategy = strategy.CreateExposedHoleCardStrategy(rules)
+	var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(1)
+	var dealerTen cards.Card = cards.Card{Suite: cards.HEARTS, Rank: cards.TEN}
+	hand := func(ranks ...cards.CardRank) (*game.PlayerHand, []strategy.PlayerDecision) {
+		var playerHand *game.PlayerHand = createLegalActionsHand(false, 2, ranks...)
+		return playerHand, strategy.LegalActions(playerHand, masterHand, rules, strategy.UNLIMITED_BANKROLL)
+	}
+	play := func(dealerTop cards.CardRank, dealerHole cards.CardRank, ranks ...cards.CardRank) strategy.PlayerDecision {
+		playerHand, legalActions := hand(ranks...)
+		return exposed.DeterminePlayWithHoleCard(
+			cards.Card{Suite: cards.HEARTS, Rank: dealerTop},
+			cards.Card{Suite: cards.CLUBS, Rank: dealerHole},
+			playerHand,
+			legalActions,
+		)
+	}
+	tell := func(holeCardIsTen bool, ranks ...cards.CardRank) strategy.PlayerDecision {
+		playerHand, legalActions := hand(ranks...)
+		return exposed.DeterminePlayWithTell(dealerTen, holeCardIsTen, playerHand, legalActions)
+	}
+
+	assert.Equal(t, strategy.STAND, play(cards.TEN, cards.SIX, cards.TEN, cards.SIX), "stand 16 vs a dealer 16")
+	assert.Equal(t, strategy.HIT, play(cards.TEN, cards.KING, cards.TEN, cards.FIVE, cards.TWO), "hit 17 vs a dealer 20")
+	assert.Equal(t, strategy.DOUBLE, play(cards.SIX, cards.SIX, cards.SIX, cards.FIVE), "double 11 vs a dealer 12")
+	assert.Equal(t, strategy.SPLIT, play(cards.SEVEN, cards.SIX, cards.TEN, cards.TEN), "split 10s vs a dealer 13")
+
+	assert.Equal(t, strategy.STAND, tell(false, cards.TEN, cards.SIX), "stand 16 vs 10 when the hole card is small")
+	assert.Equal(t, strategy.SURRENDER, tell(true, cards.TEN, cards.SIX), "give 16 up vs a dealer 20")
+	assert.Equal(t, strategy.SPLIT, tell(false, cards.NINE, cards.NINE), "split 9s vs 10 when the hole card is small")
+	assert.Equal(t, strategy.HIT, tell(true, cards.SIX, cards.FIVE), "no doubling 11 vs a dealer 20")
+
+	playerHand, legalActions := hand(cards.TEN, cards.SIX)
+	assert.Equal(t, strategy.SURRENDER, exposed.DeterminePlay(dealerTen, playerHand, legalActions), "basic strategy without a look")
+}
+
+func TestStrategiesPickLegalActionsInEveryGame(t *testing.T) {
+	var masterHand *game.PlayerMasterHand = createLegalActionsMasterHand(2)
+	for _, gameName := range house_rules.GameNames() {
+		rules, _ := house_rules.CreateGameRules(gameName)
+		for _, name := range strategy.PlayerStrategyNames() {
+			playerStrategy, _ := strategy.CreatePlayerStrategy(name, rules)
+			for i := cards.ACE; i <= cards.KING; i++ {
+				var dealerTopCard cards.Card = cards.Card{Suite: cards.HEARTS, Rank: i}
+				for j := cards.ACE; j <= cards.KING; j++ {
+					for k := cards.ACE; k <= cards.KING; k++ {
+						var playerHand *game.PlayerHand = createLegalActionsHand(false, 2, j, k)
+						var legalActions []strategy.PlayerDecision = strategy.LegalActions(playerHand, masterHand, rules, strategy.UNLIMITED_BANKROLL)
+						var decision strategy.PlayerDecision = playerStrategy.DeterminePlay(dealerTopCard, playerHand, legalActions)
+						if !strategy.IsLegalAction(decision, legalActions) {
+							assert.Fail(t, "illegal decision", "%v played %v in %v with %v, %v vs %v, legal %v", name, decision, gameName, j, k, i, legalActions)
+						}
+					}
+				}
+			}
+		}
+	}
+}